@@ -0,0 +1,255 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"github.com/appcrash/media/server/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"sync"
+	"time"
+)
+
+// default periods of Config
+const (
+	DefaultKeepAlivePeriod = 2 * time.Second // server closes system channel after missing 3 keepalives
+	DefaultReconnectDelay  = time.Second
+)
+
+const eventBufferSize = 64
+
+type Config struct {
+	Address    string // grpc endpoint of media server, i.e. "127.0.0.1:5678"
+	InstanceId string // identity in system channel, sessions are prepared by it and its events are sent to it
+	// Tls connects with TLS, plaintext if nil
+	Tls *tls.Config
+	// Token is sent as bearer token if server requires authentication, only allowed over TLS
+	Token string
+
+	KeepAlivePeriod time.Duration
+	ReconnectDelay  time.Duration
+	// SessionInfoPeriod reports alive sessions prepared by this client so that watchdog won't stop them, zero
+	// means not reporting
+	SessionInfoPeriod time.Duration
+	// NoSystemChannel only calls api without registering, for tools inspecting sessions of other instances.
+	// instance id is optional then and no event is received
+	NoSystemChannel bool
+
+	DialOptions []grpc.DialOption
+}
+
+// Client calls MediaApi as an instance, it keeps system channel registered until closed
+type Client struct {
+	config *Config
+	conn   *grpc.ClientConn
+	api    rpc.MediaApiClient
+
+	events chan *rpc.SystemEvent
+	cancel context.CancelFunc
+	doneC  chan struct{}
+
+	mutex    sync.Mutex
+	sessions map[string]*Session // sessions prepared by this client and not stopped yet
+}
+
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// Dial connects to media server and registers the instance in system channel
+func Dial(config *Config) (*Client, error) {
+	if config.InstanceId == "" && !config.NoSystemChannel {
+		return nil, errors.New("client requires instance id")
+	}
+	c := *config
+	if c.KeepAlivePeriod <= 0 {
+		c.KeepAlivePeriod = DefaultKeepAlivePeriod
+	}
+	if c.ReconnectDelay <= 0 {
+		c.ReconnectDelay = DefaultReconnectDelay
+	}
+	var opts []grpc.DialOption
+	if c.Tls != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(c.Tls)))
+	} else {
+		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+	if c.Token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials(c.Token)))
+	}
+	conn, err := grpc.NewClient(c.Address, append(opts, c.DialOptions...)...)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &Client{
+		config:   &c,
+		conn:     conn,
+		api:      rpc.NewMediaApiClient(conn),
+		events:   make(chan *rpc.SystemEvent, eventBufferSize),
+		cancel:   cancel,
+		doneC:    make(chan struct{}),
+		sessions: make(map[string]*Session),
+	}
+	if c.NoSystemChannel {
+		close(client.doneC)
+	} else {
+		go client.systemChannelLoop(ctx)
+	}
+	return client, nil
+}
+
+// Api returns the raw client for calls not wrapped
+func (c *Client) Api() rpc.MediaApiClient {
+	return c.api
+}
+
+func (c *Client) InstanceId() string {
+	return c.config.InstanceId
+}
+
+// Events returns system events sent to the instance except keepalive, such as dtmf, terminated and recovered
+// sessions. events are dropped if not read in time
+func (c *Client) Events() <-chan *rpc.SystemEvent {
+	return c.events
+}
+
+// Close unregisters from system channel and closes connection, sessions are not stopped
+func (c *Client) Close() error {
+	c.cancel()
+	<-c.doneC
+	return c.conn.Close()
+}
+
+// Prepare creates a session, instance id of param is set to the client's one
+func (c *Client) Prepare(ctx context.Context, param *rpc.CreateParam) (*Session, error) {
+	param.InstanceId = c.config.InstanceId
+	info, err := c.api.PrepareSession(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	s := &Session{client: c, id: info.SessionId, info: info}
+	c.mutex.Lock()
+	c.sessions[s.id] = s
+	c.mutex.Unlock()
+	return s, nil
+}
+
+// Session returns handle of an existing session, i.e. prepared before client restarts
+func (c *Client) Session(id string) *Session {
+	return &Session{client: c, id: id}
+}
+
+func (c *Client) forgetSession(id string) {
+	c.mutex.Lock()
+	delete(c.sessions, id)
+	c.mutex.Unlock()
+}
+
+func (c *Client) aliveSessions() (ids []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for id := range c.sessions {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// systemChannelLoop registers the instance and keeps it alive, re-register after delay if channel breaks
+func (c *Client) systemChannelLoop(ctx context.Context) {
+	defer close(c.doneC)
+	for {
+		if err := c.runSystemChannel(ctx); err != nil && ctx.Err() == nil {
+			logger.Warnf("instance(%v) system channel breaks, reconnect later: %v", c.config.InstanceId, err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(c.config.ReconnectDelay):
+		}
+	}
+}
+
+func (c *Client) runSystemChannel(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.api.SystemChannel(ctx)
+	if err != nil {
+		return err
+	}
+	send := func(cmd rpc.SystemCommand, sessionId string) error {
+		return stream.Send(&rpc.SystemEvent{Cmd: cmd, InstanceId: c.config.InstanceId, SessionId: sessionId})
+	}
+	if err = send(rpc.SystemCommand_REGISTER, ""); err != nil {
+		return err
+	}
+
+	recvErrC := make(chan error, 1)
+	go func() {
+		for {
+			se, err := stream.Recv()
+			if err != nil {
+				recvErrC <- err
+				return
+			}
+			c.onSystemEvent(se)
+		}
+	}()
+	keepalive := time.NewTicker(c.config.KeepAlivePeriod)
+	defer keepalive.Stop()
+	var sessionInfo <-chan time.Time
+	if c.config.SessionInfoPeriod > 0 {
+		ticker := time.NewTicker(c.config.SessionInfoPeriod)
+		defer ticker.Stop()
+		sessionInfo = ticker.C
+	}
+	for {
+		select {
+		case <-keepalive.C:
+			err = send(rpc.SystemCommand_KEEPALIVE, "")
+		case <-sessionInfo:
+			for _, id := range c.aliveSessions() {
+				if err = send(rpc.SystemCommand_SESSION_INFO, id); err != nil {
+					break
+				}
+			}
+		case err = <-recvErrC:
+			return err
+		case <-ctx.Done():
+			stream.CloseSend()
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) onSystemEvent(se *rpc.SystemEvent) {
+	switch se.Cmd {
+	case rpc.SystemCommand_KEEPALIVE:
+		return
+	case rpc.SystemCommand_SESSION_TERMINATED:
+		c.forgetSession(se.SessionId)
+	case rpc.SystemCommand_SESSION_RECOVERED:
+		// server restarted with the session kept, report it alive again
+		c.mutex.Lock()
+		if _, exist := c.sessions[se.SessionId]; !exist {
+			c.sessions[se.SessionId] = c.Session(se.SessionId)
+		}
+		c.mutex.Unlock()
+	}
+	select {
+	case c.events <- se:
+	default:
+		logger.Warnf("instance(%v) drops system event %v as events are not read in time", c.config.InstanceId, se)
+	}
+}
@@ -0,0 +1,191 @@
+package client_test
+
+import (
+	"context"
+	"github.com/appcrash/media/client"
+	"github.com/appcrash/media/server"
+	"github.com/appcrash/media/server/channel"
+	"github.com/appcrash/media/server/comp"
+	"github.com/appcrash/media/server/rpc"
+	"github.com/appcrash/media/server/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"os"
+	"testing"
+	"time"
+)
+
+const (
+	grpcIp   = "127.0.0.1"
+	grpcPort = 5683
+)
+
+var address = "127.0.0.1:5683"
+
+// echo is a minimal rtp provider and consumer, every session requires one
+type echo struct {
+	comp.SessionNode
+	comp.ChannelNode
+
+	channel chan *utils.RtpPacketList
+}
+
+func (n *echo) PullPacketChannel() <-chan *utils.RtpPacketList {
+	return n.channel
+}
+
+func (n *echo) HandlePacketChannel() chan<- *utils.RtpPacketList {
+	return n.channel
+}
+
+func TestMain(m *testing.M) {
+	comp.InitBuiltIn()
+	comp.RegisterNodeTrait(comp.NT[echo]("echo", func() comp.SessionAware {
+		n := &echo{channel: make(chan *utils.RtpPacketList, 32)}
+		n.Trait, _ = comp.NodeTraitOfType("echo")
+		return n
+	}))
+	start, _, err := server.NewGrpcServer(&server.Config{
+		RtpIp:     "127.0.0.1",
+		StartPort: 23000,
+		EndPort:   24000,
+		GrpcIp:    grpcIp,
+		GrpcPort:  grpcPort,
+	})
+	if err != nil {
+		panic(err)
+	}
+	go start()
+	time.Sleep(500 * time.Millisecond)
+	os.Exit(m.Run())
+}
+
+func dial(t *testing.T, config *client.Config) *client.Client {
+	config.Address = address
+	c, err := client.Dial(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestSession(t *testing.T) {
+	c := dial(t, &client.Config{InstanceId: "sdk_session"})
+	ctx := context.Background()
+	session, err := c.Prepare(ctx, &rpc.CreateParam{
+		PeerIp:    "127.0.0.1",
+		PeerPort:  2000,
+		Codecs:    []*rpc.CodecInfo{{PayloadNumber: 8, PayloadType: rpc.CodecType_PCM_ALAW}},
+		GraphDesc: "[ep:echo];[src:chan_src] -> [sink:chan_sink]",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.Info().LocalRtpPort == 0 {
+		t.Fatalf("wrong session info: %v", session.Info())
+	}
+	if err = session.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	pullCtx, cancelPull := context.WithCancel(ctx)
+	defer cancelPull()
+	pullC, err := session.PullStream(pullCtx, "sink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pushC, errC, err := session.PushStream(ctx, "src")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// pull stream links to sink asynchronously, push until data comes out
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	timeout := time.After(3 * time.Second)
+pull:
+	for {
+		select {
+		case <-ticker.C:
+			pushC <- []byte("hello")
+		case data := <-pullC:
+			if string(data) != "hello" {
+				t.Fatalf("pull wrong data: %s", data)
+			}
+			break pull
+		case <-timeout:
+			t.Fatal("no data pulled from sink")
+		}
+	}
+	close(pushC)
+	if err = <-errC; err != nil {
+		t.Fatalf("push stream error: %v", err)
+	}
+
+	result, err := session.Exec(ctx, "[nobody] <-> 'hi'")
+	if err != nil || result.Ok || len(result.Replies) != 1 {
+		t.Fatalf("call to nonexistent node should fail: %v %v", result, err)
+	}
+	if err = session.Stop(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.Session(session.Id()).Stop(ctx); status.Code(err) != codes.NotFound {
+		t.Fatalf("stop stopped session should fail: %v", err)
+	}
+}
+
+func TestSystemChannelReconnect(t *testing.T) {
+	instanceId := "sdk_reconnect"
+	// keepalive is slower than server's timeout, so server closes the system channel and client re-registers
+	c := dial(t, &client.Config{
+		InstanceId:      instanceId,
+		KeepAlivePeriod: channel.KeepAliveTimeout + channel.KeepAliveCheckDuration,
+		ReconnectDelay:  100 * time.Millisecond,
+	})
+	sc := channel.GetSystemChannel()
+	notify := func(event string) *rpc.SystemEvent {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if err := sc.NotifyInstance(&rpc.SystemEvent{InstanceId: instanceId, Event: event}); err == nil {
+				break
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		select {
+		case se := <-c.Events():
+			return se
+		case <-time.After(time.Second):
+			t.Fatalf("event %v is not received", event)
+			return nil
+		}
+	}
+	if se := notify("before"); se.Event != "before" {
+		t.Fatalf("wrong event: %v", se)
+	}
+	// wait until server times out the instance, then the client reconnects on next keepalive
+	time.Sleep(channel.KeepAliveTimeout + 2*channel.KeepAliveCheckDuration)
+	if se := notify("after"); se.Event != "after" {
+		t.Fatalf("wrong event: %v", se)
+	}
+}
+
+func TestNoSystemChannel(t *testing.T) {
+	c := dial(t, &client.Config{NoSystemChannel: true})
+	ctx := context.Background()
+	if _, err := c.Api().GetVersion(ctx, &rpc.Empty{}); err != nil {
+		t.Fatal(err)
+	}
+	if list, err := c.Api().ListSessions(ctx, &rpc.ListSessionsParam{InstanceId: "sdk_session"}); err != nil {
+		t.Fatal(err)
+	} else {
+		for _, s := range list.Sessions {
+			if s.InstanceId != "sdk_session" {
+				t.Fatalf("wrong session: %v", s)
+			}
+		}
+	}
+	if _, err := client.Dial(&client.Config{Address: address}); err == nil {
+		t.Fatal("instance id is required with system channel")
+	}
+}
@@ -0,0 +1,13 @@
+package client
+
+import "github.com/sirupsen/logrus"
+
+var logger *logrus.Entry
+
+func init() {
+	InitLogger(logrus.New())
+}
+
+func InitLogger(gl *logrus.Logger) {
+	logger = gl.WithFields(logrus.Fields{"module": "client"})
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"github.com/appcrash/media/server/rpc"
+	"io"
+)
+
+// commands of the built-in executor of media server
+const (
+	cmdExec       = "exec"
+	cmdPullStream = "pull_stream"
+	cmdPushStream = "push_stream"
+)
+
+const streamBufferSize = 32
+
+// Session is the handle of a session in media server
+type Session struct {
+	client *Client
+	id     string
+	info   *rpc.Session // nil if handle is made by Client.Session
+}
+
+func (s *Session) Id() string {
+	return s.id
+}
+
+// Info returns what PrepareSession returned, local ports of streams are in it
+func (s *Session) Info() *rpc.Session {
+	return s.info
+}
+
+func (s *Session) Start(ctx context.Context) error {
+	_, err := s.client.api.StartSession(ctx, &rpc.StartParam{SessionId: s.id})
+	return err
+}
+
+// Update changes peer address, codec or srtp keys of session, session id of param is set to this session
+func (s *Session) Update(ctx context.Context, param *rpc.UpdateParam) error {
+	param.SessionId = s.id
+	_, err := s.client.api.UpdateSession(ctx, param)
+	return err
+}
+
+func (s *Session) Stop(ctx context.Context) error {
+	_, err := s.client.api.StopSession(ctx, &rpc.StopParam{SessionId: s.id})
+	if err == nil {
+		s.client.forgetSession(s.id)
+	}
+	return err
+}
+
+// Exec runs nmd call/cast script in session graph, i.e. "[player] <-- 'play file.wav'", replies of calls are
+// in the result
+func (s *Session) Exec(ctx context.Context, script string) (*rpc.ActionResult, error) {
+	return s.client.api.ExecuteAction(ctx, &rpc.Action{SessionId: s.id, Cmd: cmdExec, CmdArg: script})
+}
+
+// PullStream receives data sent to the chan_sink node of session graph, the returned channel is closed when
+// ctx is done or stream ends
+func (s *Session) PullStream(ctx context.Context, nodeName string) (<-chan []byte, error) {
+	stream, err := s.client.api.ExecuteActionWithNotify(ctx, &rpc.Action{
+		SessionId: s.id,
+		Cmd:       cmdPullStream,
+		CmdArg:    "<-chan " + nodeName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	dataC := make(chan []byte, streamBufferSize)
+	go func() {
+		defer close(dataC)
+		for {
+			evt, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF && ctx.Err() == nil {
+					logger.Errorf("session(%v) pull stream of %v error: %v", s.id, nodeName, err)
+				}
+				return
+			}
+			select {
+			case dataC <- []byte(evt.Event):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return dataC, nil
+}
+
+// PushStream sends data written to dataC to the chan_src node of session graph, close dataC to end the stream,
+// then the result is sent to errC
+func (s *Session) PushStream(ctx context.Context, nodeName string) (dataC chan<- []byte, errC <-chan error, err error) {
+	stream, err := s.client.api.ExecuteActionWithPush(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the first packet tells server where to push
+	if err = stream.Send(&rpc.PushData{SessionId: s.id, Cmd: cmdPushStream, NodeName: nodeName}); err != nil {
+		return nil, nil, err
+	}
+	inC, resultC := make(chan []byte, streamBufferSize), make(chan error, 1)
+	go func() {
+		defer close(resultC)
+		for data := range inC {
+			if err := stream.Send(&rpc.PushData{Data: data}); err != nil {
+				// the real error comes with CloseAndRecv
+				break
+			}
+		}
+		_, err := stream.CloseAndRecv()
+		resultC <- err
+		// stream may end before dataC closed, discard the rest so that writer never blocks
+		for range inC {
+		}
+	}()
+	return inC, resultC, nil
+}
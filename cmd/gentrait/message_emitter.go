@@ -217,7 +217,7 @@ func msgEmitConvertableMappingFunc(w *bufio.Writer) {
 		nbConversion += len(i.convertedTo)
 	}
 	if nbConversion > 0 {
-		w.Write([]byte(fmt.Sprintf("m := %v", _V("SetMessageConvertable"))))
+		w.Write([]byte(fmt.Sprintf("m := %v\n", _V("SetMessageConvertable"))))
 	} else {
 		return
 	}
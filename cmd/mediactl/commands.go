@@ -0,0 +1,357 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/appcrash/media/client"
+	"github.com/appcrash/media/server/rpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+)
+
+func printJson(m proto.Message) error {
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Printf("%s\n", data)
+	return err
+}
+
+func newTable() *tabwriter.Writer {
+	return tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+}
+
+func formatTime(ms int64) string {
+	if ms == 0 {
+		return "-"
+	}
+	return time.UnixMilli(ms).Format(time.RFC3339)
+}
+
+func formatStreams(streams []*rpc.StreamInfo) string {
+	var s []string
+	for _, stream := range streams {
+		str := fmt.Sprintf("%v:%v->%v:%v", stream.Name, stream.LocalRtpPort, stream.PeerIp, stream.PeerRtpPort)
+		if stream.Direction != rpc.MediaDirection_SENDRECV {
+			str += "(" + strings.ToLower(stream.Direction.String()) + ")"
+		}
+		s = append(s, str)
+	}
+	return strings.Join(s, ",")
+}
+
+// parseStatus accepts both "started" and "SESSION_STARTED"
+func parseStatus(list string) (status []rpc.SessionStatus, err error) {
+	if list == "" {
+		return
+	}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToUpper(strings.TrimSpace(name))
+		if !strings.HasPrefix(name, "SESSION_") {
+			name = "SESSION_" + name
+		}
+		v, ok := rpc.SessionStatus_value[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown session status %v", name)
+		}
+		status = append(status, rpc.SessionStatus(v))
+	}
+	return
+}
+
+func sessionsCmd(c *client.Client, args []string) error {
+	if len(args) == 0 {
+		return usageError{}
+	}
+	ctx, cancel := rpcContext()
+	defer cancel()
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("sessions list", flag.ExitOnError)
+		instanceId := fs.String("instance", "", "only sessions of this instance")
+		statusList := fs.String("status", "", "only sessions of these status, separated by comma")
+		fs.Parse(args[1:])
+		status, err := parseStatus(*statusList)
+		if err != nil {
+			return err
+		}
+		list, err := c.Api().ListSessions(ctx, &rpc.ListSessionsParam{InstanceId: *instanceId, Status: status})
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			return printJson(list)
+		}
+		w := newTable()
+		fmt.Fprintln(w, "SESSION\tINSTANCE\tSTATUS\tCREATED\tLOCAL IP\tSTREAMS")
+		for _, s := range list.Sessions {
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n", s.SessionId, s.InstanceId, s.Status, formatTime(s.CreateTime),
+				s.LocalIp, formatStreams(s.Streams))
+		}
+		return w.Flush()
+	case "get":
+		if len(args) != 2 {
+			return usageError{}
+		}
+		info, err := c.Api().GetSession(ctx, &rpc.GetSessionParam{SessionId: args[1]})
+		if err != nil {
+			return err
+		}
+		if jsonOut {
+			return printJson(info)
+		}
+		printSessionInfo(info)
+		return nil
+	case "stop":
+		if len(args) != 2 {
+			return usageError{}
+		}
+		if err := c.Session(args[1]).Stop(ctx); err != nil {
+			return err
+		}
+		if jsonOut {
+			return printJson(&rpc.Status{Status: "ok"})
+		}
+		fmt.Printf("session %v stopped\n", args[1])
+		return nil
+	}
+	return usageError{}
+}
+
+func printSessionInfo(info *rpc.SessionInfo) {
+	w := newTable()
+	fmt.Fprintf(w, "session:\t%v\n", info.SessionId)
+	fmt.Fprintf(w, "instance:\t%v\n", info.InstanceId)
+	fmt.Fprintf(w, "status:\t%v\n", info.Status)
+	if info.Status == rpc.SessionStatus_SESSION_STOPPED {
+		fmt.Fprintf(w, "stop reason:\t%v\n", info.StopReason)
+	}
+	fmt.Fprintf(w, "created:\t%v\n", formatTime(info.CreateTime))
+	fmt.Fprintf(w, "local ip:\t%v\n", info.LocalIp)
+	for _, s := range info.Streams {
+		var codecs []string
+		for _, codec := range s.Codecs {
+			codecs = append(codecs, fmt.Sprintf("%v/%v", codec.PayloadType, codec.PayloadNumber))
+		}
+		fmt.Fprintf(w, "stream %v:\tlocal %v, peer %v:%v, %v, payload %v, codecs %v\n", s.Name, s.LocalRtpPort,
+			s.PeerIp, s.PeerRtpPort, strings.ToLower(s.Direction.String()), s.PayloadNumber, strings.Join(codecs, " "))
+	}
+	if d := info.Detail; d != nil {
+		if wd := d.Watchdog; wd != nil {
+			fmt.Fprintf(w, "instance alive:\t%v\n", formatTime(wd.InstanceAliveTime))
+			fmt.Fprintf(w, "send alive:\t%v\n", formatTime(wd.SendAliveTime))
+			fmt.Fprintf(w, "receive alive:\t%v\n", formatTime(wd.ReceiveAliveTime))
+			fmt.Fprintf(w, "rtcp alive:\t%v\n", formatTime(wd.RtcpAliveTime))
+			fmt.Fprintf(w, "errors:\t%v\n", wd.Errors)
+		}
+		w.Flush()
+		fmt.Printf("\ngraph:\n%v\n", d.GraphDesc)
+		if len(d.Nodes) > 0 {
+			fmt.Println()
+			w = newTable()
+			fmt.Fprintln(w, "NODE\tTYPE\tSTREAM")
+			for _, n := range d.Nodes {
+				fmt.Fprintf(w, "%v\t%v\t%v\n", n.Name, n.Type, n.Stream)
+			}
+		}
+	}
+	w.Flush()
+}
+
+func execCmd(c *client.Client, args []string) error {
+	if len(args) != 2 {
+		return usageError{}
+	}
+	ctx, cancel := rpcContext()
+	defer cancel()
+	result, err := c.Session(args[0]).Exec(ctx, args[1])
+	if err != nil {
+		return err
+	}
+	if jsonOut {
+		err = printJson(result)
+	} else {
+		for _, reply := range result.Replies {
+			state := "ok"
+			if !reply.Ok {
+				state = "err"
+			}
+			fmt.Println(strings.Join(append([]string{state}, reply.Args...), " "))
+		}
+	}
+	if err == nil && !result.Ok {
+		err = errors.New("some calls failed")
+	}
+	return err
+}
+
+// interruptContext is done by ctrl-c, streams run until then
+func interruptContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+func pullCmd(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("pull", flag.ExitOnError)
+	output := fs.String("o", "", "write to file instead of stdout")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		return usageError{}
+	}
+	out := os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+	ctx, cancel := interruptContext()
+	defer cancel()
+	dataC, err := c.Session(fs.Arg(0)).PullStream(ctx, fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	for data := range dataC {
+		if _, err = out.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pushCmd(c *client.Client, args []string) error {
+	fs := flag.NewFlagSet("push", flag.ExitOnError)
+	chunk := fs.Int("chunk", 1024, "bytes of each push")
+	interval := fs.Duration("interval", 0, "pause between pushes, i.e. 20ms for pacing audio")
+	fs.Parse(args)
+	if fs.NArg() != 2 || *chunk <= 0 {
+		return usageError{}
+	}
+	ctx, cancel := interruptContext()
+	defer cancel()
+	dataC, errC, err := c.Session(fs.Arg(0)).PushStream(ctx, fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	var total int
+	for ctx.Err() == nil {
+		buf := make([]byte, *chunk)
+		n, rerr := io.ReadFull(os.Stdin, buf)
+		if n > 0 {
+			dataC <- buf[:n]
+			total += n
+		}
+		if rerr == io.EOF || rerr == io.ErrUnexpectedEOF {
+			break
+		}
+		if rerr != nil {
+			close(dataC)
+			return rerr
+		}
+		if *interval > 0 {
+			time.Sleep(*interval)
+		}
+	}
+	close(dataC)
+	if err = <-errC; err != nil {
+		return err
+	}
+	if !jsonOut {
+		fmt.Fprintf(os.Stderr, "pushed %v bytes\n", total)
+	}
+	return nil
+}
+
+func capsCmd(c *client.Client, args []string) error {
+	if len(args) != 0 {
+		return usageError{}
+	}
+	ctx, cancel := rpcContext()
+	defer cancel()
+	caps, err := c.Api().DescribeCapabilities(ctx, &rpc.Empty{})
+	if err != nil {
+		return err
+	}
+	if jsonOut {
+		return printJson(caps)
+	}
+	w := newTable()
+	fmt.Fprintln(w, "NODE TYPE\tACCEPT\tOFFER\tPROPERTIES")
+	for _, nt := range caps.NodeTypes {
+		var props []string
+		for _, p := range nt.Properties {
+			props = append(props, p.Name+":"+p.Type)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n", nt.Name, strings.Join(nt.Accept, ","), strings.Join(nt.Offer, ","),
+			strings.Join(props, " "))
+	}
+	w.Flush()
+
+	fmt.Println()
+	w = newTable()
+	fmt.Fprintln(w, "MESSAGE TYPE\tID\tCONVERTS TO")
+	conversions := make(map[string][]string)
+	for _, conv := range caps.Conversions {
+		conversions[conv.From] = append(conversions[conv.From], conv.To)
+	}
+	for _, mt := range caps.MessageTypes {
+		to := conversions[mt.Name]
+		sort.Strings(to)
+		fmt.Fprintf(w, "%v\t%v\t%v\n", mt.Name, mt.TypeId, strings.Join(to, ","))
+	}
+	w.Flush()
+
+	var codecs []string
+	for _, codec := range caps.Codecs {
+		codecs = append(codecs, codec.String())
+	}
+	fmt.Printf("\ncodecs: %v\n", strings.Join(codecs, " "))
+	return nil
+}
+
+func validateCmd(c *client.Client, args []string) error {
+	if len(args) != 1 {
+		return usageError{}
+	}
+	var gd []byte
+	var err error
+	if args[0] == "-" {
+		gd, err = io.ReadAll(os.Stdin)
+	} else {
+		gd, err = os.ReadFile(args[0])
+	}
+	if err != nil {
+		return err
+	}
+	ctx, cancel := rpcContext()
+	defer cancel()
+	result, err := c.Api().ValidateGraph(ctx, &rpc.ValidateGraphParam{GraphDesc: string(gd)})
+	if err != nil {
+		return err
+	}
+	if jsonOut {
+		err = printJson(result)
+	} else if result.Ok {
+		fmt.Println("ok")
+	} else {
+		for _, d := range result.Diagnostics {
+			fmt.Printf("%v:%v:%v: %v\n", args[0], d.Line, d.Column, d.Message)
+		}
+	}
+	if err == nil && !result.Ok {
+		err = errors.New("graph is invalid")
+	}
+	return err
+}
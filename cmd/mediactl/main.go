@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/appcrash/media/client"
+	"google.golang.org/grpc/status"
+	"os"
+	"time"
+)
+
+var (
+	address    string
+	useTls     bool
+	caFile     string
+	token      string
+	jsonOut    bool
+	rpcTimeout time.Duration
+)
+
+type command struct {
+	usage string
+	run   func(c *client.Client, args []string) error
+}
+
+var commands = map[string]command{
+	"sessions": {"sessions list [-instance id] [-status created,started,stopped] | get <session> | stop <session>", sessionsCmd},
+	"exec":     {"exec <session> \"<nmd script>\"", execCmd},
+	"pull":     {"pull [-o file] <session> <chan_sink node>", pullCmd},
+	"push":     {"push [-chunk bytes] [-interval duration] <session> <chan_src node> < file", pushCmd},
+	"caps":     {"caps", capsCmd},
+	"validate": {"validate <graph.nmd | ->", validateCmd},
+}
+
+var commandOrder = []string{"sessions", "exec", "pull", "push", "caps", "validate"}
+
+func init() {
+	flag.StringVar(&address, "addr", "127.0.0.1:5678", "grpc address of media server")
+	flag.BoolVar(&useTls, "tls", false, "connect with tls")
+	flag.StringVar(&caFile, "ca", "", "ca certificate to verify server, system pool if empty")
+	flag.StringVar(&token, "token", "", "bearer token if server requires authentication")
+	flag.BoolVar(&jsonOut, "json", false, "print json instead of text")
+	flag.DurationVar(&rpcTimeout, "timeout", 10*time.Second, "timeout of unary calls")
+	flag.Usage = usage
+}
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), "usage: mediactl [flags] <command> [args]\n\ncommands:\n")
+	for _, name := range commandOrder {
+		fmt.Fprintf(flag.CommandLine.Output(), "  %v\n", commands[name].usage)
+	}
+	fmt.Fprintf(flag.CommandLine.Output(), "\nflags:\n")
+	flag.PrintDefaults()
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	cmd, ok := commands[flag.Arg(0)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "mediactl: unknown command %v\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+	c, err := dial()
+	if err == nil {
+		err = cmd.run(c, flag.Args()[1:])
+		c.Close()
+	}
+	if err != nil {
+		var ue usageError
+		if errors.As(err, &ue) {
+			fmt.Fprintf(os.Stderr, "usage: mediactl %v\n", cmd.usage)
+			os.Exit(2)
+		}
+		if st, ok := status.FromError(err); ok {
+			err = fmt.Errorf("%v: %v", st.Code(), st.Message())
+		}
+		fmt.Fprintf(os.Stderr, "mediactl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// usageError makes main print usage of the command
+type usageError struct{}
+
+func (usageError) Error() string {
+	return "wrong usage"
+}
+
+func dial() (*client.Client, error) {
+	config := &client.Config{
+		Address:         address,
+		Token:           token,
+		NoSystemChannel: true,
+	}
+	if useTls || caFile != "" {
+		config.Tls = &tls.Config{}
+		if caFile != "" {
+			pem, err := os.ReadFile(caFile)
+			if err != nil {
+				return nil, err
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificate found in %v", caFile)
+			}
+			config.Tls.RootCAs = pool
+		}
+	}
+	return client.Dial(config)
+}
+
+func rpcContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), rpcTimeout)
+}
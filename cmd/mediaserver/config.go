@@ -0,0 +1,184 @@
+package main
+
+import (
+	"fmt"
+	"github.com/appcrash/media/codec"
+	"github.com/appcrash/media/server"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+	"os"
+	"time"
+)
+
+type Config struct {
+	Rtp      RtpConfig      `yaml:"rtp"`
+	Grpc     GrpcConfig     `yaml:"grpc"`
+	Log      LogConfig      `yaml:"log"`
+	Watchdog WatchdogConfig `yaml:"watchdog"`
+	Limits   LimitConfig    `yaml:"limits"`
+	Admin    AdminConfig    `yaml:"admin"`
+	Store    StoreConfig    `yaml:"store"`
+	// DrainTimeout is how long graceful stop waits for sessions to end
+	DrainTimeout time.Duration `yaml:"drain_timeout"`
+}
+
+type RtpConfig struct {
+	Ip        string `yaml:"ip"`
+	StartPort uint16 `yaml:"start_port"`
+	EndPort   uint16 `yaml:"end_port"`
+}
+
+type GrpcConfig struct {
+	Ip     string        `yaml:"ip"`
+	Port   uint16        `yaml:"port"`
+	Tls    *TlsConfig    `yaml:"tls"`
+	Tokens []TokenConfig `yaml:"tokens"`
+}
+
+type TlsConfig struct {
+	CertFile     string `yaml:"cert_file"`
+	KeyFile      string `yaml:"key_file"`
+	ClientCaFile string `yaml:"client_ca_file"`
+}
+
+// TokenConfig is a bearer token accepted by grpc endpoint, the caller can only access sessions of the instances, or
+// any instance if they include "*"
+type TokenConfig struct {
+	Token     string   `yaml:"token"`
+	Name      string   `yaml:"name"`
+	Instances []string `yaml:"instances"`
+}
+
+type LogConfig struct {
+	Level string `yaml:"level"`
+	// FFLevel is log level of ffmpeg: quiet,panic,fatal,error,warning,info,verbose,debug,trace
+	FFLevel string `yaml:"ff_level"`
+}
+
+type WatchdogConfig struct {
+	AuditPeriod   time.Duration `yaml:"audit_period"`
+	TimeoutPeriod time.Duration `yaml:"timeout_period"`
+	// policies of sessions not setting their own, 0 disables the policy except inactivity_timeout which takes
+	// timeout_period
+	InactivityTimeout time.Duration `yaml:"inactivity_timeout"`
+	OneWayTimeout     time.Duration `yaml:"one_way_timeout"`
+	MaxDuration       time.Duration `yaml:"max_duration"`
+	HeartbeatTimeout  time.Duration `yaml:"heartbeat_timeout"`
+}
+
+type LimitConfig struct {
+	MaxSessions            int `yaml:"max_sessions"`
+	MaxSessionsPerInstance int `yaml:"max_sessions_per_instance"`
+	MaxGraphNodes          int `yaml:"max_graph_nodes"`
+	MaxGraphLinks          int `yaml:"max_graph_links"`
+	MaxTranscodeContexts   int `yaml:"max_transcode_contexts"`
+}
+
+type AdminConfig struct {
+	// Address serves /metrics, /debug/pprof, graph inspection and /drain, empty means disabled. draining requires
+	// a token of "*" if auth is enabled
+	Address string `yaml:"address"`
+}
+
+type StoreConfig struct {
+	// Dir keeps alive sessions to recover them after restart, empty means disabled
+	Dir string `yaml:"dir"`
+}
+
+var ffLogLevels = map[string]int{
+	"quiet":   codec.AV_LOG_QUIET,
+	"panic":   codec.AV_LOG_PANIC,
+	"fatal":   codec.AV_LOG_FATAL,
+	"error":   codec.AV_LOG_ERROR,
+	"warning": codec.AV_LOG_WARNING,
+	"info":    codec.AV_LOG_INFO,
+	"verbose": codec.AV_LOG_VERBOSE,
+	"debug":   codec.AV_LOG_DEBUG,
+	"trace":   codec.AV_LOG_TRACE,
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		Rtp:          RtpConfig{Ip: "127.0.0.1", StartPort: 10000, EndPort: 20000},
+		Grpc:         GrpcConfig{Ip: "0.0.0.0", Port: 5678},
+		Log:          LogConfig{Level: "info", FFLevel: "error"},
+		DrainTimeout: 30 * time.Second,
+	}
+}
+
+// loadConfig reads config file over the default config, so only changed items need to be written
+func loadConfig(file string) (*Config, error) {
+	c := defaultConfig()
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("parse config file %v error: %v", file, err)
+	}
+	if err = c.validate(); err != nil {
+		return nil, fmt.Errorf("invalid config file %v: %v", file, err)
+	}
+	return c, nil
+}
+
+func (c *Config) validate() error {
+	if c.Rtp.StartPort >= c.Rtp.EndPort {
+		return fmt.Errorf("rtp port range [%v,%v) is empty", c.Rtp.StartPort, c.Rtp.EndPort)
+	}
+	if _, err := logrus.ParseLevel(c.Log.Level); err != nil {
+		return err
+	}
+	if _, ok := ffLogLevels[c.Log.FFLevel]; !ok {
+		return fmt.Errorf("unknown ffmpeg log level: %v", c.Log.FFLevel)
+	}
+	return nil
+}
+
+// applyLogLevel takes effect immediately, it is called again when config is reloaded
+func (c *Config) applyLogLevel(l *logrus.Logger) {
+	level, _ := logrus.ParseLevel(c.Log.Level)
+	l.SetLevel(level)
+	codec.SetFFLogLevel(ffLogLevels[c.Log.FFLevel])
+}
+
+func (c *Config) serverConfig() (*server.Config, error) {
+	sc := &server.Config{
+		RtpIp:     c.Rtp.Ip,
+		StartPort: c.Rtp.StartPort,
+		EndPort:   c.Rtp.EndPort,
+		GrpcIp:    c.Grpc.Ip,
+		GrpcPort:  c.Grpc.Port,
+		Watchdog: server.WatchdogConfig{
+			AuditPeriod:   c.Watchdog.AuditPeriod,
+			TimeoutPeriod: c.Watchdog.TimeoutPeriod,
+			Policy: server.WatchdogPolicy{
+				InactivityTimeout: c.Watchdog.InactivityTimeout,
+				OneWayTimeout:     c.Watchdog.OneWayTimeout,
+				MaxDuration:       c.Watchdog.MaxDuration,
+				HeartbeatTimeout:  c.Watchdog.HeartbeatTimeout,
+			},
+		},
+		Limits:       server.LimitConfig(c.Limits),
+		AdminAddress: c.Admin.Address,
+		DrainTimeout: c.DrainTimeout,
+	}
+	if t := c.Grpc.Tls; t != nil {
+		sc.Tls = &server.TlsConfig{CertFile: t.CertFile, KeyFile: t.KeyFile, ClientCaFile: t.ClientCaFile}
+	}
+	if len(c.Grpc.Tokens) > 0 {
+		tokens := make(server.StaticTokenAuthenticator)
+		for _, t := range c.Grpc.Tokens {
+			tokens[t.Token] = &server.Principal{Name: t.Name, Instances: t.Instances}
+		}
+		sc.Authenticator = tokens
+	}
+	if c.Store.Dir != "" {
+		store, err := server.NewFileSessionStore(c.Store.Dir)
+		if err != nil {
+			return nil, fmt.Errorf("open session store error: %v", err)
+		}
+		sc.Store = store
+	}
+	return sc, nil
+}
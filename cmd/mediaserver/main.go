@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"github.com/appcrash/media/codec"
+	"github.com/appcrash/media/server"
+	"github.com/appcrash/media/server/comp"
+	"github.com/appcrash/media/server/prom"
+	"github.com/sirupsen/logrus"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var configFile string
+
+var log = logrus.New()
+
+func init() {
+	flag.StringVar(&configFile, "c", "mediaserver.yaml", "config file")
+}
+
+func main() {
+	flag.Parse()
+	c, err := loadConfig(configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	c.applyLogLevel(log)
+	server.InitServerLogger(log)
+	codec.InitCodecLogger(log)
+	comp.InitBuiltIn()
+
+	if c.Admin.Address != "" {
+		prom.InitCollector()
+	}
+
+	sc, err := c.serverConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	start, stop, err := server.NewGrpcServer(sc)
+	if err != nil {
+		log.Fatal(err)
+	}
+	errC := make(chan error, 1)
+	go func() {
+		errC <- start()
+	}()
+
+	sigC := make(chan os.Signal, 1)
+	signal.Notify(sigC, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
+	for {
+		select {
+		case err = <-errC:
+			if err != nil {
+				log.Fatalf("grpc endpoint exits: %v", err)
+			}
+			return
+		case sig := <-sigC:
+			if sig == syscall.SIGHUP {
+				reload(c)
+				continue
+			}
+			log.Infof("received %v, stop gracefully", sig)
+			stop()
+			return
+		}
+	}
+}
+
+// reload applies log levels of config file, other items require restart
+func reload(c *Config) {
+	nc, err := loadConfig(configFile)
+	if err != nil {
+		log.Errorf("reload config failed, keep the current one: %v", err)
+		return
+	}
+	log.Infof("reload log level: %v, ffmpeg: %v", nc.Log.Level, nc.Log.FFLevel)
+	nc.applyLogLevel(log)
+	c.Log = nc.Log
+}
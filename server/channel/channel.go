@@ -100,21 +100,44 @@ func (sc *Channel) HasInstance(name string) (exist bool) {
 	return
 }
 
+// ReceiveFromInstance NONBLOCK forwards event received from instance to channel, returns false if the instance
+// state is closed, i.e. timed out or replaced by re-registered one
+func (sc *Channel) ReceiveFromInstance(is *InstanceState, se *rpc.SystemEvent) bool {
+	// hold mutex as state is closed with it held, sending to closed channel panics
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	if is.FromInstanceC == nil {
+		return false
+	}
+	select {
+	case is.FromInstanceC <- se:
+	default:
+		logger.Errorf("server channel: instance %v sends too fast, drop event %v", is.name, se.Cmd)
+	}
+	return true
+}
+
+// ToInstance returns the channel of events sent to instance, nil if the instance state is closed
+func (sc *Channel) ToInstance(is *InstanceState) chan *rpc.SystemEvent {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	return is.ToInstanceC
+}
+
 // NotifyInstance NONBLOCK send event to instance
 func (sc *Channel) NotifyInstance(se *rpc.SystemEvent) (err error) {
 	if se.InstanceId == "" {
 		return fmt.Errorf("invalid instance id when notifying instance")
 	}
 	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
 	if is, exist := sc.instanceStateMap[se.InstanceId]; exist {
-		sc.mutex.Unlock()
 		select {
 		case is.ToInstanceC <- se:
 		default:
 			err = fmt.Errorf("server channel: send to instance %v failed", se.InstanceId)
 		}
 	} else {
-		sc.mutex.Unlock()
 		err = fmt.Errorf("server channel: no such instance %v when send to instance", se.InstanceId)
 	}
 	return
@@ -122,13 +145,9 @@ func (sc *Channel) NotifyInstance(se *rpc.SystemEvent) (err error) {
 
 // BroadcastInstance NONBLOCK send event to all instances
 func (sc *Channel) BroadcastInstance(se *rpc.SystemEvent) (err error) {
-	var instances []*InstanceState
 	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
 	for _, is := range sc.instanceStateMap {
-		instances = append(instances, is)
-	}
-	sc.mutex.Unlock()
-	for _, is := range instances {
 		select {
 		case is.ToInstanceC <- se:
 		default:
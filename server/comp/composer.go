@@ -42,6 +42,10 @@ type Composer struct {
 	initiator  CommandInitiator
 	linkPoints []LinkPoint
 	nodeExited bool // ensure node UnInit called only once
+
+	limits           *ComposeLimits
+	linkCharged      int // links reserved from budget of limits
+	transcodeCharged int // transcode contexts acquired from budget of limits
 }
 
 func NewSessionComposer(sessionId, instanceId string) *Composer {
@@ -214,6 +218,9 @@ func (c *Composer) ComposeNodes(graph *event.Graph) (err error) {
 			c.ExitGraph()
 		}
 	}()
+	if err = c.checkTopoLimits(nodeDefs); err != nil {
+		return
+	}
 
 	// create node instances
 	for _, n := range nodeDefs {
@@ -251,6 +258,9 @@ func (c *Composer) ComposeNodes(graph *event.Graph) (err error) {
 		nodeIds = append(nodeIds, id)
 	}
 
+	if err = c.chargeTranscode(); err != nil {
+		return
+	}
 	if err = c.preConnectNodes(); err != nil {
 		return
 	}
@@ -276,6 +286,7 @@ func (c *Composer) ExitGraph() {
 	if c.nodeExited {
 		return
 	}
+	c.refundLimits()
 	for _, n := range c.nodeSortedList {
 		utils.AopCall(n, nil, unInitializingNodeType, "UnInit")
 	}
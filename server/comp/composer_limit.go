@@ -0,0 +1,138 @@
+package comp
+
+import (
+	"fmt"
+	"github.com/appcrash/media/server/comp/nmd"
+	"sync"
+)
+
+// resources restricted by ComposeLimits
+const (
+	LimitNodes             = "nodes"
+	LimitLinks             = "links"
+	LimitTranscodeContexts = "transcode_contexts"
+)
+
+// ComposeLimits restricts resources taken by a session graph, zero value means unlimited
+type ComposeLimits struct {
+	MaxNodes int // nodes of one session graph
+	// Links is the budget of links shared by all sessions, nil means unlimited
+	Links *Budget
+	// Transcode is the budget of transcode contexts shared by all sessions, nil means unlimited
+	Transcode *Budget
+}
+
+// TranscodeNode is implemented by nodes holding codec transcode contexts, which are charged to the transcode
+// budget when composing and refunded when the graph exits
+type TranscodeNode interface {
+	TranscodeContexts() int
+}
+
+// LimitError tells which limit is exceeded by composing a graph
+type LimitError struct {
+	Resource string
+	Limit    int
+	Request  int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("graph requires %v %v exceeding the limit %v", e.Request, e.Resource, e.Limit)
+}
+
+// Budget is a quota of resource shared by sessions, concurrent composers reserve from it atomically
+type Budget struct {
+	mutex     sync.Mutex
+	used, max int
+}
+
+func NewBudget(max int) *Budget {
+	return &Budget{max: max}
+}
+
+// Acquire takes n from budget, returns false if the rest of budget is not enough
+func (b *Budget) Acquire(n int) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.used+n > b.max {
+		return false
+	}
+	b.used += n
+	return true
+}
+
+func (b *Budget) Release(n int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.used -= n
+}
+
+// Used returns taken budget and the max
+func (b *Budget) Used() (used, limit int) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.used, b.max
+}
+
+// SetLimits must be called before ComposeNodes
+func (c *Composer) SetLimits(limits *ComposeLimits) {
+	c.limits = limits
+}
+
+// checkTopoLimits checks nodes and reserves links to be created before any node instance is made
+func (c *Composer) checkTopoLimits(nodeDefs []*nmd.NodeDef) error {
+	if c.limits == nil {
+		return nil
+	}
+	if limit := c.limits.MaxNodes; limit > 0 && len(nodeDefs) > limit {
+		return &LimitError{Resource: LimitNodes, Limit: limit, Request: len(nodeDefs)}
+	}
+	// one link for each preferred offer, or only one
+	var nbLink int
+	for _, n := range nodeDefs {
+		for _, dep := range n.Deps {
+			nbLink += max(len(dep.PreferOffer), 1)
+		}
+	}
+	var err error
+	c.linkCharged, err = charge(c.limits.Links, LimitLinks, nbLink)
+	return err
+}
+
+// chargeTranscode acquires transcode contexts of all created nodes from budget
+func (c *Composer) chargeTranscode() (err error) {
+	if c.limits == nil {
+		return nil
+	}
+	var n int
+	for _, node := range c.nodeSortedList {
+		if tn, ok := node.(TranscodeNode); ok {
+			n += tn.TranscodeContexts()
+		}
+	}
+	c.transcodeCharged, err = charge(c.limits.Transcode, LimitTranscodeContexts, n)
+	return
+}
+
+// refundLimits gives back what composer reserved from budgets
+func (c *Composer) refundLimits() {
+	if c.linkCharged > 0 {
+		c.limits.Links.Release(c.linkCharged)
+		c.linkCharged = 0
+	}
+	if c.transcodeCharged > 0 {
+		c.limits.Transcode.Release(c.transcodeCharged)
+		c.transcodeCharged = 0
+	}
+}
+
+// charge acquires n from budget, returns the charged amount
+func charge(b *Budget, resource string, n int) (int, error) {
+	if b == nil || n == 0 {
+		return 0, nil
+	}
+	if !b.Acquire(n) {
+		used, limit := b.Used()
+		return 0, &LimitError{Resource: resource, Limit: limit, Request: used + n}
+	}
+	return n, nil
+}
@@ -2,9 +2,14 @@ package comp_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"github.com/appcrash/media/server/comp"
 	"github.com/appcrash/media/server/event"
+	"github.com/appcrash/media/server/rpc"
+	"github.com/appcrash/media/server/utils"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -115,6 +120,28 @@ func newPrintHeaderNode() comp.SessionAware {
 	return p
 }
 
+type dtmfPrintNode struct {
+	comp.SessionNode
+}
+
+func (n *dtmfPrintNode) Accept() []comp.MessageType {
+	return []comp.MessageType{comp.MtDtmf}
+}
+
+func (n *dtmfPrintNode) handleDtmfEvent(evt *event.Event) {
+	if msg, ok := comp.EventToMessage[*comp.DtmfMessage](evt); ok {
+		fmt.Printf("%v print dtmf %c %v\n", n.GetNodeName(), msg.Digit, msg.Duration)
+	}
+}
+
+func newDtmfPrintNode() comp.SessionAware {
+	p := &dtmfPrintNode{}
+	p.Self = p
+	p.Trait, _ = comp.NodeTraitOfType("dtmf_print")
+	p.SetMessageHandler(comp.MtDtmf, comp.ChainSetHandler(p.handleDtmfEvent))
+	return p
+}
+
 type fakeGateway struct {
 	comp.SessionNode
 }
@@ -140,6 +167,21 @@ func newFakeGatewayNode() comp.SessionAware {
 	return n
 }
 
+type transcoderNode struct {
+	comp.SessionNode
+}
+
+func (n *transcoderNode) TranscodeContexts() int {
+	return 1
+}
+
+func newTranscoderNode() comp.SessionAware {
+	n := &transcoderNode{}
+	n.Self = n
+	n.Trait, _ = comp.NodeTraitOfType("transcoder")
+	return n
+}
+
 func initComposer() {
 	comp.AddMessageTrait(comp.MT[customMessage](comp.MetaType[customMessageConvertable]()))
 	comp.SetMessageConvertable(mtCustom, comp.MtRawByte)
@@ -147,6 +189,8 @@ func initComposer() {
 	comp.RegisterNodeTrait(comp.NT[printHeaderNode]("print_header", newPrintHeaderNode))
 	comp.RegisterNodeTrait(comp.NT[fireNode]("fire", newFireNode))
 	comp.RegisterNodeTrait(comp.NT[fakeGateway]("fake_gateway", newFakeGatewayNode))
+	comp.RegisterNodeTrait(comp.NT[dtmfPrintNode]("dtmf_print", newDtmfPrintNode))
+	comp.RegisterNodeTrait(comp.NT[transcoderNode]("transcoder", newTranscoderNode))
 }
 
 func composeIt(session, gd string) (*comp.Composer, error) {
@@ -222,6 +266,50 @@ func TestChanSrcSink(t *testing.T) {
 	}
 }
 
+func TestRtpSrcSink(t *testing.T) {
+	gd := "[src:rtp_src] -> [sink:rtp_sink]"
+	c, e := composeIt("test_session", gd)
+	if e != nil {
+		t.Fatal(e)
+	}
+	i := c.GetNode("src").(*comp.RtpSrc)
+	o := c.GetNode("sink").(*comp.RtpSink)
+	pl := &utils.RtpPacketList{Payload: []byte("rtp payload"), PayloadType: 8}
+	i.HandlePacketChannel() <- pl
+	select {
+	case got := <-o.PullPacketChannel():
+		if bytes.Compare(got.Payload, pl.Payload) != 0 || got.PayloadType != pl.PayloadType {
+			t.Fatal("send/recv rtp packet not equal")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("rtp packet not received by sink")
+	}
+	c.ExitGraph()
+	select {
+	case _, more := <-o.PullPacketChannel():
+		if more {
+			t.Fatal("sink channel should be closed after exiting graph")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("sink channel not closed after exiting graph")
+	}
+}
+
+func TestRtpSrcSinkStream(t *testing.T) {
+	gd := "[src:rtp_src stream=video] -> [sink:rtp_sink]"
+	c, e := composeIt("test_session", gd)
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer c.ExitGraph()
+	if stream := c.GetNode("src").(*comp.RtpSrc).MediaStream(); stream != "video" {
+		t.Fatalf("src should bind to video stream: %v", stream)
+	}
+	if stream := c.GetNode("sink").(*comp.RtpSink).MediaStream(); stream != "" {
+		t.Fatalf("sink should bind to default stream: %v", stream)
+	}
+}
+
 func Example_messagePostProcessor() {
 	gd := `[input:chan_src trackable=true] -> [pubsub] -> [p1:print_header];`
 	c, err := composeIt("test_session", gd)
@@ -237,6 +325,19 @@ func Example_messagePostProcessor() {
 	// p1 print origin input
 }
 
+func Example_dtmfSrc() {
+	gd := `[input:dtmf_src] -> [p1:dtmf_print]`
+	c, err := composeIt("test_session", gd)
+	if err != nil {
+		panic(err)
+	}
+	c.GetNode("input").(*comp.DtmfSrc).HandleDtmfChannel() <- &comp.DtmfMessage{Digit: '#', Duration: 100 * time.Millisecond}
+	time.Sleep(50 * time.Millisecond)
+
+	// Output:
+	// p1 print dtmf # 100ms
+}
+
 func Example_composerPubSub() {
 	gd := `[input:chan_src] -> [pubsub] -> {[p1:print], [ps:pubsub]};
           [ps] -> {[p2:print], [p3:print],[output:chan_sink]}`
@@ -344,3 +445,214 @@ func Example_composerBuiltInCommand() {
 	// p1 print fire in the hole
 	// p2 print fire in the hole
 }
+
+func TestDtmfGen(t *testing.T) {
+	gd := "[input:dtmf_src] -> [gen:dtmf_gen]"
+	c, err := composeIt("test_session", gd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.ExitGraph()
+	initiator := c.GetCommandInitiator()
+	gen := c.GetNode("gen").(*comp.DtmfGen)
+	for _, cmd := range []string{"send_digits", "send_digits 12x", "send_digits 1 duration=5", "send_digits 1 foo=1"} {
+		args, _ := comp.WithString(cmd)
+		if resp := initiator.Call("", "gen", args); resp[0] != "err" {
+			t.Fatalf("command %v should fail", cmd)
+		}
+	}
+	args, _ := comp.WithString(`send_digits "1a#" duration=200`)
+	if resp := initiator.Call("", "gen", args); resp[0] != "ok" {
+		t.Fatalf("send_digits failed: %v", resp)
+	}
+	// digits from graph are forwarded as well
+	c.GetNode("input").(*comp.DtmfSrc).HandleDtmfChannel() <- &comp.DtmfMessage{Digit: '9', Duration: time.Second}
+	var digits []byte
+	for i := 0; i < 4; i++ {
+		select {
+		case msg := <-gen.PullDtmfChannel():
+			if i < 3 && msg.Duration != 200*time.Millisecond {
+				t.Fatalf("wrong duration: %v", msg.Duration)
+			}
+			digits = append(digits, msg.Digit)
+		case <-time.After(time.Second):
+			t.Fatal("dtmf_gen has not enough digits")
+		}
+	}
+	if string(digits) != "1A#9" {
+		t.Fatalf("wrong digits %v", string(digits))
+	}
+}
+
+func TestHoldGate(t *testing.T) {
+	gd := "[music:chan_src] -> [moh:hold_gate] -> [output:chan_sink];[dir:direction_src] -> [moh]"
+	c, err := composeIt("test_session", gd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.ExitGraph()
+	inputC, outputC := make(chan []byte, 1), make(chan []byte, 1)
+	c.GetNode("music").(*comp.ChanSrc).LinkMe(inputC)
+	c.GetNode("output").(*comp.ChanSink).LinkMe(outputC)
+	directionC := c.GetNode("dir").(*comp.DirectionSrc).HandleMediaDirectionChannel()
+	expectMusic := func(playing bool) {
+		t.Helper()
+		// direction and music go through different nodes, keep feeding until gate reacts
+		deadline := time.After(time.Second)
+		for {
+			inputC <- []byte("music")
+			select {
+			case <-outputC:
+				if !playing {
+					t.Fatal("music should not be played")
+				}
+				return
+			case <-time.After(50 * time.Millisecond):
+			case <-deadline:
+				if playing {
+					t.Fatal("music should be played")
+				}
+				return
+			}
+		}
+	}
+
+	expectMusic(false)
+	directionC <- &comp.MediaDirectionMessage{Stream: "audio", Direction: rpc.MediaDirection_SENDONLY}
+	expectMusic(true)
+	directionC <- &comp.MediaDirectionMessage{Stream: "audio", Direction: rpc.MediaDirection_SENDRECV}
+	time.Sleep(100 * time.Millisecond)
+	for len(outputC) > 0 {
+		<-outputC
+	}
+	expectMusic(false)
+}
+
+func TestNodeTraitDescription(t *testing.T) {
+	nt, _ := comp.NodeTraitOfType("rtp_src")
+	accept, offer := nt.MessageTypes()
+	if len(accept) != 0 || len(offer) != 1 || offer[0] != comp.MtRtpPacket {
+		t.Fatalf("wrong message types of rtp_src: %v %v", accept, offer)
+	}
+	props := nt.Properties()
+	if len(props) != 1 || props[0].Name != "stream" || props[0].Type != "string" {
+		t.Fatalf("wrong properties of rtp_src: %v", props)
+	}
+	found := false
+	comp.VisitMessageConversion(func(from, to comp.MessageType) {
+		found = found || from == mtCustom && to == comp.MtRawByte
+	})
+	if !found {
+		t.Fatal("conversion from custom message to raw byte not visited")
+	}
+}
+
+func TestValidateGraph(t *testing.T) {
+	for _, gd := range []string{
+		`[input:chan_src] -> [pubsub] <raw_byte> {[output1:chan_sink],[output2:chan_sink]};`,
+		`[f:fire] -> [p:print]`,
+		`[r:rtp_src stream=audio] -> [s:rtp_sink stream=audio]`,
+		`[input:chan_src trackable=true] -> [pubsub] -> [p1:print_header];`,
+	} {
+		if diags := comp.ValidateGraph(gd); len(diags) != 0 {
+			t.Fatalf("graph %v should be valid: %v", gd, diags)
+		}
+	}
+	for _, c := range []struct {
+		gd           string
+		line, column int
+	}{
+		{`[a:nope] -> [p:print]`, 1, 2},
+		{`[r:rtp_src stream=audio bad=1]`, 1, 25},
+		{"[f:fire]\n -> [d:dtmf_print]", 2, 2},
+		{`[f:fire] <rtp_packet> [p:print]`, 1, 10},
+		{`[a -> [b]`, 1, 4},
+	} {
+		diags := comp.ValidateGraph(c.gd)
+		if len(diags) != 1 || diags[0].Line != c.line || diags[0].Column != c.column {
+			t.Fatalf("graph %v should be invalid at %v:%v: %v", c.gd, c.line, c.column, diags)
+		}
+	}
+}
+
+func TestComposeLimits(t *testing.T) {
+	graph := event.NewEventGraph()
+	links, transcode := comp.NewBudget(1), comp.NewBudget(2)
+	limits := &comp.ComposeLimits{MaxNodes: 2, Links: links, Transcode: transcode}
+	compose := func(session, gd string) (*comp.Composer, error) {
+		c := comp.NewSessionComposer(session, "")
+		if err := c.ParseGraphDescription(gd); err != nil {
+			t.Fatal(err)
+		}
+		c.SetLimits(limits)
+		return c, c.ComposeNodes(graph)
+	}
+	expectLimit := func(err error, resource string) {
+		t.Helper()
+		var le *comp.LimitError
+		if !errors.As(err, &le) || le.Resource != resource {
+			t.Fatalf("expect %v limit error but got: %v", resource, err)
+		}
+	}
+	expectRefunded := func(b *comp.Budget) {
+		t.Helper()
+		if used, _ := b.Used(); used != 0 {
+			t.Fatalf("budget should be refunded, but %v are used", used)
+		}
+	}
+
+	_, err := compose("limit_nodes", "[a:chan_src] -> [b:chan_sink];[c:chan_sink]")
+	expectLimit(err, comp.LimitNodes)
+	_, err = compose("limit_links", "[a:chan_src] <raw_byte,raw_byte> [b:chan_sink]")
+	expectLimit(err, comp.LimitLinks)
+	expectRefunded(links)
+
+	// links are shared by sessions
+	c1, err := compose("limit_links1", "[a:chan_src] -> [b:chan_sink]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = compose("limit_links2", "[a:chan_src] -> [b:chan_sink]")
+	expectLimit(err, comp.LimitLinks)
+	c1.ExitGraph()
+	expectRefunded(links)
+
+	// so are transcode contexts
+	c2, err := compose("limit_transcode1", "[t1:transcoder];[t2:transcoder]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = compose("limit_transcode2", "[t:transcoder]")
+	expectLimit(err, comp.LimitTranscodeContexts)
+	c2.ExitGraph()
+	expectRefunded(transcode)
+	if _, err = compose("limit_transcode3", "[t:transcoder]"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestComposeLimitsConcurrently(t *testing.T) {
+	graph := event.NewEventGraph()
+	limits := &comp.ComposeLimits{Links: comp.NewBudget(5)}
+	var wg sync.WaitGroup
+	var composed atomic.Int32
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := comp.NewSessionComposer(fmt.Sprintf("limit_concurrent%v", i), "")
+			if err := c.ParseGraphDescription("[a:chan_src] -> [b:chan_sink]"); err != nil {
+				t.Error(err)
+				return
+			}
+			c.SetLimits(limits)
+			if c.ComposeNodes(graph) == nil {
+				composed.Add(1)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if n := composed.Load(); n != 5 {
+		t.Fatalf("links are reserved atomically, only 5 sessions can be composed but got %v", n)
+	}
+}
@@ -0,0 +1,122 @@
+package comp
+
+import (
+	"errors"
+	"fmt"
+	"github.com/appcrash/media/server/comp/nmd"
+	"slices"
+)
+
+// GraphDiagnostic is a problem of graph description, Line and Column are 0 if it can not be located
+type GraphDiagnostic struct {
+	nmd.Position
+	Message string
+}
+
+func (d *GraphDiagnostic) String() string {
+	return fmt.Sprintf("%v: %v", d.Position, d.Message)
+}
+
+// ValidateGraph checks graph description as composer would do, but nodes are not added to any event graph. links
+// are negotiated statically by Accept() and Offer(), so links to or from nodes that negotiate message types by
+// themselves(i.e. pubsub) are not checked
+func ValidateGraph(desc string) (diags []*GraphDiagnostic) {
+	report := func(pos nmd.Position, format string, args ...interface{}) {
+		diags = append(diags, &GraphDiagnostic{Position: pos, Message: fmt.Sprintf(format, args...)})
+	}
+	gt := nmd.NewGraphTopology()
+	if err := gt.ParseGraph("", desc, filterGatewayNode); err != nil {
+		var pe *nmd.ParseError
+		var le *nmd.LoopError
+		if errors.As(err, &pe) {
+			for _, se := range pe.Errors {
+				report(se.Position, "%v", se.Msg)
+			}
+		} else if errors.As(err, &le) {
+			report(le.Node.Position, "%v", err)
+		} else {
+			report(nmd.Position{}, "%v", err)
+		}
+		return
+	}
+
+	traits := make(map[*nmd.NodeDef]*NodeTrait)
+	for _, n := range gt.GetSortedNodeDefs() {
+		trait, ok := NodeTraitOfType(n.Type)
+		if !ok {
+			report(n.Position, "unknown node type %v of node %v", n.Type, n.Name)
+			continue
+		}
+		traits[n] = trait
+		for _, p := range n.Props {
+			if slices.Contains(composerProperties, p.Key) {
+				continue
+			}
+			if err := trait.CheckProperty(p.Key, p.Value); err != nil {
+				report(p.Position, "%v", err)
+			}
+		}
+	}
+	for _, n := range gt.GetSortedNodeDefs() {
+		sender := traits[n]
+		if sender == nil {
+			continue
+		}
+		_, offer := sender.MessageTypes()
+		for _, link := range n.Deps {
+			receiver := traits[link.LinkTo]
+			if receiver == nil {
+				continue
+			}
+			if err := negotiateLink(offer, receiver, link.PreferOffer); err != nil {
+				report(link.Position, "link from %v to %v: %v", n.Name, link.LinkTo.Name, err)
+			}
+		}
+	}
+	return
+}
+
+// negotiateLink checks every offer to be sent is acceptable by receiver, directly or by conversion
+func negotiateLink(offer []MessageType, receiver *NodeTrait, preferOffer []string) error {
+	if len(offer) == 0 {
+		// sender decides its offer when linking
+		return nil
+	}
+	accept, _ := receiver.MessageTypes()
+	if slices.Contains(accept, MtLinkPointRequest) {
+		// receiver negotiates by itself
+		return nil
+	}
+	acceptable := func(mt MessageType) bool {
+		for _, a := range accept {
+			if a == mt || CanConvertMessage(mt, a) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(preferOffer) == 0 {
+		// one link with any of the offers
+		for _, mt := range offer {
+			if acceptable(mt) {
+				return nil
+			}
+		}
+		return fmt.Errorf("none of offered message types is accepted by node type %v", receiver.NodeType)
+	}
+	// one link for each preferred offer
+	for _, name := range preferOffer {
+		mt, ok := MessageTraitOfName(name)
+		if !ok {
+			return fmt.Errorf("prefer offer msg type %v that is not defined", name)
+		}
+		if !slices.Contains(offer, mt.TypeId) {
+			return fmt.Errorf("prefer offer msg type %v that is not in its Offer()", name)
+		}
+		if !acceptable(mt.TypeId) {
+			return fmt.Errorf("message type %v is not accepted by node type %v", name, receiver.NodeType)
+		}
+	}
+	return nil
+}
@@ -4,7 +4,10 @@ import (
 	"bytes"
 	"github.com/appcrash/media/server/comp/nmd"
 	"github.com/appcrash/media/server/event"
+	"github.com/appcrash/media/server/rpc"
+	"github.com/appcrash/media/server/utils"
 	"strings"
+	"time"
 )
 
 // MessageBase provides basic header operations, all common properties of messages are set here, such as from which node
@@ -112,6 +115,66 @@ func (m *RawByteMessage) Clone() Cloneable {
 	return clone
 }
 
+// RtpPacketMessage carries rtp packets between the rtp stack of a session and nodes in the graph
+type RtpPacketMessage struct {
+	MessageBase
+	Packet *utils.RtpPacketList
+}
+
+func (m *RtpPacketMessage) Clone() Cloneable {
+	clone := &RtpPacketMessage{
+		MessageBase: m.MessageBase.Clone(),
+	}
+	if m.Packet != nil {
+		clone.Packet = m.Packet.Clone()
+	}
+	return clone
+}
+
+// AsRawByteMessage only takes payload of the first packet, i.e. audio frames
+func (m *RtpPacketMessage) AsRawByteMessage() *RawByteMessage {
+	if m.Packet == nil {
+		return nil
+	}
+	return &RawByteMessage{
+		MessageBase: m.MessageBase.Clone(),
+		Data:        m.Packet.Payload,
+	}
+}
+
+// DtmfMessage is a key press decoded from telephone-event packets
+type DtmfMessage struct {
+	MessageBase
+	Digit    byte // 0-9,*,#,A-D
+	Volume   uint8
+	Duration time.Duration
+}
+
+func (m *DtmfMessage) Clone() Cloneable {
+	return &DtmfMessage{
+		MessageBase: m.MessageBase.Clone(),
+		Digit:       m.Digit,
+		Volume:      m.Volume,
+		Duration:    m.Duration,
+	}
+}
+
+// MediaDirectionMessage tells the current direction of a media stream, i.e. start playing music once stream becomes
+// sendonly as peer is put on hold, stop it when back to sendrecv
+type MediaDirectionMessage struct {
+	MessageBase
+	Stream    string
+	Direction rpc.MediaDirection
+}
+
+func (m *MediaDirectionMessage) Clone() Cloneable {
+	return &MediaDirectionMessage{
+		MessageBase: m.MessageBase.Clone(),
+		Stream:      m.Stream,
+		Direction:   m.Direction,
+	}
+}
+
 // Message Processor
 var (
 	nullMessagePostProcessor = func(message Message) {}
@@ -122,6 +185,9 @@ const (
 	propTrackable = "trackable"
 )
 
+// composerProperties are consumed by composer itself rather than set to fields of node
+var composerProperties = []string{propTrackable}
+
 const (
 	Origin = "_o"
 )
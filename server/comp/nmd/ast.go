@@ -2,12 +2,27 @@ package nmd
 
 import (
 	"fmt"
+	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/appcrash/media/server/utils"
 )
 
 // TODO: every statement should have sequence id
 
+// Position is where a definition appears in graph description, both line and column count from 1
+type Position struct {
+	Line, Column int
+}
+
+func positionOf(token antlr.Token) Position {
+	return Position{Line: token.GetLine(), Column: token.GetColumn() + 1}
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%v:%v", p.Line, p.Column)
+}
+
 type NodeProp struct {
+	Position
 	Key, Type string
 	Value     interface{}
 }
@@ -18,11 +33,13 @@ func (np *NodeProp) FormalizeKey() {
 }
 
 type LinkOperator struct {
+	Position             // where the link operator is
 	LinkTo      *NodeDef // which node link to
 	PreferOffer []string // preferred offer connecting node suggests
 }
 
 type NodeDef struct {
+	Position          // where the node first appears
 	Index             int
 	Name, Scope, Type string
 	Props             []*NodeProp
@@ -25,8 +25,9 @@ type Listener struct {
 	currentNodeProp *NodeProp
 	currentNodeDef  *NodeDef
 	currentEndpoint *EndpointDefs
+	currentOperator Position
 
-	errorString string
+	SyntaxErrors []*SyntaxError
 }
 
 func NewListener(sessionId string) *Listener {
@@ -37,7 +38,7 @@ func NewListener(sessionId string) *Listener {
 }
 
 func (l *Listener) SyntaxError(recognizer antlr.Recognizer, offendingSymbol interface{}, line, column int, msg string, e antlr.RecognitionException) {
-	l.errorString += msg + "\n"
+	l.SyntaxErrors = append(l.SyntaxErrors, &SyntaxError{Position: Position{Line: line, Column: column + 1}, Msg: msg})
 }
 
 func unquoteString(quotedString string) string {
@@ -59,15 +60,16 @@ func (l *Listener) pushEndpoint() {
 	l.endpointStack = append(l.endpointStack, l.currentEndpoint)
 }
 
-func (l *Listener) getNodeDef(name, scope, typ string) (ni *NodeDef) {
+func (l *Listener) getNodeDef(pos Position, name, scope, typ string) (ni *NodeDef) {
 	var ok bool
 	queryId := name + "_" + scope
 	if ni, ok = l.nodeMap[queryId]; !ok {
 		ni = &NodeDef{
-			Name:  name,
-			Scope: scope,
-			Type:  typ,
-			Index: l.nbNode,
+			Position: pos,
+			Name:     name,
+			Scope:    scope,
+			Type:     typ,
+			Index:    l.nbNode,
 		}
 		l.nodeMap[queryId] = ni
 		l.NodeDefs = append(l.NodeDefs, ni)
@@ -89,6 +91,10 @@ func (l *Listener) EnterEndpoint(c *EndpointContext) {
 	l.nodeDefStack = nil
 }
 
+func (l *Listener) EnterLink_operator(c *Link_operatorContext) {
+	l.currentOperator = positionOf(c.GetStart())
+}
+
 func (l *Listener) EnterMsg_type_list(c *Msg_type_listContext) {
 	for _, id := range c.AllID() {
 		l.currentEndpoint.PreferOffer = append(l.currentEndpoint.PreferOffer, id.GetText())
@@ -109,11 +115,11 @@ func (l *Listener) EnterNode_id(c *Node_idContext) {
 		// type is omitted, use the name as type
 		typ = name
 	}
-	l.currentNodeDef = l.getNodeDef(name, scope, typ)
+	l.currentNodeDef = l.getNodeDef(positionOf(c.GetName()), name, scope, typ)
 }
 
 func (l *Listener) EnterNode_prop(c *Node_propContext) {
-	l.currentNodeProp = &NodeProp{Key: c.GetKey().GetText()}
+	l.currentNodeProp = &NodeProp{Position: positionOf(c.GetKey()), Key: c.GetKey().GetText()}
 }
 
 func (l *Listener) EnterPropQuoteString(ctx *PropQuoteStringContext) {
@@ -158,6 +164,7 @@ func (l *Listener) ExitEndpoint(c *EndpointContext) {
 		for _, f := range from.Nodes {
 			for _, t := range to.Nodes {
 				linkOperator := &LinkOperator{
+					Position:    l.currentOperator,
 					LinkTo:      t,
 					PreferOffer: preferOffer,
 				}
@@ -1,9 +1,10 @@
 package nmd
 
 import (
-	"errors"
+	"fmt"
 	"github.com/antlr/antlr4/runtime/Go/antlr"
 	"github.com/appcrash/media/server/utils"
+	"strings"
 )
 
 // parser analyzes session's event graph description, collect each node's properties and build the DAG between
@@ -28,6 +29,34 @@ type GraphTopology struct {
 // this filter identify these nodes and topographicalSort will ignore them, so that sorting can succeed.
 type LoopNodeFilter func(nodeName string) bool
 
+// SyntaxError is a syntax error found in graph description
+type SyntaxError struct {
+	Position
+	Msg string
+}
+
+// ParseError is returned by ParseGraph when graph description has syntax errors
+type ParseError struct {
+	Errors []*SyntaxError
+}
+
+func (e *ParseError) Error() string {
+	var sb strings.Builder
+	for _, se := range e.Errors {
+		sb.WriteString(se.Msg + "\n")
+	}
+	return sb.String()
+}
+
+// LoopError is returned by ParseGraph when filter nodes form a loop
+type LoopError struct {
+	Node *NodeDef // one of nodes in the loop
+}
+
+func (e *LoopError) Error() string {
+	return fmt.Sprintf("graph topographical sort finds a loop in filter nodes at %v", e.Node.Name)
+}
+
 func NewGraphTopology() *GraphTopology {
 	return &GraphTopology{}
 }
@@ -40,9 +69,16 @@ func (gt *GraphTopology) ParseGraph(sessionId, desc string, loopFilter LoopNodeF
 	listener := NewListener(sessionId)
 	parser.AddErrorListener(listener)
 	antlr.ParseTreeWalkerDefault.Walk(listener, parser.Graph())
+	if token := stream.LT(1); len(listener.SyntaxErrors) == 0 && token.GetTokenType() != antlr.TokenEOF {
+		// grammar stops at the first statement not followed by ';', don't silently ignore the rest
+		listener.SyntaxErrors = append(listener.SyntaxErrors, &SyntaxError{
+			Position: positionOf(token),
+			Msg:      fmt.Sprintf("extraneous input '%v', statements must be separated by ';'", token.GetText()),
+		})
+	}
 
-	if listener.errorString != "" {
-		return errors.New(listener.errorString)
+	if len(listener.SyntaxErrors) > 0 {
+		return &ParseError{Errors: listener.SyntaxErrors}
 	}
 
 	gt.nodeDefs = listener.NodeDefs
@@ -69,6 +105,22 @@ func (gt *GraphTopology) GetSinkActions() []*SinkActionDefs {
 	return gt.sinkDefs
 }
 
+// nodeInLoop walks from any unsorted node along its unsorted dependency, after n steps it must be in a loop
+func nodeInLoop(outDegree []int, mat []uint8, n int) (i int) {
+	for outDegree[i] <= 0 {
+		i++
+	}
+	for step := 0; step < n; step++ {
+		for j := 0; j < n; j++ {
+			if mat[i*n+j] > 0 && outDegree[j] > 0 {
+				i = j
+				break
+			}
+		}
+	}
+	return
+}
+
 // O(n*n) sort algorithm, ok when n is small
 // this sorting helps composer inspecting the dependency of nodes with filter behaviour. non-filter nodes
 // are filtered out by loopFilter. this check is just a simple sanity check(filters should not form loop,
@@ -126,7 +178,7 @@ func (gt *GraphTopology) topographicalSort(loopFilter LoopNodeFilter) (err error
 		}
 		if !found {
 			// can not find a candidate that has no dependency, means loop detected
-			err = errors.New("graph topographical sort finds a loop in filter nodes")
+			err = &LoopError{Node: gt.nodeDefs[nodeInLoop(outDegree, mat, n)]}
 			return
 		}
 	}
@@ -82,3 +82,39 @@ func TestSink(t *testing.T) {
 		t.Fatal("parse sink statement failed")
 	}
 }
+
+func TestPosition(t *testing.T) {
+	gt := nmd.NewGraphTopology()
+	err := gt.ParseGraph("test_session", "[a k=1]\n  -> [b]", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range gt.GetSortedNodeDefs() {
+		switch n.Name {
+		case "a":
+			if n.Line != 1 || n.Column != 2 || n.Props[0].Line != 1 || n.Props[0].Column != 4 {
+				t.Fatalf("wrong position of node a: %v %v", n.Position, n.Props[0].Position)
+			}
+			if n.Deps[0].Line != 2 || n.Deps[0].Column != 3 {
+				t.Fatalf("wrong position of link operator: %v", n.Deps[0].Position)
+			}
+		case "b":
+			if n.Line != 2 || n.Column != 7 {
+				t.Fatalf("wrong position of node b: %v", n.Position)
+			}
+		}
+	}
+
+	err = gt.ParseGraph("test_session", "[a] ->\n[b", nil)
+	if pe, ok := err.(*nmd.ParseError); !ok || pe.Errors[0].Line != 2 {
+		t.Fatalf("syntax error should be located: %v", err)
+	}
+	err = gt.ParseGraph("test_session", "[a] -> [b]\n[c]", nil)
+	if pe, ok := err.(*nmd.ParseError); !ok || pe.Errors[0].Line != 2 || pe.Errors[0].Column != 1 {
+		t.Fatalf("statement without separator should be reported: %v", err)
+	}
+	err = gt.ParseGraph("test_session", "[x] -> [a] -> [b] -> [c] -> [a]", nil)
+	if le, ok := err.(*nmd.LoopError); !ok || le.Node.Name == "x" {
+		t.Fatalf("loop should be located: %v", err)
+	}
+}
@@ -100,3 +100,17 @@ func WithError(args ...string) (r []string) {
 	r = append(r, args...)
 	return
 }
+
+// ParseReply splits reply into its state and arguments, a reply not built by WithOk is never ok
+func ParseReply(r []string) (ok bool, args []string) {
+	if len(r) == 0 {
+		return
+	}
+	switch r[0] {
+	case "ok":
+		return true, r[1:]
+	case "err":
+		return false, r[1:]
+	}
+	return false, r
+}
@@ -42,3 +42,18 @@ func TestWithString(t *testing.T) {
 		}
 	}
 }
+
+func TestParseReply(t *testing.T) {
+	if ok, args := comp.ParseReply(comp.WithOk("a", "b")); !ok || len(args) != 2 || args[0] != "a" || args[1] != "b" {
+		t.Errorf("parse ok reply error: %v %v", ok, args)
+	}
+	if ok, args := comp.ParseReply(comp.WithError("bad")); ok || len(args) != 1 || args[0] != "bad" {
+		t.Errorf("parse error reply error: %v %v", ok, args)
+	}
+	if ok, args := comp.ParseReply(nil); ok || len(args) != 0 {
+		t.Errorf("parse empty reply error: %v %v", ok, args)
+	}
+	if ok, args := comp.ParseReply([]string{"what"}); ok || len(args) != 1 {
+		t.Errorf("parse unknown reply error: %v %v", ok, args)
+	}
+}
@@ -0,0 +1,59 @@
+package comp
+
+import "context"
+
+const defaultDirectionChannelSize = 4
+
+// DirectionSrc is the entry of media direction changes into the graph. session pushes the initial direction of the
+// stream and every change to its channel, then they are sent to the first output link as MediaDirectionMessage.
+type DirectionSrc struct {
+	SessionNode
+
+	context context.Context
+	cancelF context.CancelFunc
+	C       chan *MediaDirectionMessage
+	stream  string // media stream of session it binds to, set by property
+}
+
+func (n *DirectionSrc) Offer() []MessageType {
+	return []MessageType{MtMediaDirection}
+}
+
+func (n *DirectionSrc) Init() error {
+	n.context, n.cancelF = context.WithCancel(context.Background())
+	n.C = make(chan *MediaDirectionMessage, defaultDirectionChannelSize)
+	return nil
+}
+
+func (n *DirectionSrc) AfterCompose(_ *Composer, _ SessionAware) error {
+	go n.loop()
+	return nil
+}
+
+func (n *DirectionSrc) OnExit() {
+	n.cancelF()
+}
+
+// HandleMediaDirectionChannel makes DirectionSrc a media direction consumer of session
+func (n *DirectionSrc) HandleMediaDirectionChannel() chan<- *MediaDirectionMessage {
+	return n.C
+}
+
+// MediaStream tells session which media stream this node binds to
+func (n *DirectionSrc) MediaStream() string {
+	return n.stream
+}
+
+func (n *DirectionSrc) loop() {
+	done := n.context.Done()
+	for {
+		select {
+		case msg := <-n.C:
+			if lp := n.GetLinkPoint(0); lp != nil {
+				lp.SendMessage(msg)
+			}
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package comp
+
+import (
+	"github.com/appcrash/media/server/dtmf"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDtmfDuration = 100 * time.Millisecond
+	minDtmfDuration     = 40 * time.Millisecond
+	maxDtmfDuration     = 8 * time.Second
+)
+
+// DtmfGen is the exit of key presses out of the graph, session's send loop pulls digits from it and sends them as
+// telephone-event packets. digits come from either DtmfMessage of other nodes or CALL command:
+// -----------------------------------------------------------------------
+// send_digits {digits} [duration={milliseconds}]  # e.g. send_digits "123#" duration=100
+type DtmfGen struct {
+	SessionNode
+
+	C      chan *DtmfMessage // never closed as CALL can come at any time, send loop stops on its own
+	stream string            // media stream of session it binds to, set by property
+}
+
+func (n *DtmfGen) Init() error {
+	n.C = make(chan *DtmfMessage, defaultRtpChannelSize)
+	return nil
+}
+
+// PullDtmfChannel makes DtmfGen a dtmf provider of session
+func (n *DtmfGen) PullDtmfChannel() <-chan *DtmfMessage {
+	return n.C
+}
+
+// MediaStream tells session which media stream this node binds to
+func (n *DtmfGen) MediaStream() string {
+	return n.stream
+}
+
+func (n *DtmfGen) handleDtmf(msg *DtmfMessage) {
+	select {
+	case n.C <- msg:
+	default:
+	}
+}
+
+func (n *DtmfGen) OnCall(fromNode string, args []string) (resp []string) {
+	if len(args) < 2 || args[0] != "send_digits" {
+		return WithError("wrong dtmf_gen command, usage: send_digits {digits} [duration={ms}]")
+	}
+	digits := strings.ToUpper(args[1])
+	duration := defaultDtmfDuration
+	for _, opt := range args[2:] {
+		kv := strings.SplitN(opt, "=", 2)
+		if len(kv) != 2 || kv[0] != "duration" {
+			return WithError("unknown option " + opt)
+		}
+		ms, err := strconv.Atoi(kv[1])
+		if err != nil {
+			return WithError("invalid duration " + kv[1])
+		}
+		duration = time.Duration(ms) * time.Millisecond
+	}
+	if duration < minDtmfDuration || duration > maxDtmfDuration {
+		return WithError("duration out of range")
+	}
+	if digits == "" {
+		return WithError("no digits")
+	}
+	for i := 0; i < len(digits); i++ {
+		if _, ok := dtmf.EventOfDigit(digits[i]); !ok {
+			return WithError("invalid digit " + string(digits[i]))
+		}
+	}
+	if len(n.C)+len(digits) > cap(n.C) {
+		return WithError("too many digits pending")
+	}
+	for i := 0; i < len(digits); i++ {
+		n.C <- &DtmfMessage{Digit: digits[i], Volume: dtmf.DefaultVolume, Duration: duration}
+	}
+	return WithOk()
+}
@@ -0,0 +1,62 @@
+package comp
+
+import "context"
+
+const defaultDtmfChannelSize = 8
+
+// DtmfSrc is the entry of key presses into the graph. the session's receive loop decodes telephone-event packets
+// and pushes digits to its channel, then they are sent to the first output link as DtmfMessage.
+type DtmfSrc struct {
+	SessionNode
+
+	context context.Context
+	cancelF context.CancelFunc
+	C       chan *DtmfMessage
+	stream  string // media stream of session it binds to, set by property
+}
+
+func (n *DtmfSrc) Offer() []MessageType {
+	return []MessageType{MtDtmf}
+}
+
+func (n *DtmfSrc) Init() error {
+	n.context, n.cancelF = context.WithCancel(context.Background())
+	n.C = make(chan *DtmfMessage, defaultDtmfChannelSize)
+	return nil
+}
+
+func (n *DtmfSrc) AfterCompose(_ *Composer, _ SessionAware) error {
+	go n.loop()
+	return nil
+}
+
+func (n *DtmfSrc) OnExit() {
+	n.cancelF()
+}
+
+// HandleDtmfChannel makes DtmfSrc a dtmf consumer of session
+func (n *DtmfSrc) HandleDtmfChannel() chan<- *DtmfMessage {
+	return n.C
+}
+
+// MediaStream tells session which media stream this node binds to
+func (n *DtmfSrc) MediaStream() string {
+	return n.stream
+}
+
+func (n *DtmfSrc) loop() {
+	done := n.context.Done()
+	for {
+		select {
+		case msg, more := <-n.C:
+			if !more {
+				return
+			}
+			if lp := n.GetLinkPoint(0); lp != nil {
+				lp.SendMessage(msg)
+			}
+		case <-done:
+			return
+		}
+	}
+}
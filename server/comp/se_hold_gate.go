@@ -0,0 +1,42 @@
+package comp
+
+import "github.com/appcrash/media/server/rpc"
+
+// HoldGate forwards media to the first output link only while the stream is on hold(sendonly), which makes a
+// music-on-hold player out of any media source, e.g. music pushed to chan_src starts when peer is put on hold and
+// stops once resumed:
+// [music:chan_src] -> [moh:hold_gate] -> [rtp_sink]; [direction_src] -> [moh]
+type HoldGate struct {
+	SessionNode
+
+	onHold bool
+}
+
+func (n *HoldGate) Offer() []MessageType {
+	return []MessageType{MtRtpPacket, MtRawByte}
+}
+
+func (n *HoldGate) handleMediaDirection(msg *MediaDirectionMessage) {
+	onHold := msg.Direction == rpc.MediaDirection_SENDONLY
+	if onHold != n.onHold {
+		logger.Infof("hold_gate(%v) of stream(%v) is on hold: %v", n, msg.Stream, onHold)
+	}
+	n.onHold = onHold
+}
+
+func (n *HoldGate) handleRtpPacket(msg *RtpPacketMessage) {
+	n.forward(msg)
+}
+
+func (n *HoldGate) handleRawByte(msg *RawByteMessage) {
+	n.forward(msg)
+}
+
+func (n *HoldGate) forward(msg Message) {
+	if !n.onHold {
+		return
+	}
+	if lp := n.GetLinkPoint(0); lp != nil {
+		lp.SendMessage(msg)
+	}
+}
@@ -0,0 +1,41 @@
+package comp
+
+import "github.com/appcrash/media/server/utils"
+
+// RtpSink is the exit of rtp stream out of the graph. packets it received are pulled by session's send loop, the
+// channel is closed when node exits graph so that send loop can stop
+type RtpSink struct {
+	SessionNode
+
+	C      chan *utils.RtpPacketList
+	stream string // media stream of session it binds to, set by property, the first stream if empty
+}
+
+func (n *RtpSink) Init() error {
+	n.C = make(chan *utils.RtpPacketList, defaultRtpChannelSize)
+	return nil
+}
+
+func (n *RtpSink) handleRtpPacket(msg *RtpPacketMessage) {
+	if msg.Packet == nil {
+		return
+	}
+	select {
+	case n.C <- msg.Packet:
+	default:
+	}
+}
+
+// PullPacketChannel makes RtpSink a rtp packet provider of session
+func (n *RtpSink) PullPacketChannel() <-chan *utils.RtpPacketList {
+	return n.C
+}
+
+// MediaStream tells session which media stream this node binds to
+func (n *RtpSink) MediaStream() string {
+	return n.stream
+}
+
+func (n *RtpSink) OnExit() {
+	close(n.C)
+}
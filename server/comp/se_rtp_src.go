@@ -0,0 +1,70 @@
+package comp
+
+import (
+	"context"
+	"github.com/appcrash/media/server/utils"
+)
+
+const defaultRtpChannelSize = 32
+
+// RtpSrc is the entry of rtp stream into the graph. the session's receive loop pushes packets to its channel, then
+// they are sent to the first output link as RtpPacketMessage. as the channel is closed by receive loop when session
+// ends, the node stops forwarding either channel closed or node exiting graph.
+type RtpSrc struct {
+	SessionNode
+
+	context context.Context
+	cancelF context.CancelFunc
+	C       chan *utils.RtpPacketList
+	stream  string // media stream of session it binds to, set by property, the first stream if empty
+}
+
+func (n *RtpSrc) Offer() []MessageType {
+	return []MessageType{MtRtpPacket}
+}
+
+func (n *RtpSrc) Init() error {
+	n.context, n.cancelF = context.WithCancel(context.Background())
+	n.C = make(chan *utils.RtpPacketList, defaultRtpChannelSize)
+	return nil
+}
+
+func (n *RtpSrc) AfterCompose(_ *Composer, _ SessionAware) error {
+	go n.loop()
+	return nil
+}
+
+func (n *RtpSrc) OnExit() {
+	n.cancelF()
+}
+
+// HandlePacketChannel makes RtpSrc a rtp packet consumer of session
+func (n *RtpSrc) HandlePacketChannel() chan<- *utils.RtpPacketList {
+	return n.C
+}
+
+// MediaStream tells session which media stream this node binds to
+func (n *RtpSrc) MediaStream() string {
+	return n.stream
+}
+
+func (n *RtpSrc) loop() {
+	done := n.context.Done()
+	for {
+		select {
+		case pl, more := <-n.C:
+			if !more {
+				return
+			}
+			if pl == nil {
+				continue
+			}
+			// link point can be created by conn command at any time, so fetch it every time
+			if lp := n.GetLinkPoint(0); lp != nil {
+				lp.SendMessage(&RtpPacketMessage{Packet: pl})
+			}
+		case <-done:
+			return
+		}
+	}
+}
@@ -379,26 +379,27 @@ func MakeSessionNode(nodeType string, sessionId string, props []*nmd.NodeProp) S
 func setNodeProperties(node event.Node, props []*nmd.NodeProp) (newProps []*nmd.NodeProp) {
 	ns := reflect.ValueOf(node).Elem()
 	for _, p := range props {
-		var rvCopy reflect.Value
-		k, value := p.Key, p.Value
-		field := ns.FieldByName(k)
-		rv := reflect.ValueOf(value)
-		rvt := rv.Type()
+		field := ns.FieldByName(p.Key)
 		if !field.IsValid() {
-			goto notHandled
+			newProps = append(newProps, p)
+			continue
 		}
-
-		if rvt.AssignableTo(field.Type()) {
-			rvCopy = rv
-		} else if rvt.ConvertibleTo(field.Type()) {
-			rvCopy = rv.Convert(field.Type())
+		if rv, ok := convertProperty(field.Type(), p.Value); ok {
+			utils.SetField(field, rv)
 		} else {
-			goto notHandled
+			newProps = append(newProps, p)
 		}
-		utils.SetField(field, rvCopy)
-		continue
-	notHandled:
-		newProps = append(newProps, p)
+	}
+	return
+}
+
+// convertProperty converts nmd property value to the type of field it sets
+func convertProperty(fieldType reflect.Type, value interface{}) (rv reflect.Value, ok bool) {
+	rv = reflect.ValueOf(value)
+	if rv.Type().AssignableTo(fieldType) {
+		return rv, true
+	} else if rv.Type().ConvertibleTo(fieldType) {
+		return rv.Convert(fieldType), true
 	}
 	return
 }
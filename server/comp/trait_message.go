@@ -177,6 +177,17 @@ func SetMessageConvertable(from, to MessageType) {
 	messageConvertibilityRegistry[from*maxMessageType+to] = true
 }
 
+// VisitMessageConversion calls visitor with every pair of message types that the former can be converted to the latter
+func VisitMessageConversion(visitor func(from, to MessageType)) {
+	for from := 0; from < nbMessageTrait; from++ {
+		for to := 0; to < nbMessageTrait; to++ {
+			if messageConvertibilityRegistry[from*maxMessageType+to] {
+				visitor(MessageType(from), MessageType(to))
+			}
+		}
+	}
+}
+
 func CanConvertMessage(from, to MessageType) bool {
 	if from > maxMessageType || to > maxMessageType {
 		return false
@@ -17,6 +17,9 @@ const (
 // Message Type Enum
 const (
 	MtRawByte = iota
+	MtRtpPacket
+	MtDtmf
+	MtMediaDirection
 	MtLinkPointRequest
 	MtChannelLinkRequest
 	MtUserMessageBegin
@@ -26,6 +29,18 @@ type RawByteConvertable interface {
 	AsRawByteMessage() *RawByteMessage
 }
 
+type RtpPacketConvertable interface {
+	AsRtpPacketMessage() *RtpPacketMessage
+}
+
+type DtmfConvertable interface {
+	AsDtmfMessage() *DtmfMessage
+}
+
+type MediaDirectionConvertable interface {
+	AsMediaDirectionMessage() *MediaDirectionMessage
+}
+
 type LinkPointRequestConvertable interface {
 	AsLinkPointRequestMessage() *LinkPointRequestMessage
 }
@@ -43,6 +58,30 @@ func (m *RawByteMessage) AsEvent() *event.Event {
 	return event.NewEvent(MtRawByte, m)
 }
 
+func (m *RtpPacketMessage) Type() MessageType {
+	return MtRtpPacket
+}
+
+func (m *RtpPacketMessage) AsEvent() *event.Event {
+	return event.NewEvent(MtRtpPacket, m)
+}
+
+func (m *DtmfMessage) Type() MessageType {
+	return MtDtmf
+}
+
+func (m *DtmfMessage) AsEvent() *event.Event {
+	return event.NewEvent(MtDtmf, m)
+}
+
+func (m *MediaDirectionMessage) Type() MessageType {
+	return MtMediaDirection
+}
+
+func (m *MediaDirectionMessage) AsEvent() *event.Event {
+	return event.NewEvent(MtMediaDirection, m)
+}
+
 func (m *LinkPointRequestMessage) Type() MessageType {
 	return MtLinkPointRequest
 }
@@ -64,12 +103,17 @@ func (m *ChannelLinkRequestMessage) AsEvent() *event.Event {
 func initMessageTraits() {
 	AddMessageTrait(
 		MT[RawByteMessage](MetaType[RawByteConvertable]()),
+		MT[RtpPacketMessage](MetaType[RtpPacketConvertable]()),
+		MT[DtmfMessage](MetaType[DtmfConvertable]()),
+		MT[MediaDirectionMessage](MetaType[MediaDirectionConvertable]()),
 		MT[LinkPointRequestMessage](MetaType[LinkPointRequestConvertable]()),
 		MT[ChannelLinkRequestMessage](MetaType[ChannelLinkRequestConvertable]()),
 	)
 }
 
 func initMessageConversion() {
+	m := SetMessageConvertable
+	m(MtRtpPacket, MtRawByte)
 }
 
 func InitMessage() {
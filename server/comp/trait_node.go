@@ -136,3 +136,45 @@ func NodeTraitOfType(name string) (nt *NodeTrait, exist bool) {
 	}
 	return
 }
+
+// NodeProperty is a field of node that nmd graph can set by the property of same name
+type NodeProperty struct {
+	Name string
+	Type string
+}
+
+// Properties returns fields declared by node struct that nmd property values(string or number) can be assigned to
+func (nt *NodeTrait) Properties() (props []NodeProperty) {
+	for i := 0; i < nt.Type.NumField(); i++ {
+		f := nt.Type.Field(i)
+		if f.Anonymous {
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			props = append(props, NodeProperty{Name: f.Name, Type: f.Type.String()})
+		}
+	}
+	return
+}
+
+// CheckProperty tells whether nmd property can be set to node of this type
+func (nt *NodeTrait) CheckProperty(key string, value interface{}) error {
+	f, ok := nt.Type.FieldByName(key)
+	if !ok {
+		return fmt.Errorf("node type %v has no property %v", nt.NodeType, key)
+	}
+	if _, ok = convertProperty(f.Type, value); !ok {
+		return fmt.Errorf("property %v of node type %v is %v, can not be set to %v", key, nt.NodeType, f.Type, value)
+	}
+	return nil
+}
+
+// MessageTypes creates a node to query which message types it accepts and offers, the ones decided when linking
+// such as offer of pubsub are unknown
+func (nt *NodeTrait) MessageTypes() (accept, offer []MessageType) {
+	node := nt.FactoryFunc()
+	return node.Accept(), node.Offer()
+}
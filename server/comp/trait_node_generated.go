@@ -7,7 +7,13 @@ func initNodeTraits() {
 	RegisterNodeTrait(
 		NT[ChanSink]("chan_sink", newChanSink),
 		NT[ChanSrc]("chan_src", newChanSrc),
+		NT[DirectionSrc]("direction_src", newDirectionSrc),
+		NT[DtmfGen]("dtmf_gen", newDtmfGen),
+		NT[DtmfSrc]("dtmf_src", newDtmfSrc),
+		NT[HoldGate]("hold_gate", newHoldGate),
 		NT[Pubsub]("pubsub", newPubsub),
+		NT[RtpSink]("rtp_sink", newRtpSink),
+		NT[RtpSrc]("rtp_src", newRtpSrc),
 	)
 }
 
@@ -51,6 +57,54 @@ func (n *ChanSrc) Accept() []MessageType {
 	}
 }
 
+func (n *DtmfGen) configHandler() {
+	n.SetMessageHandler(MtDtmf, func(_ MessageHandler) MessageHandler { return n._convertDtmfMessage })
+}
+
+func (n *DtmfGen) _convertDtmfMessage(evt *event.Event) {
+	if msg, ok := EventToMessage[*DtmfMessage](evt); ok {
+		n.handleDtmf(msg)
+	}
+}
+
+func (n *DtmfGen) Accept() []MessageType {
+	return []MessageType{
+		MtDtmf,
+	}
+}
+
+func (n *HoldGate) configHandler() {
+	n.SetMessageHandler(MtMediaDirection, func(_ MessageHandler) MessageHandler { return n._convertMediaDirectionMessage })
+	n.SetMessageHandler(MtRtpPacket, func(_ MessageHandler) MessageHandler { return n._convertRtpPacketMessage })
+	n.SetMessageHandler(MtRawByte, func(_ MessageHandler) MessageHandler { return n._convertRawByteMessage })
+}
+
+func (n *HoldGate) _convertMediaDirectionMessage(evt *event.Event) {
+	if msg, ok := EventToMessage[*MediaDirectionMessage](evt); ok {
+		n.handleMediaDirection(msg)
+	}
+}
+
+func (n *HoldGate) _convertRtpPacketMessage(evt *event.Event) {
+	if msg, ok := EventToMessage[*RtpPacketMessage](evt); ok {
+		n.handleRtpPacket(msg)
+	}
+}
+
+func (n *HoldGate) _convertRawByteMessage(evt *event.Event) {
+	if msg, ok := EventToMessage[*RawByteMessage](evt); ok {
+		n.handleRawByte(msg)
+	}
+}
+
+func (n *HoldGate) Accept() []MessageType {
+	return []MessageType{
+		MtMediaDirection,
+		MtRtpPacket,
+		MtRawByte,
+	}
+}
+
 func (n *Pubsub) configHandler() {
 	n.SetMessageHandler(MtLinkPointRequest, func(_ MessageHandler) MessageHandler { return n._convertLinkPointRequestMessage })
 }
@@ -67,6 +121,22 @@ func (n *Pubsub) Accept() []MessageType {
 	}
 }
 
+func (n *RtpSink) configHandler() {
+	n.SetMessageHandler(MtRtpPacket, func(_ MessageHandler) MessageHandler { return n._convertRtpPacketMessage })
+}
+
+func (n *RtpSink) _convertRtpPacketMessage(evt *event.Event) {
+	if msg, ok := EventToMessage[*RtpPacketMessage](evt); ok {
+		n.handleRtpPacket(msg)
+	}
+}
+
+func (n *RtpSink) Accept() []MessageType {
+	return []MessageType{
+		MtRtpPacket,
+	}
+}
+
 // Node Factory Method Begin
 
 func newChanSink() SessionAware {
@@ -91,6 +161,50 @@ func newChanSrc() SessionAware {
 	return node
 }
 
+func newDirectionSrc() SessionAware {
+	var exist bool
+	node := &DirectionSrc{}
+	node.Self = node
+	if node.Trait, exist = NodeTraitOfType("direction_src"); !exist {
+		panic("node type DirectionSrc not exist")
+	}
+
+	return node
+}
+
+func newDtmfGen() SessionAware {
+	var exist bool
+	node := &DtmfGen{}
+	node.Self = node
+	if node.Trait, exist = NodeTraitOfType("dtmf_gen"); !exist {
+		panic("node type DtmfGen not exist")
+	}
+	node.configHandler()
+	return node
+}
+
+func newDtmfSrc() SessionAware {
+	var exist bool
+	node := &DtmfSrc{}
+	node.Self = node
+	if node.Trait, exist = NodeTraitOfType("dtmf_src"); !exist {
+		panic("node type DtmfSrc not exist")
+	}
+
+	return node
+}
+
+func newHoldGate() SessionAware {
+	var exist bool
+	node := &HoldGate{}
+	node.Self = node
+	if node.Trait, exist = NodeTraitOfType("hold_gate"); !exist {
+		panic("node type HoldGate not exist")
+	}
+	node.configHandler()
+	return node
+}
+
 func newPubsub() SessionAware {
 	var exist bool
 	node := &Pubsub{}
@@ -102,6 +216,28 @@ func newPubsub() SessionAware {
 	return node
 }
 
+func newRtpSink() SessionAware {
+	var exist bool
+	node := &RtpSink{}
+	node.Self = node
+	if node.Trait, exist = NodeTraitOfType("rtp_sink"); !exist {
+		panic("node type RtpSink not exist")
+	}
+	node.configHandler()
+	return node
+}
+
+func newRtpSrc() SessionAware {
+	var exist bool
+	node := &RtpSrc{}
+	node.Self = node
+	if node.Trait, exist = NodeTraitOfType("rtp_src"); !exist {
+		panic("node type RtpSrc not exist")
+	}
+
+	return node
+}
+
 // Node Factory Method End
 
 func InitNode() {
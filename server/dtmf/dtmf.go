@@ -0,0 +1,136 @@
+package dtmf
+
+import (
+	"errors"
+	"time"
+)
+
+// PayloadSize of RFC 4733 named telephone event
+const PayloadSize = 4
+
+const digits = "0123456789*#ABCD"
+
+var ErrInvalidPayload = errors.New("invalid telephone event payload")
+
+// Payload of telephone-event rtp packet, refer to RFC 4733 2.3
+//
+//	 0                   1                   2                   3
+//	 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1 2 3 4 5 6 7 8 9 0 1
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+//	|     event     |E|R| volume    |          duration             |
+//	+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+-+
+type Payload struct {
+	Event    uint8
+	End      bool
+	Volume   uint8  // power level in -dBm0, 0~63
+	Duration uint16 // in timestamp units
+}
+
+func (p *Payload) Unmarshal(data []byte) error {
+	if len(data) < PayloadSize {
+		return ErrInvalidPayload
+	}
+	p.Event = data[0]
+	p.End = data[1]&0x80 != 0
+	p.Volume = data[1] & 0x3f
+	p.Duration = uint16(data[2])<<8 | uint16(data[3])
+	return nil
+}
+
+func (p *Payload) Marshal() []byte {
+	data := make([]byte, PayloadSize)
+	data[0] = p.Event
+	data[1] = p.Volume & 0x3f
+	if p.End {
+		data[1] |= 0x80
+	}
+	data[2] = byte(p.Duration >> 8)
+	data[3] = byte(p.Duration)
+	return data
+}
+
+// EventOfDigit converts digit(0-9,*,#,A-D) to event code, ok is false if digit is invalid
+func EventOfDigit(digit byte) (event uint8, ok bool) {
+	if digit >= 'a' && digit <= 'd' {
+		digit -= 'a' - 'A'
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] == digit {
+			return uint8(i), true
+		}
+	}
+	return
+}
+
+// DigitOfEvent converts event code to digit, ok is false if event is not a dtmf digit
+func DigitOfEvent(event uint8) (digit byte, ok bool) {
+	if int(event) >= len(digits) {
+		return
+	}
+	return digits[event], true
+}
+
+// Digit is a completed key press
+type Digit struct {
+	Digit    byte
+	Volume   uint8
+	Duration time.Duration
+}
+
+// Decoder collapses telephone-event packets of one key press (start, continuation and redundant end packets) into
+// a single Digit. packets of the same key press share the same rtp timestamp.
+type Decoder struct {
+	clockRate uint32
+
+	started   bool
+	timestamp uint32 // rtp timestamp of current event
+	reported  bool   // current event has been reported
+	current   Payload
+}
+
+func NewDecoder(clockRate uint32) *Decoder {
+	if clockRate == 0 {
+		clockRate = 8000
+	}
+	return &Decoder{clockRate: clockRate}
+}
+
+// Decode feeds a telephone-event packet, returns completed digits if any. a digit is completed when its end packet
+// is received, or a new event begins before end packets of previous one arrive (all of them lost)
+func (d *Decoder) Decode(timestamp uint32, data []byte) (completed []Digit, err error) {
+	var p Payload
+	if err = p.Unmarshal(data); err != nil {
+		return
+	}
+	if !d.started || timestamp != d.timestamp {
+		if d.started && !d.reported {
+			if digit, ok := d.toDigit(&d.current); ok {
+				completed = append(completed, digit)
+			}
+		}
+		d.started = true
+		d.timestamp = timestamp
+		d.reported = false
+	}
+	if d.reported {
+		// retransmission of end packet
+		return
+	}
+	d.current = p
+	if p.End {
+		d.reported = true
+		if digit, ok := d.toDigit(&p); ok {
+			completed = append(completed, digit)
+		}
+	}
+	return
+}
+
+func (d *Decoder) toDigit(p *Payload) (digit Digit, ok bool) {
+	if digit.Digit, ok = DigitOfEvent(p.Event); !ok {
+		return
+	}
+	digit.Volume = p.Volume
+	digit.Duration = time.Duration(p.Duration) * time.Second / time.Duration(d.clockRate)
+	return
+}
@@ -0,0 +1,160 @@
+package dtmf_test
+
+import (
+	"github.com/appcrash/media/server/dtmf"
+	"testing"
+	"time"
+)
+
+func payload(event uint8, end bool, duration uint16) []byte {
+	p := dtmf.Payload{Event: event, End: end, Volume: 10, Duration: duration}
+	return p.Marshal()
+}
+
+func TestPayload(t *testing.T) {
+	var p dtmf.Payload
+	if err := p.Unmarshal(payload(11, true, 800)); err != nil {
+		t.Fatal(err)
+	}
+	if p.Event != 11 || !p.End || p.Volume != 10 || p.Duration != 800 {
+		t.Fatalf("wrong payload: %+v", p)
+	}
+	if err := p.Unmarshal([]byte{1, 2}); err == nil {
+		t.Fatal("short payload should fail")
+	}
+	if e, ok := dtmf.EventOfDigit('#'); !ok || e != 11 {
+		t.Fatal("wrong event of #")
+	}
+	if d, ok := dtmf.DigitOfEvent(15); !ok || d != 'D' {
+		t.Fatal("wrong digit of event 15")
+	}
+	if _, ok := dtmf.DigitOfEvent(16); ok {
+		t.Fatal("flash is not a digit")
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	d := dtmf.NewDecoder(8000)
+	var all []dtmf.Digit
+	feed := func(ts uint32, data []byte) {
+		digits, err := d.Decode(ts, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		all = append(all, digits...)
+	}
+	// digit 5: start, continuation and 3 redundant end packets
+	feed(1000, payload(5, false, 160))
+	feed(1000, payload(5, false, 320))
+	feed(1000, payload(5, true, 800))
+	feed(1000, payload(5, true, 800))
+	feed(1000, payload(5, true, 800))
+	// digit # without any end packet, completed by the next event
+	feed(3000, payload(11, false, 160))
+	feed(3000, payload(11, false, 480))
+	feed(5000, payload(1, true, 400))
+
+	if len(all) != 3 {
+		t.Fatalf("expect 3 digits, got %v", all)
+	}
+	if all[0].Digit != '5' || all[0].Duration != 100*time.Millisecond {
+		t.Fatalf("wrong first digit: %+v", all[0])
+	}
+	if all[1].Digit != '#' || all[1].Duration != 60*time.Millisecond {
+		t.Fatalf("wrong second digit: %+v", all[1])
+	}
+	if all[2].Digit != '1' || all[2].Duration != 50*time.Millisecond {
+		t.Fatalf("wrong third digit: %+v", all[2])
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	g := dtmf.NewGenerator(8000, 20*time.Millisecond)
+	g.Enqueue(dtmf.Digit{Digit: '1', Volume: 10, Duration: 60 * time.Millisecond})
+	g.Enqueue(dtmf.Digit{Digit: '#', Volume: 10, Duration: 40 * time.Millisecond})
+
+	var packets []dtmf.Packet
+	var now uint32
+	for i := 0; g.Busy() && i < 100; i++ {
+		if p, ok := g.Tick(now); ok {
+			packets = append(packets, p)
+		}
+		now += 160
+	}
+	// digit 1: start, 2 continuations(the last one is end), 2 more end retransmissions
+	// digit #: start, end and 2 more end retransmissions
+	if len(packets) != 9 {
+		t.Fatalf("expect 9 packets, got %v", len(packets))
+	}
+	durations := []uint16{160, 320, 480, 480, 480, 160, 320, 320, 320}
+	ends := []bool{false, false, true, true, true, false, true, true, true}
+	for i, p := range packets {
+		if p.Payload.Duration != durations[i] || p.Payload.End != ends[i] {
+			t.Fatalf("packet %v is wrong: %+v", i, p)
+		}
+		if p.Marker != (i == 0 || i == 5) {
+			t.Fatalf("packet %v has wrong marker", i)
+		}
+	}
+	if packets[0].Timestamp != 0 || packets[4].Timestamp != 0 || packets[5].Timestamp == 0 {
+		t.Fatal("packets of an event should share timestamp of its start")
+	}
+	// gap between two digits is 100ms, i.e. 5 ticks
+	if packets[5].Timestamp != 10*160 {
+		t.Fatalf("wrong timestamp of second digit: %v", packets[5].Timestamp)
+	}
+	if packets[5].Payload.Event != 11 {
+		t.Fatal("wrong event of second digit")
+	}
+
+	// decode them back
+	d := dtmf.NewDecoder(8000)
+	var digits []dtmf.Digit
+	for _, p := range packets {
+		r, _ := d.Decode(p.Timestamp, p.Payload.Marshal())
+		digits = append(digits, r...)
+	}
+	if len(digits) != 2 || digits[0].Digit != '1' || digits[1].Digit != '#' ||
+		digits[0].Duration != 60*time.Millisecond || digits[1].Duration != 40*time.Millisecond {
+		t.Fatalf("wrong decoded digits: %+v", digits)
+	}
+}
+
+func TestGeneratorLongEvent(t *testing.T) {
+	g := dtmf.NewGenerator(8000, 20*time.Millisecond)
+	g.Enqueue(dtmf.Digit{Digit: '5', Volume: 10, Duration: 10 * time.Second})
+	var packets []dtmf.Packet
+	var now uint32
+	for i := 0; g.Busy() && i < 1000; i++ {
+		if p, ok := g.Tick(now); ok {
+			packets = append(packets, p)
+		}
+		now += 160
+	}
+	// 10s is 80000 units, the first segment reports maximum duration 0xffff without end bit, the second one
+	// starts at the time it was reached and ends with the rest
+	var segments []dtmf.Packet // the last packet of each segment
+	for i, p := range packets {
+		if p.Marker != (i == 0) {
+			t.Fatalf("packet %v has wrong marker", i)
+		}
+		if i > 0 && p.Timestamp != packets[i-1].Timestamp {
+			segments = append(segments, packets[i-1])
+		}
+	}
+	segments = append(segments, packets[len(packets)-1])
+	if len(segments) != 2 {
+		t.Fatalf("expect 2 segments, got %v", len(segments))
+	}
+	if p := segments[0]; p.Timestamp != 0 || p.Payload.Duration != 0xffff || p.Payload.End {
+		t.Fatalf("wrong first segment: %+v", p)
+	}
+	if p := segments[1]; p.Timestamp != 0xffff || p.Payload.Duration != 80000-0xffff || !p.Payload.End {
+		t.Fatalf("wrong second segment: %+v", p)
+	}
+	for i, p := range packets {
+		if p.Payload.End != (i >= len(packets)-dtmf.EndRetransmission) {
+			t.Fatalf("packet %v has wrong end bit", i)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package dtmf
+
+import "time"
+
+const (
+	// EndRetransmission is the number of end packets sent for each event, refer to RFC 4733 2.5.1.4
+	EndRetransmission = 3
+
+	DefaultVolume        = 10
+	DefaultInterDigitGap = 100 * time.Millisecond
+
+	maxDuration = 0xffff // duration field is 16 bits
+)
+
+// Packet is a telephone-event packet to be sent, all packets of an event share the same timestamp
+type Packet struct {
+	Payload   Payload
+	Timestamp uint32
+	Marker    bool // set for the first packet of an event
+}
+
+// Generator schedules telephone-event packets for queued digits. Tick must be called every packet interval, it
+// returns packet to send at that moment: a start packet, continuation packets with increasing duration, then
+// the end packet and its retransmissions with final duration. digits are separated by inter-digit gap. an event
+// longer than maximum duration is split into segments, refer to RFC 4733 2.5.1.3
+type Generator struct {
+	clockRate  uint32
+	interval   time.Duration
+	stepUnits  uint32 // packet interval in timestamp units
+	gapTicks   int
+	queue      []Digit
+	active     bool
+	current    Digit
+	timestamp  uint32
+	elapsed    uint32 // duration so far in timestamp units
+	segment    uint32 // start of current segment relative to event start, in timestamp units
+	total      uint32
+	endSent    int
+	gapRemains int
+}
+
+func NewGenerator(clockRate uint32, interval time.Duration) *Generator {
+	if clockRate == 0 {
+		clockRate = 8000
+	}
+	if interval <= 0 {
+		interval = 20 * time.Millisecond
+	}
+	return &Generator{
+		clockRate: clockRate,
+		interval:  interval,
+		stepUnits: uint32(interval * time.Duration(clockRate) / time.Second),
+		gapTicks:  int((DefaultInterDigitGap + interval - 1) / interval),
+	}
+}
+
+func (g *Generator) ClockRate() uint32 {
+	return g.clockRate
+}
+
+// Interval is the packet interval, Tick should be called at this pace
+func (g *Generator) Interval() time.Duration {
+	return g.interval
+}
+
+func (g *Generator) Enqueue(digit Digit) {
+	g.queue = append(g.queue, digit)
+}
+
+// Busy returns true if any digit is being sent or waiting to be sent
+func (g *Generator) Busy() bool {
+	return g.active || g.gapRemains > 0 || len(g.queue) > 0
+}
+
+// Tick returns the packet to send now, ok is false if nothing to send. now is current timestamp of the rtp stream
+// which is used as timestamp of a new event
+func (g *Generator) Tick(now uint32) (packet Packet, ok bool) {
+	if !g.active {
+		if g.gapRemains > 0 {
+			g.gapRemains--
+			return
+		}
+		if len(g.queue) == 0 {
+			return
+		}
+		g.start(now)
+		packet.Marker = true
+	} else if g.endSent == 0 {
+		if g.elapsed-g.segment >= maxDuration {
+			// maximum duration was reported, begin a new segment at the time it was reached
+			g.segment += maxDuration
+		}
+		g.elapsed += g.stepUnits
+	}
+	if g.elapsed >= g.total {
+		g.elapsed = g.total
+	}
+	duration := g.elapsed - g.segment
+	if duration > maxDuration {
+		// report the maximum without end bit, the rest goes to next segment
+		duration = maxDuration
+	} else if g.elapsed == g.total {
+		g.endSent++
+	}
+
+	event, _ := EventOfDigit(g.current.Digit)
+	packet.Timestamp = g.timestamp + g.segment
+	packet.Payload = Payload{
+		Event:    event,
+		End:      g.endSent > 0,
+		Volume:   g.current.Volume,
+		Duration: uint16(duration),
+	}
+	ok = true
+	if g.endSent >= EndRetransmission {
+		g.active = false
+		g.gapRemains = g.gapTicks
+	}
+	return
+}
+
+func (g *Generator) start(now uint32) {
+	g.current = g.queue[0]
+	g.queue = g.queue[1:]
+	g.active = true
+	g.timestamp = now
+	g.endSent = 0
+	g.total = uint32(g.current.Duration * time.Duration(g.clockRate) / time.Second)
+	g.elapsed = g.stepUnits
+	g.segment = 0
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/appcrash/media/server/prom"
 	"github.com/appcrash/media/server/utils"
 	"reflect"
+	"sort"
+	"sync/atomic"
 	"time"
 )
 
@@ -20,6 +22,9 @@ type Graph struct {
 	nodeMap  nodeMapType // nodeId -> nodeInfo
 	linkSet  linkSetType // links that still alive
 
+	// sizes of nodeMap and linkSet that can be read out of event loop
+	nbNode, nbLink atomic.Int32
+
 	eventChannel chan *Event
 }
 
@@ -30,13 +35,13 @@ type nodeInfo struct {
 }
 
 func (eg *Graph) updateNodeStats() {
-	nb := float64(len(eg.nodeMap))
-	prom.NodeGraphNodes.Set(nb)
+	eg.nbNode.Store(int32(len(eg.nodeMap)))
+	prom.NodeGraphNodes.Set(float64(len(eg.nodeMap)))
 }
 
 func (eg *Graph) updateLinkStats() {
-	nb := float64(len(eg.linkSet))
-	prom.NodeGraphLinks.Set(nb)
+	eg.nbLink.Store(int32(len(eg.linkSet)))
+	prom.NodeGraphLinks.Set(float64(len(eg.linkSet)))
 }
 
 func (eg *Graph) findNode(scope string, name string) *NodeDelegate {
@@ -193,6 +198,12 @@ func (eg *Graph) onEvent(evt *Event) {
 			return
 		}
 		eg.onLinkDown(req)
+	case reqSnapshot:
+		var req *snapshotRequest
+		if req, ok = evt.obj.(*snapshotRequest); !ok {
+			return
+		}
+		req.c <- eg.onSnapshot(req.scope)
 	}
 }
 
@@ -319,8 +330,73 @@ func (eg *Graph) onLinkDown(req *linkDownRequest) {
 	fromNode.receiveCtrl(newLinkDownResponse(stateSuccess, link))
 }
 
+func (eg *Graph) onSnapshot(scope string) *GraphSnapshot {
+	snapshot := &GraphSnapshot{Links: len(eg.linkSet)}
+	linkIds := func(links []*dlink, peer func(l *dlink) *NodeDelegate) (ids []string) {
+		ids = []string{}
+		for _, l := range links {
+			ids = append(ids, peer(l).getId())
+		}
+		sort.Strings(ids)
+		return
+	}
+	for s, nodeList := range eg.scopeMap {
+		if scope != "" && s != scope {
+			continue
+		}
+		ss := &ScopeSnapshot{Scope: s}
+		for _, nd := range nodeList {
+			info := eg.getNodeInfo(nd.getId())
+			if info == nil {
+				continue
+			}
+			ss.Nodes = append(ss.Nodes, &NodeSnapshot{
+				Id:          nd.getId(),
+				Name:        nd.getNodeName(),
+				MaxLink:     info.maxLink,
+				InputLinks:  linkIds(info.inputLinks, func(l *dlink) *NodeDelegate { return l.fromNode }),
+				OutputLinks: linkIds(info.outputLinks, func(l *dlink) *NodeDelegate { return l.toNode }),
+				QueueDepth:  len(nd.dataQueue),
+				QueueSize:   cap(nd.dataQueue),
+			})
+		}
+		sort.Slice(ss.Nodes, func(i, j int) bool { return ss.Nodes[i].Id < ss.Nodes[j].Id })
+		snapshot.Scopes = append(snapshot.Scopes, ss)
+	}
+	sort.Slice(snapshot.Scopes, func(i, j int) bool { return snapshot.Scopes[i].Scope < snapshot.Scopes[j].Scope })
+	return snapshot
+}
+
 // public APIs for end user
 
+// GraphSnapshot is the state of graph at a moment for inspection
+type GraphSnapshot struct {
+	Scopes []*ScopeSnapshot `json:"scopes"`
+	Links  int              `json:"links"` // alive links of the whole graph
+}
+
+type ScopeSnapshot struct {
+	Scope string          `json:"scope"`
+	Nodes []*NodeSnapshot `json:"nodes"`
+}
+
+type NodeSnapshot struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	MaxLink     int      `json:"max_link"`     // output links can not exceed it
+	InputLinks  []string `json:"input_links"`  // ids of sender nodes
+	OutputLinks []string `json:"output_links"` // ids of receiver nodes
+	QueueDepth  int      `json:"queue_depth"`  // events waiting in data channel
+	QueueSize   int      `json:"queue_size"`
+}
+
+// Snapshot [SYNC] dumps nodes and links of the scope, or all scopes if scope is empty
+func (eg *Graph) Snapshot(scope string) *GraphSnapshot {
+	c := make(chan *GraphSnapshot, 1)
+	eg.deliveryEvent(newSnapshotRequest(snapshotRequest{scope: scope, c: c}))
+	return <-c
+}
+
 func NewEventGraph() *Graph {
 	eg := &Graph{
 		scopeMap:     make(scopeMapType),
@@ -336,6 +412,16 @@ func NewEventGraph() *Graph {
 	return eg
 }
 
+// NodeCount returns number of nodes in graph
+func (eg *Graph) NodeCount() int {
+	return int(eg.nbNode.Load())
+}
+
+// LinkCount returns number of alive links in graph
+func (eg *Graph) LinkCount() int {
+	return int(eg.nbLink.Load())
+}
+
 // AddNode [SYNC] add a node to graph and wait until completion, i.e. the node's OnEnter is invoked
 func (eg *Graph) AddNode(node Node) (success bool) {
 	c := make(chan bool, 1)
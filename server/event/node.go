@@ -18,6 +18,7 @@ type NodeDelegate struct {
 	id              string
 	ctrlC           chan *Event
 	dataC           chan *Event
+	dataQueue       chan *Event // the same channel as dataC but never reset, only for inspection
 	userEventDoneC  chan int
 	graph           *Graph
 	inExit          atomic.Value
@@ -95,6 +96,7 @@ func newNodeDelegate(graph *Graph, node Node, maxLink int) *NodeDelegate {
 
 	// only buffered channel can satisfy nonblock sending in most case
 	delegate.dataC = make(chan *Event, dataSize)
+	delegate.dataQueue = delegate.dataC
 	delegate.deliveryTimeout = deliveryTimeout
 	return delegate
 }
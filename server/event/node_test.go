@@ -320,3 +320,34 @@ func TestOnExitUnderConcurrentDeliver(t *testing.T) {
 		t.Fatal("deliveredEvents must equal to handledEvents, onExit must be called after all events handled")
 	}
 }
+
+func TestGraphSnapshot(t *testing.T) {
+	graph := event.NewEventGraph()
+	graph.AddNode(&testNode{scope: "snapshot", name: "r1"})
+	graph.AddNode(&testNode{scope: "snapshot", name: "r2"})
+	graph.AddNode(&testNode{scope: "other", name: "r3"})
+	sender := &testNode{scope: "snapshot", name: "s",
+		onEnter: func(tn *testNode) {
+			tn.delegate.RequestLinkUp("snapshot", "r1")
+			tn.delegate.RequestLinkUp("snapshot", "r2")
+		},
+	}
+	sender.SetMaxLink(4)
+	graph.AddNode(sender)
+
+	if all := graph.Snapshot(""); len(all.Scopes) != 2 || all.Links != 2 {
+		t.Fatalf("wrong snapshot of graph: %v", all)
+	}
+	snapshot := graph.Snapshot("snapshot")
+	if len(snapshot.Scopes) != 1 || len(snapshot.Scopes[0].Nodes) != 3 {
+		t.Fatalf("wrong snapshot of scope: %v", snapshot)
+	}
+	nodes := snapshot.Scopes[0].Nodes
+	r1, s := nodes[0], nodes[2]
+	if s.Id != "snapshot:s" || s.MaxLink != 4 || fmt.Sprint(s.OutputLinks) != "[snapshot:r1 snapshot:r2]" {
+		t.Fatalf("wrong snapshot of sender: %+v", s)
+	}
+	if fmt.Sprint(r1.InputLinks) != "[snapshot:s]" || len(r1.OutputLinks) != 0 || r1.QueueSize == 0 {
+		t.Fatalf("wrong snapshot of receiver: %+v", r1)
+	}
+}
@@ -6,6 +6,7 @@ const (
 	reqLinkDown
 	reqNodeAdd
 	reqNodeExit
+	reqSnapshot
 )
 
 const (
@@ -43,6 +44,11 @@ type nodeExitRequest struct {
 	delegate *NodeDelegate
 }
 
+type snapshotRequest struct {
+	scope string
+	c     chan *GraphSnapshot
+}
+
 /* ------- response structs ------- */
 type linkUpResponse struct {
 	state    int
@@ -94,6 +100,10 @@ func newNodeExitRequest(node *NodeDelegate) *Event {
 	return NewEvent(reqNodeExit, &nodeExitRequest{node})
 }
 
+func newSnapshotRequest(req snapshotRequest) *Event {
+	return NewEvent(reqSnapshot, &req)
+}
+
 /* ---------------RESPONSE------------------- */
 func newLinkUpResponse(resp *dlink, state int, scope string, name string, c chan int) *Event {
 	return NewEvent(respLinkUp, &linkUpResponse{state, resp, scope, name, c})
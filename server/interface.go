@@ -43,6 +43,33 @@ type RtpPacketConsumer interface {
 	HandlePacketChannel() chan<- *utils.RtpPacketList
 }
 
+// DtmfConsumer consumes dtmf digits decoded from telephone-event packets of RTP session
+type DtmfConsumer interface {
+	comp.NodeTraitTag
+	HandleDtmfChannel() chan<- *comp.DtmfMessage
+}
+
+// DtmfProvider provides dtmf digits for RTP session
+// send loop generates telephone-event packets for them and interleaves them with rtp data packets
+type DtmfProvider interface {
+	comp.NodeTraitTag
+	PullDtmfChannel() <-chan *comp.DtmfMessage
+}
+
+// MediaDirectionConsumer is notified of the initial direction of RTP session's media stream and every change
+// afterwards, i.e. to play music while peer is put on hold. a stream can have more than one of them
+type MediaDirectionConsumer interface {
+	comp.NodeTraitTag
+	HandleMediaDirectionChannel() chan<- *comp.MediaDirectionMessage
+}
+
+// MediaStreamBinder is optional for rtp packet/dtmf providers and consumers as well as direction consumers, it tells
+// which media stream of session the node binds to. nodes without it, or returning empty name, bind to the first stream
+type MediaStreamBinder interface {
+	comp.NodeTraitTag
+	MediaStream() string
+}
+
 // RtpPacketInterceptor can intercept packets bidirectional, that is on the way of graph -> socket or socket -> graph
 type RtpPacketInterceptor interface {
 	InterceptRtpPacket(pl *utils.RtpPacketList)
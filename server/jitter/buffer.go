@@ -0,0 +1,280 @@
+package jitter
+
+import (
+	"github.com/appcrash/media/server/prom"
+	"github.com/appcrash/media/server/utils"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// sequence number gaps to resync, refer to RFC 3550 A.1
+	maxDropout  = 3000
+	maxMisorder = 100
+
+	jitterGain   = 16 // gain of inter-arrival jitter estimator, same as RFC 3550 A.8
+	shrinkMargin = 2  // adaptive buffer drops packets when depth exceeds target by this many packets
+)
+
+var (
+	lostCounter    = prom.RtpJitterBufferPacket.WithLabelValues("lost")
+	lateCounter    = prom.RtpJitterBufferPacket.WithLabelValues("late")
+	discardCounter = prom.RtpJitterBufferPacket.WithLabelValues("discard")
+)
+
+// Config of jitter buffer, delays are converted to number of packets by codec time step
+type Config struct {
+	MinDelay time.Duration // play-out delay when not adaptive, the lower bound otherwise
+	MaxDelay time.Duration // packets can not be buffered longer than it
+	Adaptive bool          // adapt play-out delay to estimated network jitter within [MinDelay,MaxDelay]
+}
+
+// Stats counts packets that are not played out normally
+type Stats struct {
+	Lost      uint64 // packets not arrived at their play-out time
+	Late      uint64 // packets arrived after their play-out time
+	Discarded uint64 // duplicated packets or packets evicted from a full buffer
+}
+
+// Buffer reorders received rtp packets by sequence number and plays them out at codec cadence, i.e. Pop is called
+// once every Step(). packets of the same timestamp (e.g. video frame) are played out together as a packet list.
+type Buffer struct {
+	mutex sync.Mutex
+
+	step        time.Duration
+	minDepth    int
+	maxDepth    int
+	targetDepth int
+	adaptive    bool
+
+	slots       []*utils.RtpPacketList // ring buffer indexed by sequence number, its size is power of two
+	count       int
+	initialized bool
+	prefilling  bool
+	ssrc        uint32
+	nextSeq     uint16 // sequence number of next play-out packet
+	highestSeq  uint16
+
+	lastSeq     uint16
+	lastArrival time.Time
+	jitter      float64 // estimated inter-arrival jitter in nanoseconds
+
+	stats Stats
+}
+
+func NewBuffer(step time.Duration, config Config) *Buffer {
+	if step <= 0 {
+		step = 20 * time.Millisecond
+	}
+	minDepth := int(math.Ceil(float64(config.MinDelay) / float64(step)))
+	if minDepth < 1 {
+		minDepth = 1
+	}
+	maxDepth := int(math.Ceil(float64(config.MaxDelay) / float64(step)))
+	if maxDepth < minDepth {
+		maxDepth = minDepth
+	}
+	// size of ring must divide 65536, so that slots are kept in order when sequence number wraps
+	size := 1
+	for size < maxDepth && size < 1<<16 {
+		size <<= 1
+	}
+	return &Buffer{
+		step:        step,
+		minDepth:    minDepth,
+		maxDepth:    maxDepth,
+		targetDepth: minDepth,
+		adaptive:    config.Adaptive,
+		slots:       make([]*utils.RtpPacketList, size),
+	}
+}
+
+// Step is the play-out interval
+func (b *Buffer) Step() time.Duration {
+	return b.step
+}
+
+func (b *Buffer) Stats() Stats {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.stats
+}
+
+// TargetDepth is the number of packets buffered before playing out
+func (b *Buffer) TargetDepth() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.targetDepth
+}
+
+// Push puts a received packet to buffer, arrival is the time it is received
+func (b *Buffer) Push(pl *utils.RtpPacketList, arrival time.Time) {
+	if pl == nil {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !b.initialized || pl.Ssrc != b.ssrc {
+		b.reset(pl)
+	}
+	b.estimate(pl, arrival)
+
+	diff := int(int16(pl.Sequence - b.nextSeq))
+	if diff < 0 {
+		if diff >= -maxMisorder {
+			// its play-out time has passed
+			b.stats.Late++
+			lateCounter.Inc()
+			return
+		}
+		// peer restarted sequence number
+		b.reset(pl)
+		diff = 0
+	} else if diff >= b.maxDepth {
+		if diff > maxDropout {
+			b.reset(pl)
+			diff = 0
+		} else {
+			// buffer overflows, move the window forward to make room for this packet
+			for ; diff >= b.maxDepth; diff-- {
+				b.skip()
+			}
+		}
+	}
+
+	idx := b.index(pl.Sequence)
+	if b.slots[idx] != nil {
+		b.stats.Discarded++
+		discardCounter.Inc()
+		return
+	}
+	b.slots[idx] = pl
+	b.count++
+	if b.count == 1 || int16(pl.Sequence-b.highestSeq) > 0 {
+		b.highestSeq = pl.Sequence
+	}
+}
+
+// Pop returns packets to play out at this moment, or nil if nothing can be played out due to prefilling,
+// underrun or packet loss
+func (b *Buffer) Pop() (pl *utils.RtpPacketList) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if !b.initialized {
+		return
+	}
+	if b.count == 0 {
+		// underrun, wait for buffer refilled to target depth
+		b.prefilling = true
+		return
+	}
+	if b.prefilling {
+		if b.depth() < b.targetDepth {
+			return
+		}
+		b.prefilling = false
+	}
+	if b.adaptive && b.depth() > b.targetDepth+shrinkMargin {
+		// network gets better, shorten the play-out delay
+		b.skip()
+		if b.count == 0 {
+			return
+		}
+	}
+
+	idx := b.index(b.nextSeq)
+	if pl = b.slots[idx]; pl == nil {
+		b.stats.Lost++
+		lostCounter.Inc()
+		b.nextSeq++
+		return
+	}
+	b.take(idx)
+	// packets sharing the same timestamp are played out at once
+	for last := pl; b.count > 0; {
+		idx = b.index(b.nextSeq)
+		next := b.slots[idx]
+		if next == nil || next.Pts != pl.Pts {
+			break
+		}
+		b.take(idx)
+		last.SetNext(next)
+		last = next
+	}
+	return
+}
+
+func (b *Buffer) index(seq uint16) int {
+	return int(seq) & (len(b.slots) - 1)
+}
+
+// depth is the count of packets from next play-out one to the highest received one, missing ones included
+func (b *Buffer) depth() int {
+	if b.count == 0 {
+		return 0
+	}
+	return int(b.highestSeq-b.nextSeq) + 1
+}
+
+func (b *Buffer) take(idx int) {
+	b.slots[idx] = nil
+	b.count--
+	b.nextSeq++
+}
+
+// skip drops the packet of next play-out sequence number
+func (b *Buffer) skip() {
+	idx := b.index(b.nextSeq)
+	if b.slots[idx] != nil {
+		b.slots[idx] = nil
+		b.count--
+		b.stats.Discarded++
+		discardCounter.Inc()
+	} else {
+		b.stats.Lost++
+		lostCounter.Inc()
+	}
+	b.nextSeq++
+}
+
+// reset drops all buffered packets and starts from pl
+func (b *Buffer) reset(pl *utils.RtpPacketList) {
+	if b.count > 0 {
+		b.stats.Discarded += uint64(b.count)
+		discardCounter.Add(float64(b.count))
+	}
+	for i := range b.slots {
+		b.slots[i] = nil
+	}
+	b.count = 0
+	b.initialized = true
+	b.prefilling = true
+	b.ssrc = pl.Ssrc
+	b.nextSeq = pl.Sequence
+	b.highestSeq = pl.Sequence
+	b.lastArrival = time.Time{}
+}
+
+// estimate updates inter-arrival jitter by the difference between arrival interval and the interval implied by
+// sequence numbers, then adjusts target depth accordingly
+func (b *Buffer) estimate(pl *utils.RtpPacketList, arrival time.Time) {
+	if !b.lastArrival.IsZero() {
+		expected := time.Duration(int16(pl.Sequence-b.lastSeq)) * b.step
+		d := math.Abs(float64(arrival.Sub(b.lastArrival) - expected))
+		b.jitter += (d - b.jitter) / jitterGain
+	}
+	b.lastSeq = pl.Sequence
+	b.lastArrival = arrival
+
+	if !b.adaptive {
+		return
+	}
+	target := b.minDepth + int(math.Ceil(2*b.jitter/float64(b.step)))
+	if target > b.maxDepth {
+		target = b.maxDepth
+	}
+	b.targetDepth = target
+}
@@ -0,0 +1,139 @@
+package jitter_test
+
+import (
+	"github.com/appcrash/media/server/jitter"
+	"github.com/appcrash/media/server/utils"
+	"testing"
+	"time"
+)
+
+const step = 20 * time.Millisecond
+
+func packet(seq uint16) *utils.RtpPacketList {
+	return &utils.RtpPacketList{Sequence: seq, Pts: uint32(seq) * 160, Ssrc: 1}
+}
+
+func popAll(b *jitter.Buffer, n int) (seqs []int) {
+	for i := 0; i < n; i++ {
+		if pl := b.Pop(); pl != nil {
+			seqs = append(seqs, int(pl.Sequence))
+		} else {
+			seqs = append(seqs, -1)
+		}
+	}
+	return
+}
+
+func equal(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestReorder(t *testing.T) {
+	b := jitter.NewBuffer(step, jitter.Config{MinDelay: 3 * step, MaxDelay: 10 * step})
+	now := time.Now()
+	for _, seq := range []uint16{100, 102, 101, 103} {
+		b.Push(packet(seq), now)
+	}
+	if got := popAll(b, 5); !equal(got, []int{100, 101, 102, 103, -1}) {
+		t.Fatalf("wrong play-out order: %v", got)
+	}
+	if s := b.Stats(); s.Lost != 0 || s.Late != 0 || s.Discarded != 0 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestPrefill(t *testing.T) {
+	b := jitter.NewBuffer(step, jitter.Config{MinDelay: 3 * step, MaxDelay: 10 * step})
+	now := time.Now()
+	b.Push(packet(10), now)
+	b.Push(packet(11), now)
+	if pl := b.Pop(); pl != nil {
+		t.Fatal("should not play out before reaching min delay")
+	}
+	b.Push(packet(12), now)
+	if got := popAll(b, 3); !equal(got, []int{10, 11, 12}) {
+		t.Fatalf("wrong play-out: %v", got)
+	}
+}
+
+func TestSequenceWrap(t *testing.T) {
+	b := jitter.NewBuffer(step, jitter.Config{MinDelay: step, MaxDelay: 5 * step})
+	now := time.Now()
+	for _, seq := range []uint16{65534, 65535, 0, 1} {
+		b.Push(packet(seq), now)
+	}
+	if got := popAll(b, 4); !equal(got, []int{65534, 65535, 0, 1}) {
+		t.Fatalf("wrong play-out across wrap: %v", got)
+	}
+	if s := b.Stats(); s.Lost != 0 || s.Late != 0 || s.Discarded != 0 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestLostLateDuplicate(t *testing.T) {
+	b := jitter.NewBuffer(step, jitter.Config{MinDelay: step, MaxDelay: 10 * step})
+	now := time.Now()
+	b.Push(packet(0), now)
+	b.Push(packet(0), now)
+	b.Push(packet(2), now)
+	if got := popAll(b, 3); !equal(got, []int{0, -1, 2}) {
+		t.Fatalf("wrong play-out: %v", got)
+	}
+	b.Push(packet(1), now)
+	s := b.Stats()
+	if s.Lost != 1 || s.Late != 1 || s.Discarded != 1 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestOverflow(t *testing.T) {
+	b := jitter.NewBuffer(step, jitter.Config{MinDelay: step, MaxDelay: 4 * step})
+	now := time.Now()
+	for seq := uint16(0); seq < 6; seq++ {
+		b.Push(packet(seq), now)
+	}
+	if got := popAll(b, 4); !equal(got, []int{2, 3, 4, 5}) {
+		t.Fatalf("wrong play-out: %v", got)
+	}
+	if s := b.Stats(); s.Discarded != 2 {
+		t.Fatalf("unexpected stats: %+v", s)
+	}
+}
+
+func TestSameTimestamp(t *testing.T) {
+	b := jitter.NewBuffer(step, jitter.Config{MinDelay: step, MaxDelay: 4 * step})
+	now := time.Now()
+	for seq := uint16(0); seq < 3; seq++ {
+		pl := packet(seq)
+		pl.Pts = 9000
+		b.Push(pl, now)
+	}
+	pl := b.Pop()
+	if pl == nil || pl.Len() != 3 {
+		t.Fatal("packets of same timestamp should be played out together")
+	}
+}
+
+func TestAdaptive(t *testing.T) {
+	b := jitter.NewBuffer(step, jitter.Config{MinDelay: step, MaxDelay: 10 * step, Adaptive: true})
+	now := time.Now()
+	for seq := uint16(0); seq < 100; seq++ {
+		// arrivals alternate between early and late by 30ms
+		arrival := now.Add(time.Duration(seq) * step)
+		if seq%2 == 0 {
+			arrival = arrival.Add(30 * time.Millisecond)
+		}
+		b.Push(packet(seq), arrival)
+	}
+	if d := b.TargetDepth(); d <= 1 || d > 10 {
+		t.Fatalf("target depth should grow within bounds, got %v", d)
+	}
+}
@@ -0,0 +1,213 @@
+package latch
+
+import (
+	"encoding/binary"
+	"github.com/appcrash/GoRTP/rtp"
+	"github.com/appcrash/media/server/prom"
+	"github.com/appcrash/media/server/utils"
+	"net"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const rtpHeaderLength = 12
+
+var (
+	latchCounter   = prom.RtpLatch.WithLabelValues("latch")
+	relatchCounter = prom.RtpLatch.WithLabelValues("relatch")
+	rejectCounter  = prom.RtpLatch.WithLabelValues("reject")
+)
+
+// Config of latching, a remote address is learned only from packets of expected payload types
+type Config struct {
+	Window       time.Duration             // remote address can only be learned within this period since Start
+	PayloadTypes []uint8                   // packets of other payload types never trigger latching
+	OnLatch      func(remote *net.UDPAddr) // called in receiving goroutine once remote address changed
+}
+
+// Transport stacks on top of another GoRTP transport to implement symmetric rtp(RFC 4961), i.e. it learns the
+// actual source address of peer and sends packets there instead of the address from signalling, which is
+// usually wrong if peer is behind NAT.
+//
+// the first valid packet received within latch window decides the remote address, after that packets from other
+// addresses are rejected, unless they carry a new ssrc within latch window, then transport re-latches to the new
+// address. once the window is closed, the remote address never changes.
+type Transport struct {
+	lowerRecv  rtp.TransportRecv
+	lowerWrite rtp.TransportWrite
+	callUpper  rtp.TransportRecv
+
+	window       time.Duration
+	payloadTypes map[uint8]bool
+	onLatch      func(remote *net.UDPAddr)
+
+	mutex    sync.Mutex
+	deadline time.Time // zero if not started
+	latched  bool
+	remote   net.UDPAddr
+	ctrlPort int // learned from rtcp packets, zero if none received
+	ssrc     uint32
+}
+
+func NewTransport(lowerRecv rtp.TransportRecv, lowerWrite rtp.TransportWrite, config Config) *Transport {
+	t := &Transport{
+		lowerRecv:    lowerRecv,
+		lowerWrite:   lowerWrite,
+		window:       config.Window,
+		payloadTypes: make(map[uint8]bool),
+		onLatch:      config.OnLatch,
+	}
+	for _, pt := range config.PayloadTypes {
+		t.payloadTypes[pt] = true
+	}
+	lowerRecv.SetCallUpper(t)
+	return t
+}
+
+// Start opens the latch window
+func (t *Transport) Start() {
+	t.mutex.Lock()
+	t.deadline = time.Now().Add(t.window)
+	t.mutex.Unlock()
+}
+
+// Remote returns the latched address, ok is false if nothing latched
+func (t *Transport) Remote() (remote *net.UDPAddr, ok bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.latched {
+		return
+	}
+	r := t.remote
+	return &r, true
+}
+
+// sourceOf returns source address of a received packet, which is not exported by GoRTP
+func sourceOf(packet interface{}) net.UDPAddr {
+	field := reflect.ValueOf(packet).Elem().FieldByName("fromAddr")
+	addr := utils.GetField(field).Interface().(rtp.Address)
+	port := addr.DataPort
+	if port == 0 {
+		port = addr.CtrlPort
+	}
+	return net.UDPAddr{IP: addr.IPAddr, Port: port, Zone: addr.Zone}
+}
+
+// acceptData decides whether a data packet from source can be passed to upper layer, latching happens here
+func (t *Transport) acceptData(rp *rtp.DataPacket, source net.UDPAddr) bool {
+	buffer := rp.Buffer()
+	if rp.InUse() < rtpHeaderLength || buffer[0]>>6 != 2 {
+		return false
+	}
+	ssrc := binary.BigEndian.Uint32(buffer[8:])
+	t.mutex.Lock()
+	if t.latched && t.remote.IP.Equal(source.IP) && t.remote.Port == source.Port {
+		t.ssrc = ssrc
+		t.mutex.Unlock()
+		return true
+	}
+	// learning is allowed within latch window, and the same stream(ssrc) from another address is never learned,
+	// as it is probably hijacking
+	learning := !t.deadline.IsZero() && time.Now().Before(t.deadline) && t.payloadTypes[rp.PayloadType()]
+	if !learning || (t.latched && ssrc == t.ssrc) {
+		latched := t.latched
+		t.mutex.Unlock()
+		if latched {
+			rejectCounter.Inc()
+		}
+		return !latched
+	}
+	if t.latched {
+		relatchCounter.Inc()
+	} else {
+		latchCounter.Inc()
+	}
+	t.latched = true
+	t.remote = source
+	t.ctrlPort = 0
+	t.ssrc = ssrc
+	t.mutex.Unlock()
+	if t.onLatch != nil {
+		t.onLatch(&source)
+	}
+	return true
+}
+
+// *** rtp.TransportRecv
+
+func (t *Transport) ListenOnTransports() error {
+	return t.lowerRecv.ListenOnTransports()
+}
+
+func (t *Transport) OnRecvData(rp *rtp.DataPacket) bool {
+	if t.callUpper == nil || !t.acceptData(rp, sourceOf(rp)) {
+		return false
+	}
+	return t.callUpper.OnRecvData(rp)
+}
+
+// OnRecvCtrl accepts rtcp packets from latched ip only, and learns rtcp port from them
+func (t *Transport) OnRecvCtrl(rp *rtp.CtrlPacket) bool {
+	if t.callUpper == nil {
+		return false
+	}
+	source := sourceOf(rp)
+	t.mutex.Lock()
+	if t.latched {
+		if !t.remote.IP.Equal(source.IP) {
+			t.mutex.Unlock()
+			rejectCounter.Inc()
+			return false
+		}
+		t.ctrlPort = source.Port
+	}
+	t.mutex.Unlock()
+	return t.callUpper.OnRecvCtrl(rp)
+}
+
+func (t *Transport) SetCallUpper(upper rtp.TransportRecv) {
+	t.callUpper = upper
+}
+
+func (t *Transport) CloseRecv() {
+	t.lowerRecv.CloseRecv()
+}
+
+func (t *Transport) SetEndChannel(ch rtp.TransportEnd) {
+	t.lowerRecv.SetEndChannel(ch)
+}
+
+// *** rtp.TransportWrite
+
+// redirect returns latched address if any, otherwise the address given by rtp session
+func (t *Transport) redirect(addr *rtp.Address) *rtp.Address {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.latched {
+		return addr
+	}
+	ctrlPort := t.ctrlPort
+	if ctrlPort == 0 {
+		ctrlPort = t.remote.Port + 1
+	}
+	return &rtp.Address{IPAddr: t.remote.IP, DataPort: t.remote.Port, CtrlPort: ctrlPort, Zone: t.remote.Zone}
+}
+
+func (t *Transport) WriteDataTo(rp *rtp.DataPacket, addr *rtp.Address) (n int, err error) {
+	return t.lowerWrite.WriteDataTo(rp, t.redirect(addr))
+}
+
+func (t *Transport) WriteCtrlTo(rp *rtp.CtrlPacket, addr *rtp.Address) (n int, err error) {
+	return t.lowerWrite.WriteCtrlTo(rp, t.redirect(addr))
+}
+
+func (t *Transport) SetToLower(lower rtp.TransportWrite) {
+	if lower != nil {
+		t.lowerWrite = lower
+	}
+}
+
+func (t *Transport) CloseWrite() {
+	t.lowerWrite.CloseWrite()
+}
@@ -0,0 +1,150 @@
+package latch_test
+
+import (
+	"github.com/appcrash/GoRTP/rtp"
+	"github.com/appcrash/media/server/latch"
+	"net"
+	"testing"
+	"time"
+)
+
+const window = 500 * time.Millisecond
+
+var localIp = net.ParseIP("127.0.0.1")
+
+func newSession(t *testing.T, tpRecv rtp.TransportRecv, tpWrite rtp.TransportWrite, port, remotePort int,
+	ssrc uint32) *rtp.Session {
+	session := rtp.NewSession(tpWrite, tpRecv)
+	if _, errStr := session.NewSsrcStreamOut(&rtp.Address{IPAddr: localIp, DataPort: port, CtrlPort: port + 1},
+		ssrc, 0); errStr != "" {
+		t.Fatal(errStr)
+	}
+	session.SsrcStreamOutForIndex(0).SetProfile("PCMA", 8)
+	if _, err := session.AddRemote(&rtp.Address{IPAddr: localIp, DataPort: remotePort,
+		CtrlPort: remotePort + 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := session.StartSession(); err != nil {
+		t.Fatal(err)
+	}
+	return session
+}
+
+func newPeer(t *testing.T, port, remotePort int, ssrc uint32) *rtp.Session {
+	tp, err := rtp.NewTransportUDP(&net.IPAddr{IP: localIp}, port, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newSession(t, tp, tp, port, remotePort, ssrc)
+}
+
+// drain received packets, otherwise GoRTP blocks receiving
+func drain(session *rtp.Session) {
+	recvC := session.CreateDataReceiveChan()
+	go func() {
+		for range recvC {
+		}
+	}()
+}
+
+func send(t *testing.T, session *rtp.Session, n int) {
+	for i := 0; i < n; i++ {
+		packet := session.NewDataPacket(uint32(i * 160))
+		packet.SetPayloadType(8)
+		packet.SetPayload(make([]byte, 160))
+		if _, err := session.WriteData(packet); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func expectRemote(t *testing.T, transport *latch.Transport, port int) {
+	time.Sleep(50 * time.Millisecond)
+	remote, ok := transport.Remote()
+	if port == 0 {
+		if ok {
+			t.Fatalf("should not latch: %v", remote)
+		}
+		return
+	}
+	if !ok || remote.Port != port {
+		t.Fatalf("should latch to port %v: %v", port, remote)
+	}
+}
+
+func TestLatch(t *testing.T) {
+	const port, wrongPort, peerPort, hijackPort, latePort = 32000, 32100, 32010, 32020, 32030
+	tp, err := rtp.NewTransportUDP(&net.IPAddr{IP: localIp}, port, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	latchC := make(chan *net.UDPAddr, 8)
+	transport := latch.NewTransport(tp, tp, latch.Config{
+		Window:       window,
+		PayloadTypes: []uint8{8},
+		OnLatch:      func(remote *net.UDPAddr) { latchC <- remote },
+	})
+	local := newSession(t, transport, transport, port, wrongPort, 0)
+	defer local.CloseSession()
+	drain(local)
+	transport.Start()
+
+	peer := newPeer(t, peerPort, port, 1)
+	defer peer.CloseSession()
+	send(t, peer, 3)
+	expectRemote(t, transport, peerPort)
+	if remote := <-latchC; remote.Port != peerPort {
+		t.Fatalf("wrong latched address: %v", remote)
+	}
+
+	// packets are sent to latched address rather than the one from signalling
+	recvC := peer.CreateDataReceiveChan()
+	send(t, local, 1)
+	select {
+	case <-recvC:
+	case <-time.After(time.Second):
+		t.Fatal("peer should receive packets from latched session")
+	}
+
+	// the same ssrc from another address never re-latches
+	hijacker := newPeer(t, hijackPort, port, 1)
+	send(t, hijacker, 3)
+	expectRemote(t, transport, peerPort)
+	hijacker.CloseSession()
+
+	// a new ssrc re-latches within window
+	relatch := newPeer(t, hijackPort+4, port, 2)
+	send(t, relatch, 3)
+	expectRemote(t, transport, hijackPort+4)
+	relatch.CloseSession()
+
+	// nothing changes once window is closed
+	time.Sleep(window)
+	late := newPeer(t, latePort, port, 3)
+	send(t, late, 3)
+	expectRemote(t, transport, hijackPort+4)
+	late.CloseSession()
+}
+
+func TestNotStarted(t *testing.T) {
+	const port, peerPort = 32040, 32050
+	tp, err := rtp.NewTransportUDP(&net.IPAddr{IP: localIp}, port, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	transport := latch.NewTransport(tp, tp, latch.Config{Window: window, PayloadTypes: []uint8{8}})
+	local := newSession(t, transport, transport, port, peerPort, 0)
+	defer local.CloseSession()
+	recvC := local.CreateDataReceiveChan()
+
+	peer := newPeer(t, peerPort, port, 1)
+	defer peer.CloseSession()
+	send(t, peer, 1)
+	select {
+	case <-recvC:
+	case <-time.After(time.Second):
+		t.Fatal("packets should pass through before latching")
+	}
+	expectRemote(t, transport, 0)
+}
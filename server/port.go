@@ -49,6 +49,13 @@ noPort:
 	return
 }
 
+// Free returns number of ports can be allocated, and total number of ports in pool
+func (p *PortPool) Free() (free int, total int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.freePortSet.Size(), int(p.end-p.start) / 2
+}
+
 func (p *PortPool) Put(port uint16) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
@@ -58,3 +65,14 @@ func (p *PortPool) Put(port uint16) {
 	}
 	p.freePortSet.Add(port)
 }
+
+// Reserve takes the given port out of pool, returns false if it is not free
+func (p *PortPool) Reserve(port uint16) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.freePortSet.Contain(port) {
+		return false
+	}
+	p.freePortSet.Remove(port)
+	return true
+}
@@ -37,6 +37,14 @@ var (
 		Name: "rtp_abnormal_session",
 		Help: "Abnormal exited rtp session(rtp/rtcp loops not fully exited)",
 	})
+	RtpWatchdogStop = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rtp_watchdog_stop",
+		Help: "Sessions stopped by watchdog, labeled by stop reason",
+	}, []string{"reason"})
+	RtpRecoveredSession = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rtp_recovered_session",
+		Help: "Sessions loaded from store when server starts(recovered,failed)",
+	}, []string{"result"})
 	RtpSessionGoroutine = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "rtp_session_goroutine",
 		Help: "goroutine for session(send,recv,recv_ctrl)",
@@ -45,10 +53,50 @@ var (
 		Name: "rtp_used_port_pair",
 		Help: "Port pairs(rtp/rtcp) allocated",
 	})
+	RtpJitterBufferPacket = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rtp_jitter_buffer_packet",
+		Help: "Packets not played out normally by jitter buffer(lost,late,discard)",
+	}, []string{"type"})
+	RtpSrtpError = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rtp_srtp_error",
+		Help: "Packets dropped by srtp transport(malformed,auth,replay,no_key)",
+	}, []string{"type"})
+	RtpLatch = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "rtp_latch",
+		Help: "Remote address latching(latch,relatch) and packets rejected from other addresses(reject)",
+	}, []string{"type"})
+	RtpSessionFractionLost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rtp_session_fraction_lost",
+		Help: "Loss ratio of last rtcp report interval(inbound,outbound)",
+	}, []string{"session_id", "instance_id", "stream", "direction"})
+	RtpSessionCumulativeLost = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rtp_session_cumulative_lost",
+		Help: "Packets lost since session starts(inbound,outbound)",
+	}, []string{"session_id", "instance_id", "stream", "direction"})
+	RtpSessionJitter = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rtp_session_jitter_seconds",
+		Help: "Inter-arrival jitter(inbound,outbound)",
+	}, []string{"session_id", "instance_id", "stream", "direction"})
+	RtpSessionRtt = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rtp_session_rtt_seconds",
+		Help: "Round-trip time computed from rtcp receiver reports",
+	}, []string{"session_id", "instance_id", "stream"})
+	RtpSessionMos = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "rtp_session_mos",
+		Help: "E-model MOS estimate of inbound audio",
+	}, []string{"session_id", "instance_id", "stream"})
 	GrpcSessionAction = prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "grpc_session_action",
 		Help: "Executed action on session",
 	}, []string{"cmd", "type"})
+	GrpcSessionRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "grpc_session_rejected",
+		Help: "Sessions rejected by limits(sessions,instance_sessions,nodes,links,transcode_contexts,rtp_port)",
+	}, []string{"resource"})
+	GrpcDraining = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "grpc_draining",
+		Help: "1 if server is draining sessions before stop",
+	})
 )
 
 func InitCollector() {
@@ -62,9 +110,21 @@ func InitCollector() {
 		RtpStartedSession,
 		RtpAllSession,
 		RtpAbnormalSession,
+		RtpWatchdogStop,
+		RtpRecoveredSession,
 		GrpcSessionAction,
+		GrpcSessionRejected,
+		GrpcDraining,
 		RtpSessionGoroutine,
 		RtpUsedPortPair,
+		RtpJitterBufferPacket,
+		RtpSrtpError,
+		RtpLatch,
+		RtpSessionFractionLost,
+		RtpSessionCumulativeLost,
+		RtpSessionJitter,
+		RtpSessionRtt,
+		RtpSessionMos,
 	}
 	for _, c := range cs {
 		prometheus.MustRegister(c)
@@ -23,19 +23,19 @@ const (
 type Version int32
 
 const (
-	Version_DUMMY   Version = 0 // first must be zero in proto3
-	Version_DEFAULT Version = 2 // increase it every time this file being changed
+	Version_DUMMY   Version = 0  // first must be zero in proto3
+	Version_DEFAULT Version = 22 // increase it every time this file being changed
 )
 
 // Enum value maps for Version.
 var (
 	Version_name = map[int32]string{
-		0: "DUMMY",
-		2: "DEFAULT",
+		0:  "DUMMY",
+		22: "DEFAULT",
 	}
 	Version_value = map[string]int32{
 		"DUMMY":   0,
-		"DEFAULT": 2,
+		"DEFAULT": 22,
 	}
 )
 
@@ -130,13 +130,298 @@ func (CodecType) EnumDescriptor() ([]byte, []int) {
 	return file_msapi_proto_rawDescGZIP(), []int{1}
 }
 
+type SessionStatus int32
+
+const (
+	SessionStatus_SESSION_CREATED SessionStatus = 0
+	SessionStatus_SESSION_STARTED SessionStatus = 2
+	SessionStatus_SESSION_STOPPED SessionStatus = 3
+)
+
+// Enum value maps for SessionStatus.
+var (
+	SessionStatus_name = map[int32]string{
+		0: "SESSION_CREATED",
+		2: "SESSION_STARTED",
+		3: "SESSION_STOPPED",
+	}
+	SessionStatus_value = map[string]int32{
+		"SESSION_CREATED": 0,
+		"SESSION_STARTED": 2,
+		"SESSION_STOPPED": 3,
+	}
+)
+
+func (x SessionStatus) Enum() *SessionStatus {
+	p := new(SessionStatus)
+	*p = x
+	return p
+}
+
+func (x SessionStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_msapi_proto_enumTypes[2].Descriptor()
+}
+
+func (SessionStatus) Type() protoreflect.EnumType {
+	return &file_msapi_proto_enumTypes[2]
+}
+
+func (x SessionStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionStatus.Descriptor instead.
+func (SessionStatus) EnumDescriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{2}
+}
+
+type StopReason int32
+
+const (
+	StopReason_STOP_REASON_NONE StopReason = 0 // not stopped yet
+	StopReason_RPC_STOP         StopReason = 1 // StopSession called
+	StopReason_RTCP_BYE         StopReason = 2 // peer sent rtcp bye
+	StopReason_WATCHDOG_TIMEOUT StopReason = 3
+	StopReason_TOO_MANY_ERRORS  StopReason = 4 // send/receive loops report too many errors
+	StopReason_START_FAILED     StopReason = 5
+	StopReason_DRAIN_TIMEOUT    StopReason = 6  // still alive when draining server reaches its deadline
+	StopReason_MEDIA_INACTIVE   StopReason = 7  // no packet sent or received in inactivity_timeout of watchdog
+	StopReason_ONE_WAY_MEDIA    StopReason = 8  // packets flow in only one direction of a stream in one_way_timeout of watchdog
+	StopReason_MAX_DURATION     StopReason = 9  // lasts longer than max_duration of watchdog
+	StopReason_HEARTBEAT_LOST   StopReason = 10 // instance reports no session info in heartbeat_timeout of watchdog
+)
+
+// Enum value maps for StopReason.
+var (
+	StopReason_name = map[int32]string{
+		0:  "STOP_REASON_NONE",
+		1:  "RPC_STOP",
+		2:  "RTCP_BYE",
+		3:  "WATCHDOG_TIMEOUT",
+		4:  "TOO_MANY_ERRORS",
+		5:  "START_FAILED",
+		6:  "DRAIN_TIMEOUT",
+		7:  "MEDIA_INACTIVE",
+		8:  "ONE_WAY_MEDIA",
+		9:  "MAX_DURATION",
+		10: "HEARTBEAT_LOST",
+	}
+	StopReason_value = map[string]int32{
+		"STOP_REASON_NONE": 0,
+		"RPC_STOP":         1,
+		"RTCP_BYE":         2,
+		"WATCHDOG_TIMEOUT": 3,
+		"TOO_MANY_ERRORS":  4,
+		"START_FAILED":     5,
+		"DRAIN_TIMEOUT":    6,
+		"MEDIA_INACTIVE":   7,
+		"ONE_WAY_MEDIA":    8,
+		"MAX_DURATION":     9,
+		"HEARTBEAT_LOST":   10,
+	}
+)
+
+func (x StopReason) Enum() *StopReason {
+	p := new(StopReason)
+	*p = x
+	return p
+}
+
+func (x StopReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StopReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_msapi_proto_enumTypes[3].Descriptor()
+}
+
+func (StopReason) Type() protoreflect.EnumType {
+	return &file_msapi_proto_enumTypes[3]
+}
+
+func (x StopReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StopReason.Descriptor instead.
+func (StopReason) EnumDescriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{3}
+}
+
+type SessionEventType int32
+
+const (
+	SessionEventType_SESSION_EVENT_CREATED SessionEventType = 0
+	SessionEventType_SESSION_EVENT_UPDATED SessionEventType = 1
+	SessionEventType_SESSION_EVENT_STARTED SessionEventType = 2
+	SessionEventType_SESSION_EVENT_STOPPED SessionEventType = 3
+)
+
+// Enum value maps for SessionEventType.
+var (
+	SessionEventType_name = map[int32]string{
+		0: "SESSION_EVENT_CREATED",
+		1: "SESSION_EVENT_UPDATED",
+		2: "SESSION_EVENT_STARTED",
+		3: "SESSION_EVENT_STOPPED",
+	}
+	SessionEventType_value = map[string]int32{
+		"SESSION_EVENT_CREATED": 0,
+		"SESSION_EVENT_UPDATED": 1,
+		"SESSION_EVENT_STARTED": 2,
+		"SESSION_EVENT_STOPPED": 3,
+	}
+)
+
+func (x SessionEventType) Enum() *SessionEventType {
+	p := new(SessionEventType)
+	*p = x
+	return p
+}
+
+func (x SessionEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_msapi_proto_enumTypes[4].Descriptor()
+}
+
+func (SessionEventType) Type() protoreflect.EnumType {
+	return &file_msapi_proto_enumTypes[4]
+}
+
+func (x SessionEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionEventType.Descriptor instead.
+func (SessionEventType) EnumDescriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{4}
+}
+
+type SrtpProfile int32
+
+const (
+	SrtpProfile_SRTP_NONE               SrtpProfile = 0 // plain rtp
+	SrtpProfile_AES_CM_128_HMAC_SHA1_80 SrtpProfile = 1
+	SrtpProfile_AES_CM_128_HMAC_SHA1_32 SrtpProfile = 2
+	SrtpProfile_AEAD_AES_128_GCM        SrtpProfile = 3
+)
+
+// Enum value maps for SrtpProfile.
+var (
+	SrtpProfile_name = map[int32]string{
+		0: "SRTP_NONE",
+		1: "AES_CM_128_HMAC_SHA1_80",
+		2: "AES_CM_128_HMAC_SHA1_32",
+		3: "AEAD_AES_128_GCM",
+	}
+	SrtpProfile_value = map[string]int32{
+		"SRTP_NONE":               0,
+		"AES_CM_128_HMAC_SHA1_80": 1,
+		"AES_CM_128_HMAC_SHA1_32": 2,
+		"AEAD_AES_128_GCM":        3,
+	}
+)
+
+func (x SrtpProfile) Enum() *SrtpProfile {
+	p := new(SrtpProfile)
+	*p = x
+	return p
+}
+
+func (x SrtpProfile) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SrtpProfile) Descriptor() protoreflect.EnumDescriptor {
+	return file_msapi_proto_enumTypes[5].Descriptor()
+}
+
+func (SrtpProfile) Type() protoreflect.EnumType {
+	return &file_msapi_proto_enumTypes[5]
+}
+
+func (x SrtpProfile) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SrtpProfile.Descriptor instead.
+func (SrtpProfile) EnumDescriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{5}
+}
+
+// MediaDirection is from the view of media server, like the sdp attribute of its side
+type MediaDirection int32
+
+const (
+	MediaDirection_DIRECTION_UNCHANGED MediaDirection = 0 // sendrecv when creating, keeps current one when updating
+	MediaDirection_SENDRECV            MediaDirection = 1
+	MediaDirection_SENDONLY            MediaDirection = 2 // received media is discarded, i.e. put peer on hold with music
+	MediaDirection_RECVONLY            MediaDirection = 3 // nothing is sent, i.e. held by peer
+	MediaDirection_INACTIVE            MediaDirection = 4 // neither, rtcp still flows
+)
+
+// Enum value maps for MediaDirection.
+var (
+	MediaDirection_name = map[int32]string{
+		0: "DIRECTION_UNCHANGED",
+		1: "SENDRECV",
+		2: "SENDONLY",
+		3: "RECVONLY",
+		4: "INACTIVE",
+	}
+	MediaDirection_value = map[string]int32{
+		"DIRECTION_UNCHANGED": 0,
+		"SENDRECV":            1,
+		"SENDONLY":            2,
+		"RECVONLY":            3,
+		"INACTIVE":            4,
+	}
+)
+
+func (x MediaDirection) Enum() *MediaDirection {
+	p := new(MediaDirection)
+	*p = x
+	return p
+}
+
+func (x MediaDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MediaDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_msapi_proto_enumTypes[6].Descriptor()
+}
+
+func (MediaDirection) Type() protoreflect.EnumType {
+	return &file_msapi_proto_enumTypes[6]
+}
+
+func (x MediaDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MediaDirection.Descriptor instead.
+func (MediaDirection) EnumDescriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{6}
+}
+
 type SystemCommand int32
 
 const (
-	SystemCommand_USER_EVENT   SystemCommand = 0 // used by other subsystem
-	SystemCommand_REGISTER     SystemCommand = 1
-	SystemCommand_KEEPALIVE    SystemCommand = 2
-	SystemCommand_SESSION_INFO SystemCommand = 3
+	SystemCommand_USER_EVENT         SystemCommand = 0 // used by other subsystem
+	SystemCommand_REGISTER           SystemCommand = 1
+	SystemCommand_KEEPALIVE          SystemCommand = 2
+	SystemCommand_SESSION_INFO       SystemCommand = 3
+	SystemCommand_DTMF               SystemCommand = 4 // dtmf digit received by session, event is like "digit=5;duration=100"
+	SystemCommand_SESSION_TERMINATED SystemCommand = 5 // session is stopped by server or peer instead of StopSession, event is like "reason=RTCP_BYE"
+	SystemCommand_SESSION_RECOVERED  SystemCommand = 6 // session is recovered after server restarts, sent when instance registers, event is like "status=SESSION_STARTED"
 )
 
 // Enum value maps for SystemCommand.
@@ -146,12 +431,18 @@ var (
 		1: "REGISTER",
 		2: "KEEPALIVE",
 		3: "SESSION_INFO",
+		4: "DTMF",
+		5: "SESSION_TERMINATED",
+		6: "SESSION_RECOVERED",
 	}
 	SystemCommand_value = map[string]int32{
-		"USER_EVENT":   0,
-		"REGISTER":     1,
-		"KEEPALIVE":    2,
-		"SESSION_INFO": 3,
+		"USER_EVENT":         0,
+		"REGISTER":           1,
+		"KEEPALIVE":          2,
+		"SESSION_INFO":       3,
+		"DTMF":               4,
+		"SESSION_TERMINATED": 5,
+		"SESSION_RECOVERED":  6,
 	}
 )
 
@@ -166,11 +457,11 @@ func (x SystemCommand) String() string {
 }
 
 func (SystemCommand) Descriptor() protoreflect.EnumDescriptor {
-	return file_msapi_proto_enumTypes[2].Descriptor()
+	return file_msapi_proto_enumTypes[7].Descriptor()
 }
 
 func (SystemCommand) Type() protoreflect.EnumType {
-	return &file_msapi_proto_enumTypes[2]
+	return &file_msapi_proto_enumTypes[7]
 }
 
 func (x SystemCommand) Number() protoreflect.EnumNumber {
@@ -179,7 +470,7 @@ func (x SystemCommand) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SystemCommand.Descriptor instead.
 func (SystemCommand) EnumDescriptor() ([]byte, []int) {
-	return file_msapi_proto_rawDescGZIP(), []int{2}
+	return file_msapi_proto_rawDescGZIP(), []int{7}
 }
 
 type VersionNumber struct {
@@ -187,7 +478,8 @@ type VersionNumber struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Ver Version `protobuf:"varint,1,opt,name=ver,proto3,enum=rpc.Version" json:"ver,omitempty"`
+	Ver   Version     `protobuf:"varint,1,opt,name=ver,proto3,enum=rpc.Version" json:"ver,omitempty"`
+	Drain *DrainState `protobuf:"bytes,2,opt,name=drain,proto3" json:"drain,omitempty"`
 }
 
 func (x *VersionNumber) Reset() {
@@ -229,6 +521,77 @@ func (x *VersionNumber) GetVer() Version {
 	return Version_DUMMY
 }
 
+func (x *VersionNumber) GetDrain() *DrainState {
+	if x != nil {
+		return x.Drain
+	}
+	return nil
+}
+
+// DrainState reports progress of draining before server stops, new sessions are refused while draining
+type DrainState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Draining          bool  `protobuf:"varint,1,opt,name=draining,proto3" json:"draining,omitempty"`
+	RemainingSessions int32 `protobuf:"varint,2,opt,name=remaining_sessions,json=remainingSessions,proto3" json:"remaining_sessions,omitempty"`
+	Deadline          int64 `protobuf:"varint,3,opt,name=deadline,proto3" json:"deadline,omitempty"` // unix milliseconds when remaining sessions are stopped
+}
+
+func (x *DrainState) Reset() {
+	*x = DrainState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DrainState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DrainState) ProtoMessage() {}
+
+func (x *DrainState) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DrainState.ProtoReflect.Descriptor instead.
+func (*DrainState) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DrainState) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+func (x *DrainState) GetRemainingSessions() int32 {
+	if x != nil {
+		return x.RemainingSessions
+	}
+	return 0
+}
+
+func (x *DrainState) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
 type Empty struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -238,7 +601,7 @@ type Empty struct {
 func (x *Empty) Reset() {
 	*x = Empty{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msapi_proto_msgTypes[1]
+		mi := &file_msapi_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -251,7 +614,7 @@ func (x *Empty) String() string {
 func (*Empty) ProtoMessage() {}
 
 func (x *Empty) ProtoReflect() protoreflect.Message {
-	mi := &file_msapi_proto_msgTypes[1]
+	mi := &file_msapi_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -264,7 +627,7 @@ func (x *Empty) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Empty.ProtoReflect.Descriptor instead.
 func (*Empty) Descriptor() ([]byte, []int) {
-	return file_msapi_proto_rawDescGZIP(), []int{1}
+	return file_msapi_proto_rawDescGZIP(), []int{2}
 }
 
 type CodecInfo struct {
@@ -280,7 +643,7 @@ type CodecInfo struct {
 func (x *CodecInfo) Reset() {
 	*x = CodecInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msapi_proto_msgTypes[2]
+		mi := &file_msapi_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -293,7 +656,7 @@ func (x *CodecInfo) String() string {
 func (*CodecInfo) ProtoMessage() {}
 
 func (x *CodecInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_msapi_proto_msgTypes[2]
+	mi := &file_msapi_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -306,7 +669,7 @@ func (x *CodecInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CodecInfo.ProtoReflect.Descriptor instead.
 func (*CodecInfo) Descriptor() ([]byte, []int) {
-	return file_msapi_proto_rawDescGZIP(), []int{2}
+	return file_msapi_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CodecInfo) GetPayloadNumber() uint32 {
@@ -335,17 +698,24 @@ type CreateParam struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PeerIp     string       `protobuf:"bytes,1,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`        // remote rtp ip
-	PeerPort   uint32       `protobuf:"varint,2,opt,name=peer_port,json=peerPort,proto3" json:"peer_port,omitempty"` // remote rtp port
-	Codecs     []*CodecInfo `protobuf:"bytes,3,rep,name=codecs,proto3" json:"codecs,omitempty"`
-	GraphDesc  string       `protobuf:"bytes,4,opt,name=graph_desc,json=graphDesc,proto3" json:"graph_desc,omitempty"`    // used to describe event graph
-	InstanceId string       `protobuf:"bytes,5,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"` // which instance creates this session
+	PeerIp       string             `protobuf:"bytes,1,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`        // remote rtp ip
+	PeerPort     uint32             `protobuf:"varint,2,opt,name=peer_port,json=peerPort,proto3" json:"peer_port,omitempty"` // remote rtp port
+	Codecs       []*CodecInfo       `protobuf:"bytes,3,rep,name=codecs,proto3" json:"codecs,omitempty"`
+	GraphDesc    string             `protobuf:"bytes,4,opt,name=graph_desc,json=graphDesc,proto3" json:"graph_desc,omitempty"`          // used to describe event graph
+	InstanceId   string             `protobuf:"bytes,5,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`       // which instance creates this session
+	JitterBuffer *JitterBufferParam `protobuf:"bytes,6,opt,name=jitter_buffer,json=jitterBuffer,proto3" json:"jitter_buffer,omitempty"` // no jitter buffer if absent
+	NotifyDtmf   bool               `protobuf:"varint,7,opt,name=notify_dtmf,json=notifyDtmf,proto3" json:"notify_dtmf,omitempty"`      // notify instance of received dtmf digits via system channel
+	Srtp         *SrtpParam         `protobuf:"bytes,8,opt,name=srtp,proto3" json:"srtp,omitempty"`                                     // plain rtp if absent
+	Latch        *LatchParam        `protobuf:"bytes,9,opt,name=latch,proto3" json:"latch,omitempty"`                                   // send to peer_ip/peer_port from signalling if absent
+	Streams      []*StreamParam     `protobuf:"bytes,10,rep,name=streams,proto3" json:"streams,omitempty"`                              // media streams of session, peer_port and codecs define the only one if absent
+	Watchdog     *WatchdogParam     `protobuf:"bytes,11,opt,name=watchdog,proto3" json:"watchdog,omitempty"`                            // policies of server config if absent
+	Direction    MediaDirection     `protobuf:"varint,12,opt,name=direction,proto3,enum=rpc.MediaDirection" json:"direction,omitempty"` // direction of the only stream if streams absent
 }
 
 func (x *CreateParam) Reset() {
 	*x = CreateParam{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msapi_proto_msgTypes[3]
+		mi := &file_msapi_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -358,7 +728,7 @@ func (x *CreateParam) String() string {
 func (*CreateParam) ProtoMessage() {}
 
 func (x *CreateParam) ProtoReflect() protoreflect.Message {
-	mi := &file_msapi_proto_msgTypes[3]
+	mi := &file_msapi_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -371,7 +741,7 @@ func (x *CreateParam) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateParam.ProtoReflect.Descriptor instead.
 func (*CreateParam) Descriptor() ([]byte, []int) {
-	return file_msapi_proto_rawDescGZIP(), []int{3}
+	return file_msapi_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateParam) GetPeerIp() string {
@@ -409,34 +779,84 @@ func (x *CreateParam) GetInstanceId() string {
 	return ""
 }
 
-type UpdateParam struct {
+func (x *CreateParam) GetJitterBuffer() *JitterBufferParam {
+	if x != nil {
+		return x.JitterBuffer
+	}
+	return nil
+}
+
+func (x *CreateParam) GetNotifyDtmf() bool {
+	if x != nil {
+		return x.NotifyDtmf
+	}
+	return false
+}
+
+func (x *CreateParam) GetSrtp() *SrtpParam {
+	if x != nil {
+		return x.Srtp
+	}
+	return nil
+}
+
+func (x *CreateParam) GetLatch() *LatchParam {
+	if x != nil {
+		return x.Latch
+	}
+	return nil
+}
+
+func (x *CreateParam) GetStreams() []*StreamParam {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
+func (x *CreateParam) GetWatchdog() *WatchdogParam {
+	if x != nil {
+		return x.Watchdog
+	}
+	return nil
+}
+
+func (x *CreateParam) GetDirection() MediaDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return MediaDirection_DIRECTION_UNCHANGED
+}
+
+// a media stream(m-line) of session, every stream has its own local port pair and rtp session
+type StreamParam struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId     string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	PeerIp        string `protobuf:"bytes,2,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`
-	PeerPort      uint32 `protobuf:"varint,3,opt,name=peer_port,json=peerPort,proto3" json:"peer_port,omitempty"`
-	PayloadNumber int32  `protobuf:"varint,4,opt,name=payload_number,json=payloadNumber,proto3" json:"payload_number,omitempty"` //add by sean. disable when <0
+	Name      string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                          // referred by graph nodes with property stream, "audio"/"video" if empty
+	PeerPort  uint32         `protobuf:"varint,2,opt,name=peer_port,json=peerPort,proto3" json:"peer_port,omitempty"` // remote rtp port
+	Codecs    []*CodecInfo   `protobuf:"bytes,3,rep,name=codecs,proto3" json:"codecs,omitempty"`                      // the first audio/video codec is used for sending until switched
+	Direction MediaDirection `protobuf:"varint,4,opt,name=direction,proto3,enum=rpc.MediaDirection" json:"direction,omitempty"`
 }
 
-func (x *UpdateParam) Reset() {
-	*x = UpdateParam{}
+func (x *StreamParam) Reset() {
+	*x = StreamParam{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msapi_proto_msgTypes[4]
+		mi := &file_msapi_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateParam) String() string {
+func (x *StreamParam) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateParam) ProtoMessage() {}
+func (*StreamParam) ProtoMessage() {}
 
-func (x *UpdateParam) ProtoReflect() protoreflect.Message {
-	mi := &file_msapi_proto_msgTypes[4]
+func (x *StreamParam) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -447,64 +867,65 @@ func (x *UpdateParam) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateParam.ProtoReflect.Descriptor instead.
-func (*UpdateParam) Descriptor() ([]byte, []int) {
-	return file_msapi_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use StreamParam.ProtoReflect.Descriptor instead.
+func (*StreamParam) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *UpdateParam) GetSessionId() string {
+func (x *StreamParam) GetName() string {
 	if x != nil {
-		return x.SessionId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *UpdateParam) GetPeerIp() string {
+func (x *StreamParam) GetPeerPort() uint32 {
 	if x != nil {
-		return x.PeerIp
+		return x.PeerPort
 	}
-	return ""
+	return 0
 }
 
-func (x *UpdateParam) GetPeerPort() uint32 {
+func (x *StreamParam) GetCodecs() []*CodecInfo {
 	if x != nil {
-		return x.PeerPort
+		return x.Codecs
 	}
-	return 0
+	return nil
 }
 
-func (x *UpdateParam) GetPayloadNumber() int32 {
+func (x *StreamParam) GetDirection() MediaDirection {
 	if x != nil {
-		return x.PayloadNumber
+		return x.Direction
 	}
-	return 0
+	return MediaDirection_DIRECTION_UNCHANGED
 }
 
-type StartParam struct {
+// learn actual address of peer from received rtp packets(symmetric rtp), useful when peer is behind NAT
+type LatchParam struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Window uint32 `protobuf:"varint,1,opt,name=window,proto3" json:"window,omitempty"` // milliseconds since session starts during which address can be learned, 0 for 10s
 }
 
-func (x *StartParam) Reset() {
-	*x = StartParam{}
+func (x *LatchParam) Reset() {
+	*x = LatchParam{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msapi_proto_msgTypes[5]
+		mi := &file_msapi_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StartParam) String() string {
+func (x *LatchParam) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartParam) ProtoMessage() {}
+func (*LatchParam) ProtoMessage() {}
 
-func (x *StartParam) ProtoReflect() protoreflect.Message {
-	mi := &file_msapi_proto_msgTypes[5]
+func (x *LatchParam) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,43 +936,47 @@ func (x *StartParam) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartParam.ProtoReflect.Descriptor instead.
-func (*StartParam) Descriptor() ([]byte, []int) {
-	return file_msapi_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use LatchParam.ProtoReflect.Descriptor instead.
+func (*LatchParam) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *StartParam) GetSessionId() string {
+func (x *LatchParam) GetWindow() uint32 {
 	if x != nil {
-		return x.SessionId
+		return x.Window
 	}
-	return ""
+	return 0
 }
 
-type StopParam struct {
+// WatchdogParam stops session when any policy is violated, in milliseconds. 0 takes the server's default policy
+type WatchdogParam struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	InactivityTimeout uint32 `protobuf:"varint,1,opt,name=inactivity_timeout,json=inactivityTimeout,proto3" json:"inactivity_timeout,omitempty"` // since last packet sent or received by any stream, counted from start
+	OneWayTimeout     uint32 `protobuf:"varint,2,opt,name=one_way_timeout,json=oneWayTimeout,proto3" json:"one_way_timeout,omitempty"`           // a stream sends but never receives, or the reverse, counted from start
+	MaxDuration       uint32 `protobuf:"varint,3,opt,name=max_duration,json=maxDuration,proto3" json:"max_duration,omitempty"`                   // since created
+	HeartbeatTimeout  uint32 `protobuf:"varint,4,opt,name=heartbeat_timeout,json=heartbeatTimeout,proto3" json:"heartbeat_timeout,omitempty"`    // since last SESSION_INFO of the session from instance, counted from creation
 }
 
-func (x *StopParam) Reset() {
-	*x = StopParam{}
+func (x *WatchdogParam) Reset() {
+	*x = WatchdogParam{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msapi_proto_msgTypes[6]
+		mi := &file_msapi_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StopParam) String() string {
+func (x *WatchdogParam) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopParam) ProtoMessage() {}
+func (*WatchdogParam) ProtoMessage() {}
 
-func (x *StopParam) ProtoReflect() protoreflect.Message {
-	mi := &file_msapi_proto_msgTypes[6]
+func (x *WatchdogParam) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -562,79 +987,53 @@ func (x *StopParam) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopParam.ProtoReflect.Descriptor instead.
-func (*StopParam) Descriptor() ([]byte, []int) {
-	return file_msapi_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use WatchdogParam.ProtoReflect.Descriptor instead.
+func (*WatchdogParam) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *StopParam) GetSessionId() string {
+func (x *WatchdogParam) GetInactivityTimeout() uint32 {
 	if x != nil {
-		return x.SessionId
+		return x.InactivityTimeout
 	}
-	return ""
-}
-
-type Status struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	return 0
 }
 
-func (x *Status) Reset() {
-	*x = Status{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_msapi_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *WatchdogParam) GetOneWayTimeout() uint32 {
+	if x != nil {
+		return x.OneWayTimeout
 	}
+	return 0
 }
 
-func (x *Status) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*Status) ProtoMessage() {}
-
-func (x *Status) ProtoReflect() protoreflect.Message {
-	mi := &file_msapi_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *WatchdogParam) GetMaxDuration() uint32 {
+	if x != nil {
+		return x.MaxDuration
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use Status.ProtoReflect.Descriptor instead.
-func (*Status) Descriptor() ([]byte, []int) {
-	return file_msapi_proto_rawDescGZIP(), []int{7}
+	return 0
 }
 
-func (x *Status) GetStatus() string {
+func (x *WatchdogParam) GetHeartbeatTimeout() uint32 {
 	if x != nil {
-		return x.Status
+		return x.HeartbeatTimeout
 	}
-	return ""
+	return 0
 }
 
-type Session struct {
+// keys are base64 of master key and master salt, i.e. key-params of sdes crypto attribute(RFC 4568) like
+// "inline:WVNfX19zZW1jdGwgKCkgewkyMjA7fQp9CnVubGVz|2^20|1:4", lifetime and mki are ignored
+type SrtpParam struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId    string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	LocalIp      string `protobuf:"bytes,2,opt,name=local_ip,json=localIp,proto3" json:"local_ip,omitempty"`
-	LocalRtpPort uint32 `protobuf:"varint,3,opt,name=local_rtp_port,json=localRtpPort,proto3" json:"local_rtp_port,omitempty"`
-	PeerIp       string `protobuf:"bytes,4,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`
-	PeerRtpPort  uint32 `protobuf:"varint,5,opt,name=peer_rtp_port,json=peerRtpPort,proto3" json:"peer_rtp_port,omitempty"`
+	Profile   SrtpProfile `protobuf:"varint,1,opt,name=profile,proto3,enum=rpc.SrtpProfile" json:"profile,omitempty"`
+	LocalKey  string      `protobuf:"bytes,2,opt,name=local_key,json=localKey,proto3" json:"local_key,omitempty"`    // key of outgoing packets, generated by media server if empty
+	RemoteKey string      `protobuf:"bytes,3,opt,name=remote_key,json=remoteKey,proto3" json:"remote_key,omitempty"` // key of incoming packets, can be set later by UpdateParam
 }
 
-func (x *Session) Reset() {
-	*x = Session{}
+func (x *SrtpParam) Reset() {
+	*x = SrtpParam{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_msapi_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -642,13 +1041,13 @@ func (x *Session) Reset() {
 	}
 }
 
-func (x *Session) String() string {
+func (x *SrtpParam) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Session) ProtoMessage() {}
+func (*SrtpParam) ProtoMessage() {}
 
-func (x *Session) ProtoReflect() protoreflect.Message {
+func (x *SrtpParam) ProtoReflect() protoreflect.Message {
 	mi := &file_msapi_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -660,58 +1059,44 @@ func (x *Session) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Session.ProtoReflect.Descriptor instead.
-func (*Session) Descriptor() ([]byte, []int) {
+// Deprecated: Use SrtpParam.ProtoReflect.Descriptor instead.
+func (*SrtpParam) Descriptor() ([]byte, []int) {
 	return file_msapi_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Session) GetSessionId() string {
+func (x *SrtpParam) GetProfile() SrtpProfile {
 	if x != nil {
-		return x.SessionId
+		return x.Profile
 	}
-	return ""
+	return SrtpProfile_SRTP_NONE
 }
 
-func (x *Session) GetLocalIp() string {
+func (x *SrtpParam) GetLocalKey() string {
 	if x != nil {
-		return x.LocalIp
+		return x.LocalKey
 	}
 	return ""
 }
 
-func (x *Session) GetLocalRtpPort() uint32 {
-	if x != nil {
-		return x.LocalRtpPort
-	}
-	return 0
-}
-
-func (x *Session) GetPeerIp() string {
+func (x *SrtpParam) GetRemoteKey() string {
 	if x != nil {
-		return x.PeerIp
+		return x.RemoteKey
 	}
 	return ""
 }
 
-func (x *Session) GetPeerRtpPort() uint32 {
-	if x != nil {
-		return x.PeerRtpPort
-	}
-	return 0
-}
-
-type Action struct {
+type JitterBufferParam struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Cmd       string `protobuf:"bytes,2,opt,name=cmd,proto3" json:"cmd,omitempty"`
-	CmdArg    string `protobuf:"bytes,3,opt,name=cmd_arg,json=cmdArg,proto3" json:"cmd_arg,omitempty"`
+	MinDelay uint32 `protobuf:"varint,1,opt,name=min_delay,json=minDelay,proto3" json:"min_delay,omitempty"` // play-out delay in milliseconds, lower bound if adaptive
+	MaxDelay uint32 `protobuf:"varint,2,opt,name=max_delay,json=maxDelay,proto3" json:"max_delay,omitempty"` // upper bound of play-out delay in milliseconds
+	Adaptive bool   `protobuf:"varint,3,opt,name=adaptive,proto3" json:"adaptive,omitempty"`                 // adapt play-out delay to network jitter
 }
 
-func (x *Action) Reset() {
-	*x = Action{}
+func (x *JitterBufferParam) Reset() {
+	*x = JitterBufferParam{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_msapi_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -719,13 +1104,13 @@ func (x *Action) Reset() {
 	}
 }
 
-func (x *Action) String() string {
+func (x *JitterBufferParam) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Action) ProtoMessage() {}
+func (*JitterBufferParam) ProtoMessage() {}
 
-func (x *Action) ProtoReflect() protoreflect.Message {
+func (x *JitterBufferParam) ProtoReflect() protoreflect.Message {
 	mi := &file_msapi_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -737,43 +1122,48 @@ func (x *Action) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Action.ProtoReflect.Descriptor instead.
-func (*Action) Descriptor() ([]byte, []int) {
+// Deprecated: Use JitterBufferParam.ProtoReflect.Descriptor instead.
+func (*JitterBufferParam) Descriptor() ([]byte, []int) {
 	return file_msapi_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *Action) GetSessionId() string {
+func (x *JitterBufferParam) GetMinDelay() uint32 {
 	if x != nil {
-		return x.SessionId
+		return x.MinDelay
 	}
-	return ""
+	return 0
 }
 
-func (x *Action) GetCmd() string {
+func (x *JitterBufferParam) GetMaxDelay() uint32 {
 	if x != nil {
-		return x.Cmd
+		return x.MaxDelay
 	}
-	return ""
+	return 0
 }
 
-func (x *Action) GetCmdArg() string {
+func (x *JitterBufferParam) GetAdaptive() bool {
 	if x != nil {
-		return x.CmdArg
+		return x.Adaptive
 	}
-	return ""
+	return false
 }
 
-type ActionResult struct {
+type UpdateParam struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	State     string `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	SessionId     string          `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	PeerIp        string          `protobuf:"bytes,2,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`                       // kept if empty, peer address and srtp keys are rejected once started
+	PeerPort      uint32          `protobuf:"varint,3,opt,name=peer_port,json=peerPort,proto3" json:"peer_port,omitempty"`                // kept if 0
+	PayloadNumber int32           `protobuf:"varint,4,opt,name=payload_number,json=payloadNumber,proto3" json:"payload_number,omitempty"` //add by sean. disable when <0. switch sending codec of the first stream, even started
+	Srtp          *SrtpParam      `protobuf:"bytes,5,opt,name=srtp,proto3" json:"srtp,omitempty"`                                         // update srtp keys, profile must be the same as created
+	Streams       []*StreamUpdate `protobuf:"bytes,6,rep,name=streams,proto3" json:"streams,omitempty"`                                   // update peer port of streams by name
+	Direction     MediaDirection  `protobuf:"varint,7,opt,name=direction,proto3,enum=rpc.MediaDirection" json:"direction,omitempty"`      // change direction of the first stream, even started
 }
 
-func (x *ActionResult) Reset() {
-	*x = ActionResult{}
+func (x *UpdateParam) Reset() {
+	*x = UpdateParam{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_msapi_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -781,13 +1171,13 @@ func (x *ActionResult) Reset() {
 	}
 }
 
-func (x *ActionResult) String() string {
+func (x *UpdateParam) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionResult) ProtoMessage() {}
+func (*UpdateParam) ProtoMessage() {}
 
-func (x *ActionResult) ProtoReflect() protoreflect.Message {
+func (x *UpdateParam) ProtoReflect() protoreflect.Message {
 	mi := &file_msapi_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -799,36 +1189,73 @@ func (x *ActionResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionResult.ProtoReflect.Descriptor instead.
-func (*ActionResult) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateParam.ProtoReflect.Descriptor instead.
+func (*UpdateParam) Descriptor() ([]byte, []int) {
 	return file_msapi_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ActionResult) GetSessionId() string {
+func (x *UpdateParam) GetSessionId() string {
 	if x != nil {
 		return x.SessionId
 	}
 	return ""
 }
 
-func (x *ActionResult) GetState() string {
+func (x *UpdateParam) GetPeerIp() string {
 	if x != nil {
-		return x.State
+		return x.PeerIp
 	}
 	return ""
 }
 
-type ActionEvent struct {
+func (x *UpdateParam) GetPeerPort() uint32 {
+	if x != nil {
+		return x.PeerPort
+	}
+	return 0
+}
+
+func (x *UpdateParam) GetPayloadNumber() int32 {
+	if x != nil {
+		return x.PayloadNumber
+	}
+	return 0
+}
+
+func (x *UpdateParam) GetSrtp() *SrtpParam {
+	if x != nil {
+		return x.Srtp
+	}
+	return nil
+}
+
+func (x *UpdateParam) GetStreams() []*StreamUpdate {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
+func (x *UpdateParam) GetDirection() MediaDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return MediaDirection_DIRECTION_UNCHANGED
+}
+
+type StreamUpdate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Event     string `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+	Name          string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	PeerPort      uint32         `protobuf:"varint,2,opt,name=peer_port,json=peerPort,proto3" json:"peer_port,omitempty"`                // kept if 0, rejected once started
+	PayloadNumber int32          `protobuf:"varint,3,opt,name=payload_number,json=payloadNumber,proto3" json:"payload_number,omitempty"` // switch sending codec to the negotiated one, ignored if <= 0
+	Direction     MediaDirection `protobuf:"varint,4,opt,name=direction,proto3,enum=rpc.MediaDirection" json:"direction,omitempty"`      // hold or resume the stream, even started
 }
 
-func (x *ActionEvent) Reset() {
-	*x = ActionEvent{}
+func (x *StreamUpdate) Reset() {
+	*x = StreamUpdate{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_msapi_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -836,13 +1263,13 @@ func (x *ActionEvent) Reset() {
 	}
 }
 
-func (x *ActionEvent) String() string {
+func (x *StreamUpdate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActionEvent) ProtoMessage() {}
+func (*StreamUpdate) ProtoMessage() {}
 
-func (x *ActionEvent) ProtoReflect() protoreflect.Message {
+func (x *StreamUpdate) ProtoReflect() protoreflect.Message {
 	mi := &file_msapi_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -854,39 +1281,49 @@ func (x *ActionEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActionEvent.ProtoReflect.Descriptor instead.
-func (*ActionEvent) Descriptor() ([]byte, []int) {
+// Deprecated: Use StreamUpdate.ProtoReflect.Descriptor instead.
+func (*StreamUpdate) Descriptor() ([]byte, []int) {
 	return file_msapi_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *ActionEvent) GetSessionId() string {
+func (x *StreamUpdate) GetName() string {
 	if x != nil {
-		return x.SessionId
+		return x.Name
 	}
 	return ""
 }
 
-func (x *ActionEvent) GetEvent() string {
+func (x *StreamUpdate) GetPeerPort() uint32 {
 	if x != nil {
-		return x.Event
+		return x.PeerPort
 	}
-	return ""
+	return 0
 }
 
-type PushData struct {
+func (x *StreamUpdate) GetPayloadNumber() int32 {
+	if x != nil {
+		return x.PayloadNumber
+	}
+	return 0
+}
+
+func (x *StreamUpdate) GetDirection() MediaDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return MediaDirection_DIRECTION_UNCHANGED
+}
+
+type StartParam struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// only used in first packet, following packets use the same session id,cmd and node_name
 	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Cmd       string `protobuf:"bytes,2,opt,name=cmd,proto3" json:"cmd,omitempty"`
-	NodeName  string `protobuf:"bytes,3,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
-	Data      []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (x *PushData) Reset() {
-	*x = PushData{}
+func (x *StartParam) Reset() {
+	*x = StartParam{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_msapi_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -894,13 +1331,13 @@ func (x *PushData) Reset() {
 	}
 }
 
-func (x *PushData) String() string {
+func (x *StartParam) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PushData) ProtoMessage() {}
+func (*StartParam) ProtoMessage() {}
 
-func (x *PushData) ProtoReflect() protoreflect.Message {
+func (x *StartParam) ProtoReflect() protoreflect.Message {
 	mi := &file_msapi_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -912,316 +1349,3101 @@ func (x *PushData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PushData.ProtoReflect.Descriptor instead.
-func (*PushData) Descriptor() ([]byte, []int) {
+// Deprecated: Use StartParam.ProtoReflect.Descriptor instead.
+func (*StartParam) Descriptor() ([]byte, []int) {
 	return file_msapi_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *PushData) GetSessionId() string {
+func (x *StartParam) GetSessionId() string {
 	if x != nil {
 		return x.SessionId
 	}
 	return ""
 }
 
-func (x *PushData) GetCmd() string {
-	if x != nil {
-		return x.Cmd
-	}
-	return ""
+type StopParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
 }
 
-func (x *PushData) GetNodeName() string {
-	if x != nil {
-		return x.NodeName
-	}
+func (x *StopParam) Reset() {
+	*x = StopParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopParam) ProtoMessage() {}
+
+func (x *StopParam) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopParam.ProtoReflect.Descriptor instead.
+func (*StopParam) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StopParam) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
 	return ""
 }
 
-func (x *PushData) GetData() []byte {
+type Status struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *Status) Reset() {
+	*x = Status{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Status) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Status) ProtoMessage() {}
+
+func (x *Status) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Status.ProtoReflect.Descriptor instead.
+func (*Status) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Status) GetStatus() string {
 	if x != nil {
-		return x.Data
+		return x.Status
+	}
+	return ""
+}
+
+type Session struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId    string        `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	LocalIp      string        `protobuf:"bytes,2,opt,name=local_ip,json=localIp,proto3" json:"local_ip,omitempty"`
+	LocalRtpPort uint32        `protobuf:"varint,3,opt,name=local_rtp_port,json=localRtpPort,proto3" json:"local_rtp_port,omitempty"`
+	PeerIp       string        `protobuf:"bytes,4,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`
+	PeerRtpPort  uint32        `protobuf:"varint,5,opt,name=peer_rtp_port,json=peerRtpPort,proto3" json:"peer_rtp_port,omitempty"`
+	SrtpLocalKey string        `protobuf:"bytes,6,opt,name=srtp_local_key,json=srtpLocalKey,proto3" json:"srtp_local_key,omitempty"` // base64 key of outgoing packets if srtp enabled
+	Streams      []*StreamInfo `protobuf:"bytes,7,rep,name=streams,proto3" json:"streams,omitempty"`                                 // local_rtp_port and peer_rtp_port are the ones of the first stream
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Session) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Session) GetLocalIp() string {
+	if x != nil {
+		return x.LocalIp
+	}
+	return ""
+}
+
+func (x *Session) GetLocalRtpPort() uint32 {
+	if x != nil {
+		return x.LocalRtpPort
+	}
+	return 0
+}
+
+func (x *Session) GetPeerIp() string {
+	if x != nil {
+		return x.PeerIp
+	}
+	return ""
+}
+
+func (x *Session) GetPeerRtpPort() uint32 {
+	if x != nil {
+		return x.PeerRtpPort
+	}
+	return 0
+}
+
+func (x *Session) GetSrtpLocalKey() string {
+	if x != nil {
+		return x.SrtpLocalKey
+	}
+	return ""
+}
+
+func (x *Session) GetStreams() []*StreamInfo {
+	if x != nil {
+		return x.Streams
 	}
 	return nil
 }
 
-type SystemEvent struct {
+type StreamInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Cmd        SystemCommand `protobuf:"varint,1,opt,name=cmd,proto3,enum=rpc.SystemCommand" json:"cmd,omitempty"`
-	InstanceId string        `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
-	SessionId  string        `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Event      string        `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+	Name          string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	LocalRtpPort  uint32         `protobuf:"varint,2,opt,name=local_rtp_port,json=localRtpPort,proto3" json:"local_rtp_port,omitempty"`
+	PeerRtpPort   uint32         `protobuf:"varint,3,opt,name=peer_rtp_port,json=peerRtpPort,proto3" json:"peer_rtp_port,omitempty"`
+	PayloadNumber uint32         `protobuf:"varint,4,opt,name=payload_number,json=payloadNumber,proto3" json:"payload_number,omitempty"` // payload type of sending codec
+	PeerIp        string         `protobuf:"bytes,5,opt,name=peer_ip,json=peerIp,proto3" json:"peer_ip,omitempty"`
+	Codecs        []*CodecInfo   `protobuf:"bytes,6,rep,name=codecs,proto3" json:"codecs,omitempty"` // negotiated audio/video codecs, only filled by GetSession
+	Direction     MediaDirection `protobuf:"varint,7,opt,name=direction,proto3,enum=rpc.MediaDirection" json:"direction,omitempty"`
 }
 
-func (x *SystemEvent) Reset() {
-	*x = SystemEvent{}
+func (x *StreamInfo) Reset() {
+	*x = StreamInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_msapi_proto_msgTypes[13]
+		mi := &file_msapi_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SystemEvent) String() string {
+func (x *StreamInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SystemEvent) ProtoMessage() {}
+func (*StreamInfo) ProtoMessage() {}
 
-func (x *SystemEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_msapi_proto_msgTypes[13]
+func (x *StreamInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamInfo.ProtoReflect.Descriptor instead.
+func (*StreamInfo) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StreamInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StreamInfo) GetLocalRtpPort() uint32 {
+	if x != nil {
+		return x.LocalRtpPort
+	}
+	return 0
+}
+
+func (x *StreamInfo) GetPeerRtpPort() uint32 {
+	if x != nil {
+		return x.PeerRtpPort
+	}
+	return 0
+}
+
+func (x *StreamInfo) GetPayloadNumber() uint32 {
+	if x != nil {
+		return x.PayloadNumber
+	}
+	return 0
+}
+
+func (x *StreamInfo) GetPeerIp() string {
+	if x != nil {
+		return x.PeerIp
+	}
+	return ""
+}
+
+func (x *StreamInfo) GetCodecs() []*CodecInfo {
+	if x != nil {
+		return x.Codecs
+	}
+	return nil
+}
+
+func (x *StreamInfo) GetDirection() MediaDirection {
+	if x != nil {
+		return x.Direction
+	}
+	return MediaDirection_DIRECTION_UNCHANGED
+}
+
+type ListSessionsParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InstanceId string          `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`      // sessions of all instances if empty
+	Status     []SessionStatus `protobuf:"varint,2,rep,packed,name=status,proto3,enum=rpc.SessionStatus" json:"status,omitempty"` // sessions of any status if empty
+}
+
+func (x *ListSessionsParam) Reset() {
+	*x = ListSessionsParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionsParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsParam) ProtoMessage() {}
+
+func (x *ListSessionsParam) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
 			ms.StoreMessageInfo(mi)
 		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use SystemEvent.ProtoReflect.Descriptor instead.
-func (*SystemEvent) Descriptor() ([]byte, []int) {
-	return file_msapi_proto_rawDescGZIP(), []int{13}
-}
-
-func (x *SystemEvent) GetCmd() SystemCommand {
-	if x != nil {
-		return x.Cmd
-	}
-	return SystemCommand_USER_EVENT
-}
-
-func (x *SystemEvent) GetInstanceId() string {
-	if x != nil {
-		return x.InstanceId
-	}
-	return ""
-}
-
-func (x *SystemEvent) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
-}
-
-func (x *SystemEvent) GetEvent() string {
-	if x != nil {
-		return x.Event
-	}
-	return ""
-}
-
-var File_msapi_proto protoreflect.FileDescriptor
-
-var file_msapi_proto_rawDesc = []byte{
-	0x0a, 0x0b, 0x6d, 0x73, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x72,
-	0x70, 0x63, 0x22, 0x2f, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x03, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
-	0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x03,
-	0x76, 0x65, 0x72, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x86, 0x01, 0x0a,
-	0x09, 0x43, 0x6f, 0x64, 0x65, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61,
-	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0d, 0x52, 0x0d, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x12, 0x31, 0x0a, 0x0c, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f,
-	0x64, 0x65, 0x63, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
-	0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x5f, 0x70, 0x61,
-	0x72, 0x61, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x64, 0x65, 0x63,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x22, 0xab, 0x01, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x70,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x70, 0x12, 0x1b,
-	0x0a, 0x09, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x08, 0x70, 0x65, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x63,
-	0x6f, 0x64, 0x65, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x72, 0x70,
-	0x63, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63, 0x6f, 0x64,
-	0x65, 0x63, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x61, 0x70, 0x68, 0x5f, 0x64, 0x65, 0x73,
-	0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x61, 0x70, 0x68, 0x44, 0x65,
-	0x73, 0x63, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69,
-	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63,
-	0x65, 0x49, 0x64, 0x22, 0x89, 0x01, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61,
-	0x72, 0x61, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x70, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x70,
-	0x65, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
-	0x70, 0x65, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61, 0x79, 0x6c,
-	0x6f, 0x61, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x0d, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22,
-	0x2b, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x1d, 0x0a,
-	0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x2a, 0x0a, 0x09,
-	0x53, 0x74, 0x6f, 0x70, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x20, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xa6, 0x01, 0x0a, 0x07, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x69,
-	0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x49, 0x70,
-	0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x72, 0x74, 0x70, 0x5f, 0x70, 0x6f,
-	0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x52,
-	0x74, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69,
-	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x70, 0x12,
-	0x22, 0x0a, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x72, 0x74, 0x70, 0x5f, 0x70, 0x6f, 0x72, 0x74,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x52, 0x74, 0x70, 0x50,
-	0x6f, 0x72, 0x74, 0x22, 0x52, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
-	0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03,
-	0x63, 0x6d, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x17,
-	0x0a, 0x07, 0x63, 0x6d, 0x64, 0x5f, 0x61, 0x72, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x63, 0x6d, 0x64, 0x41, 0x72, 0x67, 0x22, 0x43, 0x0a, 0x0c, 0x41, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0x42, 0x0a, 0x0b,
-	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76,
-	0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74,
-	0x22, 0x6c, 0x0a, 0x08, 0x50, 0x75, 0x73, 0x68, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x0a, 0x0a,
-	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63,
-	0x6d, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x1b, 0x0a,
-	0x09, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61,
-	0x74, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x89,
-	0x01, 0x0a, 0x0b, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x24,
-	0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x70,
-	0x63, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52,
-	0x03, 0x63, 0x6d, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
-	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61,
-	0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2a, 0x21, 0x0a, 0x07, 0x56, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x55, 0x4d, 0x4d, 0x59, 0x10, 0x00,
-	0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x02, 0x2a, 0x7c, 0x0a,
-	0x09, 0x43, 0x6f, 0x64, 0x65, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x41,
-	0x57, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x45, 0x4c, 0x45, 0x50, 0x48, 0x4f, 0x4e, 0x45,
-	0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x38, 0x4b, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x54,
-	0x45, 0x4c, 0x45, 0x50, 0x48, 0x4f, 0x4e, 0x45, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x31,
-	0x36, 0x4b, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x43, 0x4d, 0x5f, 0x41, 0x4c, 0x41, 0x57,
-	0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x4d, 0x52, 0x4e, 0x42, 0x10, 0x04, 0x12, 0x09, 0x0a,
-	0x05, 0x41, 0x4d, 0x52, 0x57, 0x42, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x32, 0x36, 0x34,
-	0x10, 0x06, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x56, 0x53, 0x10, 0x07, 0x2a, 0x4e, 0x0a, 0x0d, 0x53,
-	0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x0a,
-	0x55, 0x53, 0x45, 0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08,
-	0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45,
-	0x45, 0x50, 0x41, 0x4c, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x45, 0x53,
-	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x03, 0x32, 0xe9, 0x03, 0x0a, 0x08,
-	0x4d, 0x65, 0x64, 0x69, 0x61, 0x41, 0x70, 0x69, 0x12, 0x2e, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x56,
-	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x00, 0x12, 0x32, 0x0a, 0x0e, 0x50, 0x72, 0x65, 0x70,
-	0x61, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x2e, 0x72, 0x70, 0x63,
-	0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x0c, 0x2e, 0x72,
-	0x70, 0x63, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0d,
-	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a,
-	0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x2e,
-	0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0f,
-	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a,
-	0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x2c,
-	0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x70, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x0b, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x0d,
-	0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x11, 0x2e, 0x72, 0x70, 0x63,
-	0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x12,
-	0x3c, 0x0a, 0x17, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x57, 0x69, 0x74, 0x68, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63,
-	0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x63,
-	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3d, 0x0a,
-	0x15, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x69,
-	0x74, 0x68, 0x50, 0x75, 0x73, 0x68, 0x12, 0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x75, 0x73,
-	0x68, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x28, 0x01, 0x12, 0x39, 0x0a, 0x0d,
-	0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x10, 0x2e,
-	0x72, 0x70, 0x63, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x1a,
-	0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69, 0x74, 0x68, 0x75,
-	0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x70, 0x63, 0x72, 0x61, 0x73, 0x68, 0x2f, 0x6d,
-	0x65, 0x64, 0x69, 0x61, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x72, 0x70, 0x63, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
-}
-
-var (
-	file_msapi_proto_rawDescOnce sync.Once
-	file_msapi_proto_rawDescData = file_msapi_proto_rawDesc
-)
-
-func file_msapi_proto_rawDescGZIP() []byte {
-	file_msapi_proto_rawDescOnce.Do(func() {
-		file_msapi_proto_rawDescData = protoimpl.X.CompressGZIP(file_msapi_proto_rawDescData)
-	})
-	return file_msapi_proto_rawDescData
-}
-
-var file_msapi_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_msapi_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
-var file_msapi_proto_goTypes = []interface{}{
-	(Version)(0),          // 0: rpc.Version
-	(CodecType)(0),        // 1: rpc.CodecType
-	(SystemCommand)(0),    // 2: rpc.SystemCommand
-	(*VersionNumber)(nil), // 3: rpc.VersionNumber
-	(*Empty)(nil),         // 4: rpc.Empty
-	(*CodecInfo)(nil),     // 5: rpc.CodecInfo
-	(*CreateParam)(nil),   // 6: rpc.CreateParam
-	(*UpdateParam)(nil),   // 7: rpc.UpdateParam
-	(*StartParam)(nil),    // 8: rpc.StartParam
-	(*StopParam)(nil),     // 9: rpc.StopParam
-	(*Status)(nil),        // 10: rpc.Status
-	(*Session)(nil),       // 11: rpc.Session
-	(*Action)(nil),        // 12: rpc.Action
-	(*ActionResult)(nil),  // 13: rpc.ActionResult
-	(*ActionEvent)(nil),   // 14: rpc.ActionEvent
-	(*PushData)(nil),      // 15: rpc.PushData
-	(*SystemEvent)(nil),   // 16: rpc.SystemEvent
-}
-var file_msapi_proto_depIdxs = []int32{
-	0,  // 0: rpc.VersionNumber.ver:type_name -> rpc.Version
-	1,  // 1: rpc.CodecInfo.payload_type:type_name -> rpc.CodecType
-	5,  // 2: rpc.CreateParam.codecs:type_name -> rpc.CodecInfo
-	2,  // 3: rpc.SystemEvent.cmd:type_name -> rpc.SystemCommand
-	4,  // 4: rpc.MediaApi.GetVersion:input_type -> rpc.Empty
-	6,  // 5: rpc.MediaApi.PrepareSession:input_type -> rpc.CreateParam
-	7,  // 6: rpc.MediaApi.UpdateSession:input_type -> rpc.UpdateParam
-	8,  // 7: rpc.MediaApi.StartSession:input_type -> rpc.StartParam
-	9,  // 8: rpc.MediaApi.StopSession:input_type -> rpc.StopParam
-	12, // 9: rpc.MediaApi.ExecuteAction:input_type -> rpc.Action
-	12, // 10: rpc.MediaApi.ExecuteActionWithNotify:input_type -> rpc.Action
-	15, // 11: rpc.MediaApi.ExecuteActionWithPush:input_type -> rpc.PushData
-	16, // 12: rpc.MediaApi.SystemChannel:input_type -> rpc.SystemEvent
-	3,  // 13: rpc.MediaApi.GetVersion:output_type -> rpc.VersionNumber
-	11, // 14: rpc.MediaApi.PrepareSession:output_type -> rpc.Session
-	10, // 15: rpc.MediaApi.UpdateSession:output_type -> rpc.Status
-	10, // 16: rpc.MediaApi.StartSession:output_type -> rpc.Status
-	10, // 17: rpc.MediaApi.StopSession:output_type -> rpc.Status
-	13, // 18: rpc.MediaApi.ExecuteAction:output_type -> rpc.ActionResult
-	14, // 19: rpc.MediaApi.ExecuteActionWithNotify:output_type -> rpc.ActionEvent
-	13, // 20: rpc.MediaApi.ExecuteActionWithPush:output_type -> rpc.ActionResult
-	16, // 21: rpc.MediaApi.SystemChannel:output_type -> rpc.SystemEvent
-	13, // [13:22] is the sub-list for method output_type
-	4,  // [4:13] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
-}
-
-func init() { file_msapi_proto_init() }
-func file_msapi_proto_init() {
-	if File_msapi_proto != nil {
-		return
-	}
-	if !protoimpl.UnsafeEnabled {
-		file_msapi_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VersionNumber); i {
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsParam.ProtoReflect.Descriptor instead.
+func (*ListSessionsParam) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListSessionsParam) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *ListSessionsParam) GetStatus() []SessionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type SessionList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions []*SessionInfo `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *SessionList) Reset() {
+	*x = SessionList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionList) ProtoMessage() {}
+
+func (x *SessionList) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionList.ProtoReflect.Descriptor instead.
+func (*SessionList) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SessionList) GetSessions() []*SessionInfo {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type GetSessionParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *GetSessionParam) Reset() {
+	*x = GetSessionParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSessionParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionParam) ProtoMessage() {}
+
+func (x *GetSessionParam) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionParam.ProtoReflect.Descriptor instead.
+func (*GetSessionParam) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetSessionParam) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+type SessionInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId  string         `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	InstanceId string         `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	Status     SessionStatus  `protobuf:"varint,3,opt,name=status,proto3,enum=rpc.SessionStatus" json:"status,omitempty"`
+	CreateTime int64          `protobuf:"varint,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"` // unix milliseconds
+	LocalIp    string         `protobuf:"bytes,5,opt,name=local_ip,json=localIp,proto3" json:"local_ip,omitempty"`
+	Streams    []*StreamInfo  `protobuf:"bytes,6,rep,name=streams,proto3" json:"streams,omitempty"`
+	Detail     *SessionDetail `protobuf:"bytes,7,opt,name=detail,proto3" json:"detail,omitempty"` // only filled by GetSession
+	StopReason StopReason     `protobuf:"varint,8,opt,name=stop_reason,json=stopReason,proto3,enum=rpc.StopReason" json:"stop_reason,omitempty"`
+}
+
+func (x *SessionInfo) Reset() {
+	*x = SessionInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInfo) ProtoMessage() {}
+
+func (x *SessionInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionInfo.ProtoReflect.Descriptor instead.
+func (*SessionInfo) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SessionInfo) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetStatus() SessionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SessionStatus_SESSION_CREATED
+}
+
+func (x *SessionInfo) GetCreateTime() int64 {
+	if x != nil {
+		return x.CreateTime
+	}
+	return 0
+}
+
+func (x *SessionInfo) GetLocalIp() string {
+	if x != nil {
+		return x.LocalIp
+	}
+	return ""
+}
+
+func (x *SessionInfo) GetStreams() []*StreamInfo {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetDetail() *SessionDetail {
+	if x != nil {
+		return x.Detail
+	}
+	return nil
+}
+
+func (x *SessionInfo) GetStopReason() StopReason {
+	if x != nil {
+		return x.StopReason
+	}
+	return StopReason_STOP_REASON_NONE
+}
+
+type WatchSessionsParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InstanceId string `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"` // events of all instances if empty
+	// resume after this sequence number, buffered events are replayed before new ones. OUT_OF_RANGE with the
+	// oldest buffered sequence number if some events after it are no longer buffered, then sessions should be
+	// reconciled by ListSessions. server is restarted if it is greater than the latest one, then all buffered events
+	// are replayed. 0 for new events only
+	AfterSeq uint64 `protobuf:"varint,2,opt,name=after_seq,json=afterSeq,proto3" json:"after_seq,omitempty"`
+}
+
+func (x *WatchSessionsParam) Reset() {
+	*x = WatchSessionsParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchSessionsParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSessionsParam) ProtoMessage() {}
+
+func (x *WatchSessionsParam) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSessionsParam.ProtoReflect.Descriptor instead.
+func (*WatchSessionsParam) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *WatchSessionsParam) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *WatchSessionsParam) GetAfterSeq() uint64 {
+	if x != nil {
+		return x.AfterSeq
+	}
+	return 0
+}
+
+type SessionEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seq        uint64           `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"` // increases by one for every event of any instance
+	Type       SessionEventType `protobuf:"varint,2,opt,name=type,proto3,enum=rpc.SessionEventType" json:"type,omitempty"`
+	SessionId  string           `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	InstanceId string           `protobuf:"bytes,4,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	Reason     StopReason       `protobuf:"varint,5,opt,name=reason,proto3,enum=rpc.StopReason" json:"reason,omitempty"` // stopped event only
+	Time       int64            `protobuf:"varint,6,opt,name=time,proto3" json:"time,omitempty"`                         // unix milliseconds
+	Streams    []*StreamInfo    `protobuf:"bytes,7,rep,name=streams,proto3" json:"streams,omitempty"`                    // endpoints when event happens
+}
+
+func (x *SessionEvent) Reset() {
+	*x = SessionEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionEvent) ProtoMessage() {}
+
+func (x *SessionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionEvent.ProtoReflect.Descriptor instead.
+func (*SessionEvent) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SessionEvent) GetSeq() uint64 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *SessionEvent) GetType() SessionEventType {
+	if x != nil {
+		return x.Type
+	}
+	return SessionEventType_SESSION_EVENT_CREATED
+}
+
+func (x *SessionEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *SessionEvent) GetReason() StopReason {
+	if x != nil {
+		return x.Reason
+	}
+	return StopReason_STOP_REASON_NONE
+}
+
+func (x *SessionEvent) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *SessionEvent) GetStreams() []*StreamInfo {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
+type SessionDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GraphDesc string        `protobuf:"bytes,1,opt,name=graph_desc,json=graphDesc,proto3" json:"graph_desc,omitempty"`
+	Graph     []*GraphNode  `protobuf:"bytes,2,rep,name=graph,proto3" json:"graph,omitempty"` // parsed graph in topological order
+	Nodes     []*LiveNode   `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"` // nodes running in event graph
+	Watchdog  *WatchdogInfo `protobuf:"bytes,4,opt,name=watchdog,proto3" json:"watchdog,omitempty"`
+}
+
+func (x *SessionDetail) Reset() {
+	*x = SessionDetail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionDetail) ProtoMessage() {}
+
+func (x *SessionDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionDetail.ProtoReflect.Descriptor instead.
+func (*SessionDetail) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SessionDetail) GetGraphDesc() string {
+	if x != nil {
+		return x.GraphDesc
+	}
+	return ""
+}
+
+func (x *SessionDetail) GetGraph() []*GraphNode {
+	if x != nil {
+		return x.Graph
+	}
+	return nil
+}
+
+func (x *SessionDetail) GetNodes() []*LiveNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *SessionDetail) GetWatchdog() *WatchdogInfo {
+	if x != nil {
+		return x.Watchdog
+	}
+	return nil
+}
+
+type GraphNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type      string            `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Props     map[string]string `protobuf:"bytes,3,rep,name=props,proto3" json:"props,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Receivers []string          `protobuf:"bytes,4,rep,name=receivers,proto3" json:"receivers,omitempty"` // names of nodes it links to
+}
+
+func (x *GraphNode) Reset() {
+	*x = GraphNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GraphNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphNode) ProtoMessage() {}
+
+func (x *GraphNode) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphNode.ProtoReflect.Descriptor instead.
+func (*GraphNode) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GraphNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GraphNode) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GraphNode) GetProps() map[string]string {
+	if x != nil {
+		return x.Props
+	}
+	return nil
+}
+
+func (x *GraphNode) GetReceivers() []string {
+	if x != nil {
+		return x.Receivers
+	}
+	return nil
+}
+
+type LiveNode struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type   string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Stream string `protobuf:"bytes,3,opt,name=stream,proto3" json:"stream,omitempty"` // media stream it binds to if it is edge between rtp stack and graph
+}
+
+func (x *LiveNode) Reset() {
+	*x = LiveNode{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LiveNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LiveNode) ProtoMessage() {}
+
+func (x *LiveNode) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LiveNode.ProtoReflect.Descriptor instead.
+func (*LiveNode) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *LiveNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LiveNode) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LiveNode) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+// timestamps in unix milliseconds, 0 if never reported
+type WatchdogInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	InstanceAliveTime int64          `protobuf:"varint,1,opt,name=instance_alive_time,json=instanceAliveTime,proto3" json:"instance_alive_time,omitempty"` // last session info reported by instance
+	SendAliveTime     int64          `protobuf:"varint,2,opt,name=send_alive_time,json=sendAliveTime,proto3" json:"send_alive_time,omitempty"`
+	ReceiveAliveTime  int64          `protobuf:"varint,3,opt,name=receive_alive_time,json=receiveAliveTime,proto3" json:"receive_alive_time,omitempty"`
+	RtcpAliveTime     int64          `protobuf:"varint,4,opt,name=rtcp_alive_time,json=rtcpAliveTime,proto3" json:"rtcp_alive_time,omitempty"`
+	Errors            int32          `protobuf:"varint,5,opt,name=errors,proto3" json:"errors,omitempty"` // number of errors reported by loops
+	Policy            *WatchdogParam `protobuf:"bytes,6,opt,name=policy,proto3" json:"policy,omitempty"`  // effective policy, 0 means disabled
+}
+
+func (x *WatchdogInfo) Reset() {
+	*x = WatchdogInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchdogInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchdogInfo) ProtoMessage() {}
+
+func (x *WatchdogInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchdogInfo.ProtoReflect.Descriptor instead.
+func (*WatchdogInfo) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WatchdogInfo) GetInstanceAliveTime() int64 {
+	if x != nil {
+		return x.InstanceAliveTime
+	}
+	return 0
+}
+
+func (x *WatchdogInfo) GetSendAliveTime() int64 {
+	if x != nil {
+		return x.SendAliveTime
+	}
+	return 0
+}
+
+func (x *WatchdogInfo) GetReceiveAliveTime() int64 {
+	if x != nil {
+		return x.ReceiveAliveTime
+	}
+	return 0
+}
+
+func (x *WatchdogInfo) GetRtcpAliveTime() int64 {
+	if x != nil {
+		return x.RtcpAliveTime
+	}
+	return 0
+}
+
+func (x *WatchdogInfo) GetErrors() int32 {
+	if x != nil {
+		return x.Errors
+	}
+	return 0
+}
+
+func (x *WatchdogInfo) GetPolicy() *WatchdogParam {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type SessionStatsParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+}
+
+func (x *SessionStatsParam) Reset() {
+	*x = SessionStatsParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionStatsParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionStatsParam) ProtoMessage() {}
+
+func (x *SessionStatsParam) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionStatsParam.ProtoReflect.Descriptor instead.
+func (*SessionStatsParam) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SessionStatsParam) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+// statistics of a rtp stream, inbound stream is measured locally while outbound stream is reported by peer's RTCP
+type StreamStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ssrc            uint32  `protobuf:"varint,1,opt,name=ssrc,proto3" json:"ssrc,omitempty"`
+	Outbound        bool    `protobuf:"varint,2,opt,name=outbound,proto3" json:"outbound,omitempty"`
+	SentPackets     uint32  `protobuf:"varint,3,opt,name=sent_packets,json=sentPackets,proto3" json:"sent_packets,omitempty"`             // sender's packet count, from peer's sender report if inbound
+	SentOctets      uint32  `protobuf:"varint,4,opt,name=sent_octets,json=sentOctets,proto3" json:"sent_octets,omitempty"`                // sender's octet count, from peer's sender report if inbound
+	ReceivedPackets uint32  `protobuf:"varint,5,opt,name=received_packets,json=receivedPackets,proto3" json:"received_packets,omitempty"` // inbound only
+	FractionLost    float32 `protobuf:"fixed32,6,opt,name=fraction_lost,json=fractionLost,proto3" json:"fraction_lost,omitempty"`         // loss ratio(0 ~ 1) of last report interval
+	CumulativeLost  int32   `protobuf:"varint,7,opt,name=cumulative_lost,json=cumulativeLost,proto3" json:"cumulative_lost,omitempty"`
+	Jitter          float32 `protobuf:"fixed32,8,opt,name=jitter,proto3" json:"jitter,omitempty"` // inter-arrival jitter in milliseconds
+	Rtt             float32 `protobuf:"fixed32,9,opt,name=rtt,proto3" json:"rtt,omitempty"`       // round-trip time in milliseconds, outbound only, 0 if unknown
+	Stream          string  `protobuf:"bytes,10,opt,name=stream,proto3" json:"stream,omitempty"`  // name of media stream it belongs to
+}
+
+func (x *StreamStats) Reset() {
+	*x = StreamStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamStats) ProtoMessage() {}
+
+func (x *StreamStats) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamStats.ProtoReflect.Descriptor instead.
+func (*StreamStats) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *StreamStats) GetSsrc() uint32 {
+	if x != nil {
+		return x.Ssrc
+	}
+	return 0
+}
+
+func (x *StreamStats) GetOutbound() bool {
+	if x != nil {
+		return x.Outbound
+	}
+	return false
+}
+
+func (x *StreamStats) GetSentPackets() uint32 {
+	if x != nil {
+		return x.SentPackets
+	}
+	return 0
+}
+
+func (x *StreamStats) GetSentOctets() uint32 {
+	if x != nil {
+		return x.SentOctets
+	}
+	return 0
+}
+
+func (x *StreamStats) GetReceivedPackets() uint32 {
+	if x != nil {
+		return x.ReceivedPackets
+	}
+	return 0
+}
+
+func (x *StreamStats) GetFractionLost() float32 {
+	if x != nil {
+		return x.FractionLost
+	}
+	return 0
+}
+
+func (x *StreamStats) GetCumulativeLost() int32 {
+	if x != nil {
+		return x.CumulativeLost
+	}
+	return 0
+}
+
+func (x *StreamStats) GetJitter() float32 {
+	if x != nil {
+		return x.Jitter
+	}
+	return 0
+}
+
+func (x *StreamStats) GetRtt() float32 {
+	if x != nil {
+		return x.Rtt
+	}
+	return 0
+}
+
+func (x *StreamStats) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+type SessionStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string         `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Streams   []*StreamStats `protobuf:"bytes,2,rep,name=streams,proto3" json:"streams,omitempty"`
+	Rtt       float32        `protobuf:"fixed32,3,opt,name=rtt,proto3" json:"rtt,omitempty"` // latest round-trip time in milliseconds, 0 if unknown
+	Mos       float32        `protobuf:"fixed32,4,opt,name=mos,proto3" json:"mos,omitempty"` // E-model estimate(1.0 ~ 4.5) of inbound audio of the first audio stream, 0 if nothing received
+}
+
+func (x *SessionStats) Reset() {
+	*x = SessionStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionStats) ProtoMessage() {}
+
+func (x *SessionStats) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionStats.ProtoReflect.Descriptor instead.
+func (*SessionStats) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SessionStats) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SessionStats) GetStreams() []*StreamStats {
+	if x != nil {
+		return x.Streams
+	}
+	return nil
+}
+
+func (x *SessionStats) GetRtt() float32 {
+	if x != nil {
+		return x.Rtt
+	}
+	return 0
+}
+
+func (x *SessionStats) GetMos() float32 {
+	if x != nil {
+		return x.Mos
+	}
+	return 0
+}
+
+type Action struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Cmd       string `protobuf:"bytes,2,opt,name=cmd,proto3" json:"cmd,omitempty"`
+	CmdArg    string `protobuf:"bytes,3,opt,name=cmd_arg,json=cmdArg,proto3" json:"cmd_arg,omitempty"`
+}
+
+func (x *Action) Reset() {
+	*x = Action{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Action) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Action) ProtoMessage() {}
+
+func (x *Action) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Action.ProtoReflect.Descriptor instead.
+func (*Action) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Action) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Action) GetCmd() string {
+	if x != nil {
+		return x.Cmd
+	}
+	return ""
+}
+
+func (x *Action) GetCmdArg() string {
+	if x != nil {
+		return x.CmdArg
+	}
+	return ""
+}
+
+// ActionReply is the reply of one node call, built by comp.WithOk or comp.WithError
+type ActionReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok   bool     `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Args []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"` // reply arguments without the leading ok/err
+}
+
+func (x *ActionReply) Reset() {
+	*x = ActionReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionReply) ProtoMessage() {}
+
+func (x *ActionReply) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionReply.ProtoReflect.Descriptor instead.
+func (*ActionReply) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ActionReply) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ActionReply) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+type ActionResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string         `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	State     string         `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`     // all replies joined by space, kept for legacy clients
+	Ok        bool           `protobuf:"varint,3,opt,name=ok,proto3" json:"ok,omitempty"`          // true if every reply is ok
+	Replies   []*ActionReply `protobuf:"bytes,4,rep,name=replies,proto3" json:"replies,omitempty"` // replies in the order of calls
+}
+
+func (x *ActionResult) Reset() {
+	*x = ActionResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionResult) ProtoMessage() {}
+
+func (x *ActionResult) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionResult.ProtoReflect.Descriptor instead.
+func (*ActionResult) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ActionResult) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ActionResult) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ActionResult) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *ActionResult) GetReplies() []*ActionReply {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+type ActionEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Event     string `protobuf:"bytes,2,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *ActionEvent) Reset() {
+	*x = ActionEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActionEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActionEvent) ProtoMessage() {}
+
+func (x *ActionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActionEvent.ProtoReflect.Descriptor instead.
+func (*ActionEvent) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ActionEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ActionEvent) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+type PushData struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// only used in first packet, following packets use the same session id,cmd and node_name
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Cmd       string `protobuf:"bytes,2,opt,name=cmd,proto3" json:"cmd,omitempty"`
+	NodeName  string `protobuf:"bytes,3,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	Data      []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *PushData) Reset() {
+	*x = PushData{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PushData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PushData) ProtoMessage() {}
+
+func (x *PushData) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PushData.ProtoReflect.Descriptor instead.
+func (*PushData) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PushData) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *PushData) GetCmd() string {
+	if x != nil {
+		return x.Cmd
+	}
+	return ""
+}
+
+func (x *PushData) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *PushData) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type NodePropertyInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // property key in nmd graph, i.e. [rtp:rtp_src stream=audio]
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // go type of the field
+}
+
+func (x *NodePropertyInfo) Reset() {
+	*x = NodePropertyInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodePropertyInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodePropertyInfo) ProtoMessage() {}
+
+func (x *NodePropertyInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodePropertyInfo.ProtoReflect.Descriptor instead.
+func (*NodePropertyInfo) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *NodePropertyInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NodePropertyInfo) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type NodeTypeInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string              `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Accept     []string            `protobuf:"bytes,2,rep,name=accept,proto3" json:"accept,omitempty"` // names of accepted message types
+	Offer      []string            `protobuf:"bytes,3,rep,name=offer,proto3" json:"offer,omitempty"`   // names of offered message types, empty if decided when linking
+	Properties []*NodePropertyInfo `protobuf:"bytes,4,rep,name=properties,proto3" json:"properties,omitempty"`
+}
+
+func (x *NodeTypeInfo) Reset() {
+	*x = NodeTypeInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NodeTypeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeTypeInfo) ProtoMessage() {}
+
+func (x *NodeTypeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeTypeInfo.ProtoReflect.Descriptor instead.
+func (*NodeTypeInfo) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *NodeTypeInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NodeTypeInfo) GetAccept() []string {
+	if x != nil {
+		return x.Accept
+	}
+	return nil
+}
+
+func (x *NodeTypeInfo) GetOffer() []string {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+func (x *NodeTypeInfo) GetProperties() []*NodePropertyInfo {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+type MessageTypeInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TypeId int32  `protobuf:"varint,2,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+}
+
+func (x *MessageTypeInfo) Reset() {
+	*x = MessageTypeInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageTypeInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageTypeInfo) ProtoMessage() {}
+
+func (x *MessageTypeInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageTypeInfo.ProtoReflect.Descriptor instead.
+func (*MessageTypeInfo) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *MessageTypeInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MessageTypeInfo) GetTypeId() int32 {
+	if x != nil {
+		return x.TypeId
+	}
+	return 0
+}
+
+type MessageConversion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *MessageConversion) Reset() {
+	*x = MessageConversion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageConversion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageConversion) ProtoMessage() {}
+
+func (x *MessageConversion) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageConversion.ProtoReflect.Descriptor instead.
+func (*MessageConversion) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *MessageConversion) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *MessageConversion) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type Capabilities struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NodeTypes    []*NodeTypeInfo      `protobuf:"bytes,1,rep,name=node_types,json=nodeTypes,proto3" json:"node_types,omitempty"`
+	MessageTypes []*MessageTypeInfo   `protobuf:"bytes,2,rep,name=message_types,json=messageTypes,proto3" json:"message_types,omitempty"`
+	Conversions  []*MessageConversion `protobuf:"bytes,3,rep,name=conversions,proto3" json:"conversions,omitempty"`
+	Codecs       []CodecType          `protobuf:"varint,4,rep,packed,name=codecs,proto3,enum=rpc.CodecType" json:"codecs,omitempty"` // codecs that streams can negotiate
+}
+
+func (x *Capabilities) Reset() {
+	*x = Capabilities{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Capabilities) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Capabilities) ProtoMessage() {}
+
+func (x *Capabilities) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Capabilities.ProtoReflect.Descriptor instead.
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Capabilities) GetNodeTypes() []*NodeTypeInfo {
+	if x != nil {
+		return x.NodeTypes
+	}
+	return nil
+}
+
+func (x *Capabilities) GetMessageTypes() []*MessageTypeInfo {
+	if x != nil {
+		return x.MessageTypes
+	}
+	return nil
+}
+
+func (x *Capabilities) GetConversions() []*MessageConversion {
+	if x != nil {
+		return x.Conversions
+	}
+	return nil
+}
+
+func (x *Capabilities) GetCodecs() []CodecType {
+	if x != nil {
+		return x.Codecs
+	}
+	return nil
+}
+
+type ValidateGraphParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GraphDesc string `protobuf:"bytes,1,opt,name=graph_desc,json=graphDesc,proto3" json:"graph_desc,omitempty"`
+}
+
+func (x *ValidateGraphParam) Reset() {
+	*x = ValidateGraphParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateGraphParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateGraphParam) ProtoMessage() {}
+
+func (x *ValidateGraphParam) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateGraphParam.ProtoReflect.Descriptor instead.
+func (*ValidateGraphParam) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ValidateGraphParam) GetGraphDesc() string {
+	if x != nil {
+		return x.GraphDesc
+	}
+	return ""
+}
+
+type GraphDiagnostic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Line    int32  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`     // counts from 1, 0 if the problem can not be located
+	Column  int32  `protobuf:"varint,2,opt,name=column,proto3" json:"column,omitempty"` // counts from 1, 0 if the problem can not be located
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *GraphDiagnostic) Reset() {
+	*x = GraphDiagnostic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GraphDiagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphDiagnostic) ProtoMessage() {}
+
+func (x *GraphDiagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphDiagnostic.ProtoReflect.Descriptor instead.
+func (*GraphDiagnostic) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GraphDiagnostic) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *GraphDiagnostic) GetColumn() int32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *GraphDiagnostic) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GraphValidation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ok          bool               `protobuf:"varint,1,opt,name=ok,proto3" json:"ok,omitempty"`
+	Diagnostics []*GraphDiagnostic `protobuf:"bytes,2,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+}
+
+func (x *GraphValidation) Reset() {
+	*x = GraphValidation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GraphValidation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GraphValidation) ProtoMessage() {}
+
+func (x *GraphValidation) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GraphValidation.ProtoReflect.Descriptor instead.
+func (*GraphValidation) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GraphValidation) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *GraphValidation) GetDiagnostics() []*GraphDiagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
+
+// LoadReport helps signalling layer to place new sessions on the least loaded server
+type LoadReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FreePorts       int32   `protobuf:"varint,1,opt,name=free_ports,json=freePorts,proto3" json:"free_ports,omitempty"` // each stream of session takes one rtp port
+	TotalPorts      int32   `protobuf:"varint,2,opt,name=total_ports,json=totalPorts,proto3" json:"total_ports,omitempty"`
+	Sessions        int32   `protobuf:"varint,3,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	StartedSessions int32   `protobuf:"varint,4,opt,name=started_sessions,json=startedSessions,proto3" json:"started_sessions,omitempty"`
+	GraphNodes      int32   `protobuf:"varint,5,opt,name=graph_nodes,json=graphNodes,proto3" json:"graph_nodes,omitempty"`
+	GraphLinks      int32   `protobuf:"varint,6,opt,name=graph_links,json=graphLinks,proto3" json:"graph_links,omitempty"`
+	CpuUsage        float64 `protobuf:"fixed64,7,opt,name=cpu_usage,json=cpuUsage,proto3" json:"cpu_usage,omitempty"` // cpu time of server process over all cores since last report, in [0,1]
+	NumCpu          int32   `protobuf:"varint,8,opt,name=num_cpu,json=numCpu,proto3" json:"num_cpu,omitempty"`
+	Draining        bool    `protobuf:"varint,9,opt,name=draining,proto3" json:"draining,omitempty"` // draining server accepts no new session
+}
+
+func (x *LoadReport) Reset() {
+	*x = LoadReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadReport) ProtoMessage() {}
+
+func (x *LoadReport) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadReport.ProtoReflect.Descriptor instead.
+func (*LoadReport) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *LoadReport) GetFreePorts() int32 {
+	if x != nil {
+		return x.FreePorts
+	}
+	return 0
+}
+
+func (x *LoadReport) GetTotalPorts() int32 {
+	if x != nil {
+		return x.TotalPorts
+	}
+	return 0
+}
+
+func (x *LoadReport) GetSessions() int32 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *LoadReport) GetStartedSessions() int32 {
+	if x != nil {
+		return x.StartedSessions
+	}
+	return 0
+}
+
+func (x *LoadReport) GetGraphNodes() int32 {
+	if x != nil {
+		return x.GraphNodes
+	}
+	return 0
+}
+
+func (x *LoadReport) GetGraphLinks() int32 {
+	if x != nil {
+		return x.GraphLinks
+	}
+	return 0
+}
+
+func (x *LoadReport) GetCpuUsage() float64 {
+	if x != nil {
+		return x.CpuUsage
+	}
+	return 0
+}
+
+func (x *LoadReport) GetNumCpu() int32 {
+	if x != nil {
+		return x.NumCpu
+	}
+	return 0
+}
+
+func (x *LoadReport) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+type SystemEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Cmd        SystemCommand `protobuf:"varint,1,opt,name=cmd,proto3,enum=rpc.SystemCommand" json:"cmd,omitempty"`
+	InstanceId string        `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	SessionId  string        `protobuf:"bytes,3,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Event      string        `protobuf:"bytes,4,opt,name=event,proto3" json:"event,omitempty"`
+}
+
+func (x *SystemEvent) Reset() {
+	*x = SystemEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_msapi_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SystemEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemEvent) ProtoMessage() {}
+
+func (x *SystemEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_msapi_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemEvent.ProtoReflect.Descriptor instead.
+func (*SystemEvent) Descriptor() ([]byte, []int) {
+	return file_msapi_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *SystemEvent) GetCmd() SystemCommand {
+	if x != nil {
+		return x.Cmd
+	}
+	return SystemCommand_USER_EVENT
+}
+
+func (x *SystemEvent) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *SystemEvent) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *SystemEvent) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+var File_msapi_proto protoreflect.FileDescriptor
+
+var file_msapi_proto_rawDesc = []byte{
+	0x0a, 0x0b, 0x6d, 0x73, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x03, 0x72,
+	0x70, 0x63, 0x22, 0x56, 0x0a, 0x0d, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x12, 0x1e, 0x0a, 0x03, 0x76, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x03,
+	0x76, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x05, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x44, 0x72, 0x61, 0x69, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x65, 0x52, 0x05, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x22, 0x73, 0x0a, 0x0a, 0x44, 0x72,
+	0x61, 0x69, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x72, 0x61, 0x69,
+	0x6e, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x72, 0x61, 0x69,
+	0x6e, 0x69, 0x6e, 0x67, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e,
+	0x67, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x11, 0x72, 0x65, 0x6d, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x22,
+	0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x86, 0x01, 0x0a, 0x09, 0x43, 0x6f, 0x64,
+	0x65, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
+	0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x31, 0x0a,
+	0x0c, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x63, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x0b, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x54, 0x79, 0x70, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x22, 0xe3, 0x03, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x65,
+	0x65, 0x72, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70,
+	0x65, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x64, 0x65, 0x63,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f,
+	0x64, 0x65, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x61, 0x70, 0x68, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x61, 0x70, 0x68, 0x44, 0x65, 0x73, 0x63, 0x12, 0x1f,
+	0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x3b, 0x0a, 0x0d, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4a, 0x69, 0x74,
+	0x74, 0x65, 0x72, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x0c,
+	0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x5f, 0x64, 0x74, 0x6d, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0a, 0x6e, 0x6f, 0x74, 0x69, 0x66, 0x79, 0x44, 0x74, 0x6d, 0x66, 0x12, 0x22, 0x0a,
+	0x04, 0x73, 0x72, 0x74, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x72, 0x74, 0x70, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x04, 0x73, 0x72, 0x74,
+	0x70, 0x12, 0x25, 0x0a, 0x05, 0x6c, 0x61, 0x74, 0x63, 0x68, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x52, 0x05, 0x6c, 0x61, 0x74, 0x63, 0x68, 0x12, 0x2a, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x07, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x73, 0x12, 0x2e, 0x0a, 0x08, 0x77, 0x61, 0x74, 0x63, 0x68, 0x64, 0x6f, 0x67,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x64, 0x6f, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x08, 0x77, 0x61, 0x74, 0x63,
+	0x68, 0x64, 0x6f, 0x67, 0x12, 0x31, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65,
+	0x64, 0x69, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x99, 0x01, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70,
+	0x65, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08,
+	0x70, 0x65, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x64, 0x65,
+	0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43,
+	0x6f, 0x64, 0x65, 0x63, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x06, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x73,
+	0x12, 0x31, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x44,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x22, 0x24, 0x0a, 0x0a, 0x4c, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x22, 0xb6, 0x01, 0x0a, 0x0d, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x64, 0x6f, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x2d, 0x0a, 0x12, 0x69,
+	0x6e, 0x61, 0x63, 0x74, 0x69, 0x76, 0x69, 0x74, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11, 0x69, 0x6e, 0x61, 0x63, 0x74, 0x69, 0x76,
+	0x69, 0x74, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x6f, 0x6e,
+	0x65, 0x5f, 0x77, 0x61, 0x79, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6f, 0x6e, 0x65, 0x57, 0x61, 0x79, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x78, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x44, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65,
+	0x61, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x10, 0x68, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x22, 0x73, 0x0a, 0x09, 0x53, 0x72, 0x74, 0x70, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12,
+	0x2a, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x72, 0x74, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x4b, 0x65, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x6d, 0x6f,
+	0x74, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x6d, 0x6f, 0x74, 0x65, 0x4b, 0x65, 0x79, 0x22, 0x69, 0x0a, 0x11, 0x4a, 0x69, 0x74, 0x74, 0x65,
+	0x72, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x1b, 0x0a, 0x09,
+	0x6d, 0x69, 0x6e, 0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x08, 0x6d, 0x69, 0x6e, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78,
+	0x5f, 0x64, 0x65, 0x6c, 0x61, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6d, 0x61,
+	0x78, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x64, 0x61, 0x70, 0x74, 0x69,
+	0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x61, 0x64, 0x61, 0x70, 0x74, 0x69,
+	0x76, 0x65, 0x22, 0x8d, 0x02, 0x0a, 0x0b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72,
+	0x61, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x65,
+	0x65, 0x72, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70,
+	0x65, 0x65, 0x72, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61, 0x79, 0x6c, 0x6f,
+	0x61, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0d, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x22,
+	0x0a, 0x04, 0x73, 0x72, 0x74, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x53, 0x72, 0x74, 0x70, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x04, 0x73, 0x72,
+	0x74, 0x70, 0x12, 0x2b, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12,
+	0x31, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x44, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x22, 0x99, 0x01, 0x0a, 0x0c, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x65, 0x65, 0x72, 0x5f,
+	0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x65, 0x65, 0x72,
+	0x50, 0x6f, 0x72, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x09, 0x64,
+	0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x2b,
+	0x0a, 0x0a, 0x53, 0x74, 0x61, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x2a, 0x0a, 0x09, 0x53,
+	0x74, 0x6f, 0x70, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x20, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0xf7, 0x01, 0x0a, 0x07, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x69, 0x70,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x49, 0x70, 0x12,
+	0x24, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x72, 0x74, 0x70, 0x5f, 0x70, 0x6f, 0x72,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x74,
+	0x70, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x69, 0x70,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x70, 0x12, 0x22,
+	0x0a, 0x0d, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x72, 0x74, 0x70, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x52, 0x74, 0x70, 0x50, 0x6f,
+	0x72, 0x74, 0x12, 0x24, 0x0a, 0x0e, 0x73, 0x72, 0x74, 0x70, 0x5f, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x72, 0x74, 0x70,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x4b, 0x65, 0x79, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x73, 0x22, 0x85, 0x02, 0x0a, 0x0a, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f,
+	0x72, 0x74, 0x70, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x74, 0x70, 0x50, 0x6f, 0x72, 0x74, 0x12, 0x22, 0x0a, 0x0d,
+	0x70, 0x65, 0x65, 0x72, 0x5f, 0x72, 0x74, 0x70, 0x5f, 0x70, 0x6f, 0x72, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0b, 0x70, 0x65, 0x65, 0x72, 0x52, 0x74, 0x70, 0x50, 0x6f, 0x72, 0x74,
+	0x12, 0x25, 0x0a, 0x0e, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x65, 0x65, 0x72, 0x5f,
+	0x69, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x65, 0x65, 0x72, 0x49, 0x70,
+	0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x63, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x06, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x60, 0x0a, 0x11, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49,
+	0x64, 0x12, 0x2a, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x3b, 0x0a,
+	0x0b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2c, 0x0a, 0x08,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x30, 0x0a, 0x0f, 0x47, 0x65,
+	0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0xbe, 0x02, 0x0a,
+	0x0b, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72,
+	0x70, 0x63, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x5f, 0x69, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x6f, 0x63,
+	0x61, 0x6c, 0x49, 0x70, 0x12, 0x29, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12,
+	0x2a, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x30, 0x0a, 0x0b, 0x73,
+	0x74, 0x6f, 0x70, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x0f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x52, 0x0a, 0x73, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x52, 0x0a,
+	0x12, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x66, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x65,
+	0x71, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x61, 0x66, 0x74, 0x65, 0x72, 0x53, 0x65,
+	0x71, 0x22, 0xf3, 0x01, 0x0a, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x73, 0x65, 0x71, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x03, 0x73, 0x65, 0x71, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x15, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1f,
+	0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x27, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x29, 0x0a, 0x07,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07,
+	0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x22, 0xa8, 0x01, 0x0a, 0x0d, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x61,
+	0x70, 0x68, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x44, 0x65, 0x73, 0x63, 0x12, 0x24, 0x0a, 0x05, 0x67, 0x72, 0x61, 0x70,
+	0x68, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x67, 0x72, 0x61, 0x70, 0x68, 0x12, 0x23,
+	0x0a, 0x05, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x52, 0x05, 0x6e, 0x6f,
+	0x64, 0x65, 0x73, 0x12, 0x2d, 0x0a, 0x08, 0x77, 0x61, 0x74, 0x63, 0x68, 0x64, 0x6f, 0x67, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x64, 0x6f, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x08, 0x77, 0x61, 0x74, 0x63, 0x68, 0x64,
+	0x6f, 0x67, 0x22, 0xbc, 0x01, 0x0a, 0x09, 0x47, 0x72, 0x61, 0x70, 0x68, 0x4e, 0x6f, 0x64, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2f, 0x0a, 0x05, 0x70, 0x72, 0x6f, 0x70,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x4e, 0x6f, 0x64, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x70, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x05, 0x70, 0x72, 0x6f, 0x70, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x72, 0x73, 0x1a, 0x38, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x70, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
+	0x01, 0x22, 0x4a, 0x0a, 0x08, 0x4c, 0x69, 0x76, 0x65, 0x4e, 0x6f, 0x64, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x22, 0x80, 0x02,
+	0x0a, 0x0c, 0x57, 0x61, 0x74, 0x63, 0x68, 0x64, 0x6f, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2e,
+	0x0a, 0x13, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x61, 0x6c, 0x69, 0x76, 0x65,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x69, 0x6e, 0x73,
+	0x74, 0x61, 0x6e, 0x63, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x26,
+	0x0a, 0x0f, 0x73, 0x65, 0x6e, 0x64, 0x5f, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x73, 0x65, 0x6e, 0x64, 0x41, 0x6c, 0x69,
+	0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x5f, 0x61, 0x6c, 0x69, 0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x10, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x41, 0x6c, 0x69, 0x76, 0x65,
+	0x54, 0x69, 0x6d, 0x65, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x74, 0x63, 0x70, 0x5f, 0x61, 0x6c, 0x69,
+	0x76, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72,
+	0x74, 0x63, 0x70, 0x41, 0x6c, 0x69, 0x76, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x12, 0x2a, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x64, 0x6f, 0x67, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x22, 0x32, 0x0a, 0x11, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x22, 0xbc, 0x02, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x53,
+	0x74, 0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x73, 0x72, 0x63, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x73, 0x73, 0x72, 0x63, 0x12, 0x1a, 0x0a, 0x08, 0x6f, 0x75, 0x74, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6f, 0x75, 0x74, 0x62,
+	0x6f, 0x75, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x6e, 0x74, 0x5f, 0x70, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x65, 0x6e, 0x74,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x6e, 0x74, 0x5f,
+	0x6f, 0x63, 0x74, 0x65, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x65,
+	0x6e, 0x74, 0x4f, 0x63, 0x74, 0x65, 0x74, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x50, 0x61, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6c, 0x6f, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0c, 0x66, 0x72, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x4c, 0x6f, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x75, 0x6d, 0x75,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x6c, 0x6f, 0x73, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0e, 0x63, 0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x4c, 0x6f, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x02, 0x52, 0x06, 0x6a, 0x69, 0x74, 0x74, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x74, 0x74,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03, 0x72, 0x74, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x22, 0x7d, 0x0a, 0x0c, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x49, 0x64, 0x12, 0x2a, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x07, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x73, 0x12, 0x10,
+	0x0a, 0x03, 0x72, 0x74, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03, 0x72, 0x74, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x6d, 0x6f, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x03, 0x6d,
+	0x6f, 0x73, 0x22, 0x52, 0x0a, 0x06, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a, 0x0a,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63,
+	0x6d, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x17, 0x0a,
+	0x07, 0x63, 0x6d, 0x64, 0x5f, 0x61, 0x72, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x6d, 0x64, 0x41, 0x72, 0x67, 0x22, 0x31, 0x0a, 0x0b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x22, 0x7f, 0x0a, 0x0c, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x2a,
+	0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x65, 0x73, 0x22, 0x42, 0x0a, 0x0b, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x6c,
+	0x0a, 0x08, 0x50, 0x75, 0x73, 0x68, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x6d, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x63, 0x6d, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6e,
+	0x6f, 0x64, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x3a, 0x0a, 0x10,
+	0x4e, 0x6f, 0x64, 0x65, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x87, 0x01, 0x0a, 0x0c, 0x4e, 0x6f, 0x64,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a,
+	0x06, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x61,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x66, 0x66, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x0a, 0x70,
+	0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x15, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x50, 0x72, 0x6f, 0x70, 0x65, 0x72,
+	0x74, 0x79, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x70, 0x65, 0x72, 0x74, 0x69,
+	0x65, 0x73, 0x22, 0x3e, 0x0a, 0x0f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x79, 0x70, 0x65,
+	0x49, 0x64, 0x22, 0x37, 0x0a, 0x11, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02, 0x74,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x22, 0xdd, 0x01, 0x0a, 0x0c,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x0a,
+	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x09, 0x6e, 0x6f, 0x64, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x39,
+	0x0a, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x38, 0x0a, 0x0b, 0x63, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x6e, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x63, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x06, 0x63, 0x6f, 0x64, 0x65, 0x63, 0x73, 0x22, 0x33, 0x0a, 0x12, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x47, 0x72, 0x61, 0x70, 0x68, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x61, 0x70, 0x68, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x61, 0x70, 0x68, 0x44, 0x65, 0x73, 0x63,
+	0x22, 0x57, 0x0a, 0x0f, 0x47, 0x72, 0x61, 0x70, 0x68, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
+	0x74, 0x69, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d,
+	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x59, 0x0a, 0x0f, 0x47, 0x72, 0x61,
+	0x70, 0x68, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02,
+	0x6f, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x36, 0x0a, 0x0b,
+	0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x47, 0x72, 0x61, 0x70, 0x68, 0x44, 0x69, 0x61,
+	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x52, 0x0b, 0x64, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73,
+	0x74, 0x69, 0x63, 0x73, 0x22, 0xa7, 0x02, 0x0a, 0x0a, 0x4c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x65, 0x65, 0x5f, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x72, 0x65, 0x65, 0x50, 0x6f, 0x72,
+	0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x70, 0x6f, 0x72, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x6f,
+	0x72, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x29, 0x0a, 0x10, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x65, 0x64, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x67, 0x72,
+	0x61, 0x70, 0x68, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x67, 0x72, 0x61, 0x70, 0x68, 0x4e, 0x6f, 0x64, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x67,
+	0x72, 0x61, 0x70, 0x68, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x67, 0x72, 0x61, 0x70, 0x68, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12, 0x1b, 0x0a, 0x09,
+	0x63, 0x70, 0x75, 0x5f, 0x75, 0x73, 0x61, 0x67, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x08, 0x63, 0x70, 0x75, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x75, 0x6d,
+	0x5f, 0x63, 0x70, 0x75, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x43,
+	0x70, 0x75, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x72, 0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x89,
+	0x01, 0x0a, 0x0b, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x24,
+	0x0a, 0x03, 0x63, 0x6d, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x52,
+	0x03, 0x63, 0x6d, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x2a, 0x21, 0x0a, 0x07, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x09, 0x0a, 0x05, 0x44, 0x55, 0x4d, 0x4d, 0x59, 0x10, 0x00,
+	0x12, 0x0b, 0x0a, 0x07, 0x44, 0x45, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x10, 0x16, 0x2a, 0x7c, 0x0a,
+	0x09, 0x43, 0x6f, 0x64, 0x65, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x41,
+	0x57, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x54, 0x45, 0x4c, 0x45, 0x50, 0x48, 0x4f, 0x4e, 0x45,
+	0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x38, 0x4b, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x54,
+	0x45, 0x4c, 0x45, 0x50, 0x48, 0x4f, 0x4e, 0x45, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x31,
+	0x36, 0x4b, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x50, 0x43, 0x4d, 0x5f, 0x41, 0x4c, 0x41, 0x57,
+	0x10, 0x03, 0x12, 0x09, 0x0a, 0x05, 0x41, 0x4d, 0x52, 0x4e, 0x42, 0x10, 0x04, 0x12, 0x09, 0x0a,
+	0x05, 0x41, 0x4d, 0x52, 0x57, 0x42, 0x10, 0x05, 0x12, 0x08, 0x0a, 0x04, 0x48, 0x32, 0x36, 0x34,
+	0x10, 0x06, 0x12, 0x07, 0x0a, 0x03, 0x45, 0x56, 0x53, 0x10, 0x07, 0x2a, 0x4e, 0x0a, 0x0d, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x13, 0x0a, 0x0f,
+	0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41,
+	0x52, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f,
+	0x4e, 0x5f, 0x53, 0x54, 0x4f, 0x50, 0x50, 0x45, 0x44, 0x10, 0x03, 0x2a, 0xdb, 0x01, 0x0a, 0x0a,
+	0x53, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x54,
+	0x4f, 0x50, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00,
+	0x12, 0x0c, 0x0a, 0x08, 0x52, 0x50, 0x43, 0x5f, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x01, 0x12, 0x0c,
+	0x0a, 0x08, 0x52, 0x54, 0x43, 0x50, 0x5f, 0x42, 0x59, 0x45, 0x10, 0x02, 0x12, 0x14, 0x0a, 0x10,
+	0x57, 0x41, 0x54, 0x43, 0x48, 0x44, 0x4f, 0x47, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54,
+	0x10, 0x03, 0x12, 0x13, 0x0a, 0x0f, 0x54, 0x4f, 0x4f, 0x5f, 0x4d, 0x41, 0x4e, 0x59, 0x5f, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x53, 0x10, 0x04, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x54, 0x41, 0x52, 0x54,
+	0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x44, 0x52, 0x41,
+	0x49, 0x4e, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x06, 0x12, 0x12, 0x0a, 0x0e,
+	0x4d, 0x45, 0x44, 0x49, 0x41, 0x5f, 0x49, 0x4e, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x07,
+	0x12, 0x11, 0x0a, 0x0d, 0x4f, 0x4e, 0x45, 0x5f, 0x57, 0x41, 0x59, 0x5f, 0x4d, 0x45, 0x44, 0x49,
+	0x41, 0x10, 0x08, 0x12, 0x10, 0x0a, 0x0c, 0x4d, 0x41, 0x58, 0x5f, 0x44, 0x55, 0x52, 0x41, 0x54,
+	0x49, 0x4f, 0x4e, 0x10, 0x09, 0x12, 0x12, 0x0a, 0x0e, 0x48, 0x45, 0x41, 0x52, 0x54, 0x42, 0x45,
+	0x41, 0x54, 0x5f, 0x4c, 0x4f, 0x53, 0x54, 0x10, 0x0a, 0x2a, 0x7e, 0x0a, 0x10, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a,
+	0x15, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x43,
+	0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x53, 0x53,
+	0x49, 0x4f, 0x4e, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45,
+	0x44, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x45,
+	0x56, 0x45, 0x4e, 0x54, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x19,
+	0x0a, 0x15, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f,
+	0x53, 0x54, 0x4f, 0x50, 0x50, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x6c, 0x0a, 0x0b, 0x53, 0x72, 0x74,
+	0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x0d, 0x0a, 0x09, 0x53, 0x52, 0x54, 0x50,
+	0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x45, 0x53, 0x5f, 0x43,
+	0x4d, 0x5f, 0x31, 0x32, 0x38, 0x5f, 0x48, 0x4d, 0x41, 0x43, 0x5f, 0x53, 0x48, 0x41, 0x31, 0x5f,
+	0x38, 0x30, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x41, 0x45, 0x53, 0x5f, 0x43, 0x4d, 0x5f, 0x31,
+	0x32, 0x38, 0x5f, 0x48, 0x4d, 0x41, 0x43, 0x5f, 0x53, 0x48, 0x41, 0x31, 0x5f, 0x33, 0x32, 0x10,
+	0x02, 0x12, 0x14, 0x0a, 0x10, 0x41, 0x45, 0x41, 0x44, 0x5f, 0x41, 0x45, 0x53, 0x5f, 0x31, 0x32,
+	0x38, 0x5f, 0x47, 0x43, 0x4d, 0x10, 0x03, 0x2a, 0x61, 0x0a, 0x0e, 0x4d, 0x65, 0x64, 0x69, 0x61,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x13, 0x44, 0x49, 0x52,
+	0x45, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08, 0x53, 0x45, 0x4e, 0x44, 0x52, 0x45, 0x43, 0x56, 0x10, 0x01,
+	0x12, 0x0c, 0x0a, 0x08, 0x53, 0x45, 0x4e, 0x44, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x02, 0x12, 0x0c,
+	0x0a, 0x08, 0x52, 0x45, 0x43, 0x56, 0x4f, 0x4e, 0x4c, 0x59, 0x10, 0x03, 0x12, 0x0c, 0x0a, 0x08,
+	0x49, 0x4e, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x04, 0x2a, 0x87, 0x01, 0x0a, 0x0d, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x0a,
+	0x55, 0x53, 0x45, 0x52, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x10, 0x00, 0x12, 0x0c, 0x0a, 0x08,
+	0x52, 0x45, 0x47, 0x49, 0x53, 0x54, 0x45, 0x52, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4b, 0x45,
+	0x45, 0x50, 0x41, 0x4c, 0x49, 0x56, 0x45, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x45, 0x53,
+	0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0x03, 0x12, 0x08, 0x0a, 0x04, 0x44,
+	0x54, 0x4d, 0x46, 0x10, 0x04, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e,
+	0x5f, 0x54, 0x45, 0x52, 0x4d, 0x49, 0x4e, 0x41, 0x54, 0x45, 0x44, 0x10, 0x05, 0x12, 0x15, 0x0a,
+	0x11, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x56, 0x45, 0x52,
+	0x45, 0x44, 0x10, 0x06, 0x32, 0x83, 0x07, 0x0a, 0x08, 0x4d, 0x65, 0x64, 0x69, 0x61, 0x41, 0x70,
+	0x69, 0x12, 0x2e, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x0a, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22,
+	0x00, 0x12, 0x32, 0x0a, 0x0e, 0x50, 0x72, 0x65, 0x70, 0x61, 0x72, 0x65, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x0c, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x22, 0x00, 0x12, 0x30, 0x0a, 0x0d, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x2e, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74,
+	0x61, 0x72, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x2c, 0x0a, 0x0b, 0x53, 0x74, 0x6f, 0x70, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x6f,
+	0x70, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x22, 0x00, 0x12, 0x31, 0x0a, 0x0d, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65,
+	0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x1a, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x00, 0x12, 0x3c, 0x0a, 0x17, 0x45, 0x78, 0x65, 0x63,
+	0x75, 0x74, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x4e, 0x6f, 0x74,
+	0x69, 0x66, 0x79, 0x12, 0x0b, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3d, 0x0a, 0x15, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74,
+	0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x57, 0x69, 0x74, 0x68, 0x50, 0x75, 0x73, 0x68, 0x12,
+	0x0d, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x50, 0x75, 0x73, 0x68, 0x44, 0x61, 0x74, 0x61, 0x1a, 0x11,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x22, 0x00, 0x28, 0x01, 0x12, 0x39, 0x0a, 0x0d, 0x53, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x43,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x12, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x79, 0x73,
+	0x74, 0x65, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53,
+	0x79, 0x73, 0x74, 0x65, 0x6d, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x00, 0x28, 0x01, 0x30, 0x01,
+	0x12, 0x3e, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x16, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x11, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x00,
+	0x12, 0x3a, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x16, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53,
+	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x22, 0x00, 0x12, 0x36, 0x0a, 0x0a,
+	0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x2e, 0x72, 0x70, 0x63,
+	0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6d,
+	0x1a, 0x10, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
+	0x66, 0x6f, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x0d, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x17, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x11,
+	0x2e, 0x72, 0x70, 0x63, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x22, 0x00, 0x30, 0x01, 0x12, 0x37, 0x0a, 0x14, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x0a, 0x2e,
+	0x72, 0x70, 0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x11, 0x2e, 0x72, 0x70, 0x63, 0x2e,
+	0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x22, 0x00, 0x12, 0x40,
+	0x0a, 0x0d, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x47, 0x72, 0x61, 0x70, 0x68, 0x12,
+	0x17, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x47, 0x72,
+	0x61, 0x70, 0x68, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x14, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x47,
+	0x72, 0x61, 0x70, 0x68, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x00,
+	0x12, 0x28, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x61, 0x64, 0x12, 0x0a, 0x2e, 0x72, 0x70,
+	0x63, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x0f, 0x2e, 0x72, 0x70, 0x63, 0x2e, 0x4c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x42, 0x26, 0x5a, 0x24, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x70, 0x70, 0x63, 0x72, 0x61, 0x73,
+	0x68, 0x2f, 0x6d, 0x65, 0x64, 0x69, 0x61, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2f, 0x72,
+	0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_msapi_proto_rawDescOnce sync.Once
+	file_msapi_proto_rawDescData = file_msapi_proto_rawDesc
+)
+
+func file_msapi_proto_rawDescGZIP() []byte {
+	file_msapi_proto_rawDescOnce.Do(func() {
+		file_msapi_proto_rawDescData = protoimpl.X.CompressGZIP(file_msapi_proto_rawDescData)
+	})
+	return file_msapi_proto_rawDescData
+}
+
+var file_msapi_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_msapi_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
+var file_msapi_proto_goTypes = []interface{}{
+	(Version)(0),               // 0: rpc.Version
+	(CodecType)(0),             // 1: rpc.CodecType
+	(SessionStatus)(0),         // 2: rpc.SessionStatus
+	(StopReason)(0),            // 3: rpc.StopReason
+	(SessionEventType)(0),      // 4: rpc.SessionEventType
+	(SrtpProfile)(0),           // 5: rpc.SrtpProfile
+	(MediaDirection)(0),        // 6: rpc.MediaDirection
+	(SystemCommand)(0),         // 7: rpc.SystemCommand
+	(*VersionNumber)(nil),      // 8: rpc.VersionNumber
+	(*DrainState)(nil),         // 9: rpc.DrainState
+	(*Empty)(nil),              // 10: rpc.Empty
+	(*CodecInfo)(nil),          // 11: rpc.CodecInfo
+	(*CreateParam)(nil),        // 12: rpc.CreateParam
+	(*StreamParam)(nil),        // 13: rpc.StreamParam
+	(*LatchParam)(nil),         // 14: rpc.LatchParam
+	(*WatchdogParam)(nil),      // 15: rpc.WatchdogParam
+	(*SrtpParam)(nil),          // 16: rpc.SrtpParam
+	(*JitterBufferParam)(nil),  // 17: rpc.JitterBufferParam
+	(*UpdateParam)(nil),        // 18: rpc.UpdateParam
+	(*StreamUpdate)(nil),       // 19: rpc.StreamUpdate
+	(*StartParam)(nil),         // 20: rpc.StartParam
+	(*StopParam)(nil),          // 21: rpc.StopParam
+	(*Status)(nil),             // 22: rpc.Status
+	(*Session)(nil),            // 23: rpc.Session
+	(*StreamInfo)(nil),         // 24: rpc.StreamInfo
+	(*ListSessionsParam)(nil),  // 25: rpc.ListSessionsParam
+	(*SessionList)(nil),        // 26: rpc.SessionList
+	(*GetSessionParam)(nil),    // 27: rpc.GetSessionParam
+	(*SessionInfo)(nil),        // 28: rpc.SessionInfo
+	(*WatchSessionsParam)(nil), // 29: rpc.WatchSessionsParam
+	(*SessionEvent)(nil),       // 30: rpc.SessionEvent
+	(*SessionDetail)(nil),      // 31: rpc.SessionDetail
+	(*GraphNode)(nil),          // 32: rpc.GraphNode
+	(*LiveNode)(nil),           // 33: rpc.LiveNode
+	(*WatchdogInfo)(nil),       // 34: rpc.WatchdogInfo
+	(*SessionStatsParam)(nil),  // 35: rpc.SessionStatsParam
+	(*StreamStats)(nil),        // 36: rpc.StreamStats
+	(*SessionStats)(nil),       // 37: rpc.SessionStats
+	(*Action)(nil),             // 38: rpc.Action
+	(*ActionReply)(nil),        // 39: rpc.ActionReply
+	(*ActionResult)(nil),       // 40: rpc.ActionResult
+	(*ActionEvent)(nil),        // 41: rpc.ActionEvent
+	(*PushData)(nil),           // 42: rpc.PushData
+	(*NodePropertyInfo)(nil),   // 43: rpc.NodePropertyInfo
+	(*NodeTypeInfo)(nil),       // 44: rpc.NodeTypeInfo
+	(*MessageTypeInfo)(nil),    // 45: rpc.MessageTypeInfo
+	(*MessageConversion)(nil),  // 46: rpc.MessageConversion
+	(*Capabilities)(nil),       // 47: rpc.Capabilities
+	(*ValidateGraphParam)(nil), // 48: rpc.ValidateGraphParam
+	(*GraphDiagnostic)(nil),    // 49: rpc.GraphDiagnostic
+	(*GraphValidation)(nil),    // 50: rpc.GraphValidation
+	(*LoadReport)(nil),         // 51: rpc.LoadReport
+	(*SystemEvent)(nil),        // 52: rpc.SystemEvent
+	nil,                        // 53: rpc.GraphNode.PropsEntry
+}
+var file_msapi_proto_depIdxs = []int32{
+	0,  // 0: rpc.VersionNumber.ver:type_name -> rpc.Version
+	9,  // 1: rpc.VersionNumber.drain:type_name -> rpc.DrainState
+	1,  // 2: rpc.CodecInfo.payload_type:type_name -> rpc.CodecType
+	11, // 3: rpc.CreateParam.codecs:type_name -> rpc.CodecInfo
+	17, // 4: rpc.CreateParam.jitter_buffer:type_name -> rpc.JitterBufferParam
+	16, // 5: rpc.CreateParam.srtp:type_name -> rpc.SrtpParam
+	14, // 6: rpc.CreateParam.latch:type_name -> rpc.LatchParam
+	13, // 7: rpc.CreateParam.streams:type_name -> rpc.StreamParam
+	15, // 8: rpc.CreateParam.watchdog:type_name -> rpc.WatchdogParam
+	6,  // 9: rpc.CreateParam.direction:type_name -> rpc.MediaDirection
+	11, // 10: rpc.StreamParam.codecs:type_name -> rpc.CodecInfo
+	6,  // 11: rpc.StreamParam.direction:type_name -> rpc.MediaDirection
+	5,  // 12: rpc.SrtpParam.profile:type_name -> rpc.SrtpProfile
+	16, // 13: rpc.UpdateParam.srtp:type_name -> rpc.SrtpParam
+	19, // 14: rpc.UpdateParam.streams:type_name -> rpc.StreamUpdate
+	6,  // 15: rpc.UpdateParam.direction:type_name -> rpc.MediaDirection
+	6,  // 16: rpc.StreamUpdate.direction:type_name -> rpc.MediaDirection
+	24, // 17: rpc.Session.streams:type_name -> rpc.StreamInfo
+	11, // 18: rpc.StreamInfo.codecs:type_name -> rpc.CodecInfo
+	6,  // 19: rpc.StreamInfo.direction:type_name -> rpc.MediaDirection
+	2,  // 20: rpc.ListSessionsParam.status:type_name -> rpc.SessionStatus
+	28, // 21: rpc.SessionList.sessions:type_name -> rpc.SessionInfo
+	2,  // 22: rpc.SessionInfo.status:type_name -> rpc.SessionStatus
+	24, // 23: rpc.SessionInfo.streams:type_name -> rpc.StreamInfo
+	31, // 24: rpc.SessionInfo.detail:type_name -> rpc.SessionDetail
+	3,  // 25: rpc.SessionInfo.stop_reason:type_name -> rpc.StopReason
+	4,  // 26: rpc.SessionEvent.type:type_name -> rpc.SessionEventType
+	3,  // 27: rpc.SessionEvent.reason:type_name -> rpc.StopReason
+	24, // 28: rpc.SessionEvent.streams:type_name -> rpc.StreamInfo
+	32, // 29: rpc.SessionDetail.graph:type_name -> rpc.GraphNode
+	33, // 30: rpc.SessionDetail.nodes:type_name -> rpc.LiveNode
+	34, // 31: rpc.SessionDetail.watchdog:type_name -> rpc.WatchdogInfo
+	53, // 32: rpc.GraphNode.props:type_name -> rpc.GraphNode.PropsEntry
+	15, // 33: rpc.WatchdogInfo.policy:type_name -> rpc.WatchdogParam
+	36, // 34: rpc.SessionStats.streams:type_name -> rpc.StreamStats
+	39, // 35: rpc.ActionResult.replies:type_name -> rpc.ActionReply
+	43, // 36: rpc.NodeTypeInfo.properties:type_name -> rpc.NodePropertyInfo
+	44, // 37: rpc.Capabilities.node_types:type_name -> rpc.NodeTypeInfo
+	45, // 38: rpc.Capabilities.message_types:type_name -> rpc.MessageTypeInfo
+	46, // 39: rpc.Capabilities.conversions:type_name -> rpc.MessageConversion
+	1,  // 40: rpc.Capabilities.codecs:type_name -> rpc.CodecType
+	49, // 41: rpc.GraphValidation.diagnostics:type_name -> rpc.GraphDiagnostic
+	7,  // 42: rpc.SystemEvent.cmd:type_name -> rpc.SystemCommand
+	10, // 43: rpc.MediaApi.GetVersion:input_type -> rpc.Empty
+	12, // 44: rpc.MediaApi.PrepareSession:input_type -> rpc.CreateParam
+	18, // 45: rpc.MediaApi.UpdateSession:input_type -> rpc.UpdateParam
+	20, // 46: rpc.MediaApi.StartSession:input_type -> rpc.StartParam
+	21, // 47: rpc.MediaApi.StopSession:input_type -> rpc.StopParam
+	38, // 48: rpc.MediaApi.ExecuteAction:input_type -> rpc.Action
+	38, // 49: rpc.MediaApi.ExecuteActionWithNotify:input_type -> rpc.Action
+	42, // 50: rpc.MediaApi.ExecuteActionWithPush:input_type -> rpc.PushData
+	52, // 51: rpc.MediaApi.SystemChannel:input_type -> rpc.SystemEvent
+	35, // 52: rpc.MediaApi.GetSessionStats:input_type -> rpc.SessionStatsParam
+	25, // 53: rpc.MediaApi.ListSessions:input_type -> rpc.ListSessionsParam
+	27, // 54: rpc.MediaApi.GetSession:input_type -> rpc.GetSessionParam
+	29, // 55: rpc.MediaApi.WatchSessions:input_type -> rpc.WatchSessionsParam
+	10, // 56: rpc.MediaApi.DescribeCapabilities:input_type -> rpc.Empty
+	48, // 57: rpc.MediaApi.ValidateGraph:input_type -> rpc.ValidateGraphParam
+	10, // 58: rpc.MediaApi.GetLoad:input_type -> rpc.Empty
+	8,  // 59: rpc.MediaApi.GetVersion:output_type -> rpc.VersionNumber
+	23, // 60: rpc.MediaApi.PrepareSession:output_type -> rpc.Session
+	22, // 61: rpc.MediaApi.UpdateSession:output_type -> rpc.Status
+	22, // 62: rpc.MediaApi.StartSession:output_type -> rpc.Status
+	22, // 63: rpc.MediaApi.StopSession:output_type -> rpc.Status
+	40, // 64: rpc.MediaApi.ExecuteAction:output_type -> rpc.ActionResult
+	41, // 65: rpc.MediaApi.ExecuteActionWithNotify:output_type -> rpc.ActionEvent
+	40, // 66: rpc.MediaApi.ExecuteActionWithPush:output_type -> rpc.ActionResult
+	52, // 67: rpc.MediaApi.SystemChannel:output_type -> rpc.SystemEvent
+	37, // 68: rpc.MediaApi.GetSessionStats:output_type -> rpc.SessionStats
+	26, // 69: rpc.MediaApi.ListSessions:output_type -> rpc.SessionList
+	28, // 70: rpc.MediaApi.GetSession:output_type -> rpc.SessionInfo
+	30, // 71: rpc.MediaApi.WatchSessions:output_type -> rpc.SessionEvent
+	47, // 72: rpc.MediaApi.DescribeCapabilities:output_type -> rpc.Capabilities
+	50, // 73: rpc.MediaApi.ValidateGraph:output_type -> rpc.GraphValidation
+	51, // 74: rpc.MediaApi.GetLoad:output_type -> rpc.LoadReport
+	59, // [59:75] is the sub-list for method output_type
+	43, // [43:59] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
+}
+
+func init() { file_msapi_proto_init() }
+func file_msapi_proto_init() {
+	if File_msapi_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_msapi_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VersionNumber); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DrainState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CodecInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LatchParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchdogParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SrtpParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JitterBufferParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StopParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Status); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Session); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSessionsParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1232,8 +4454,8 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Empty); i {
+		file_msapi_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSessionParam); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1244,8 +4466,8 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CodecInfo); i {
+		file_msapi_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1256,8 +4478,8 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateParam); i {
+		file_msapi_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchSessionsParam); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1268,8 +4490,8 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateParam); i {
+		file_msapi_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1280,8 +4502,8 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartParam); i {
+		file_msapi_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionDetail); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1292,8 +4514,8 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StopParam); i {
+		file_msapi_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphNode); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1304,8 +4526,8 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Status); i {
+		file_msapi_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LiveNode); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1316,8 +4538,8 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Session); i {
+		file_msapi_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchdogInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1328,7 +4550,43 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_msapi_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionStatsParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StreamStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Action); i {
 			case 0:
 				return &v.state
@@ -1340,7 +4598,19 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+		file_msapi_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ActionReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ActionResult); i {
 			case 0:
 				return &v.state
@@ -1352,7 +4622,7 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+		file_msapi_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ActionEvent); i {
 			case 0:
 				return &v.state
@@ -1364,7 +4634,7 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+		file_msapi_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PushData); i {
 			case 0:
 				return &v.state
@@ -1376,7 +4646,115 @@ func file_msapi_proto_init() {
 				return nil
 			}
 		}
-		file_msapi_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+		file_msapi_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodePropertyInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NodeTypeInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageTypeInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MessageConversion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Capabilities); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ValidateGraphParam); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphDiagnostic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GraphValidation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LoadReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_msapi_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SystemEvent); i {
 			case 0:
 				return &v.state
@@ -1394,8 +4772,8 @@ func file_msapi_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_msapi_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   14,
+			NumEnums:      8,
+			NumMessages:   46,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,6 +27,13 @@ type MediaApiClient interface {
 	ExecuteActionWithNotify(ctx context.Context, in *Action, opts ...grpc.CallOption) (MediaApi_ExecuteActionWithNotifyClient, error)
 	ExecuteActionWithPush(ctx context.Context, opts ...grpc.CallOption) (MediaApi_ExecuteActionWithPushClient, error)
 	SystemChannel(ctx context.Context, opts ...grpc.CallOption) (MediaApi_SystemChannelClient, error)
+	GetSessionStats(ctx context.Context, in *SessionStatsParam, opts ...grpc.CallOption) (*SessionStats, error)
+	ListSessions(ctx context.Context, in *ListSessionsParam, opts ...grpc.CallOption) (*SessionList, error)
+	GetSession(ctx context.Context, in *GetSessionParam, opts ...grpc.CallOption) (*SessionInfo, error)
+	WatchSessions(ctx context.Context, in *WatchSessionsParam, opts ...grpc.CallOption) (MediaApi_WatchSessionsClient, error)
+	DescribeCapabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Capabilities, error)
+	ValidateGraph(ctx context.Context, in *ValidateGraphParam, opts ...grpc.CallOption) (*GraphValidation, error)
+	GetLoad(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LoadReport, error)
 }
 
 type mediaApiClient struct {
@@ -188,6 +195,92 @@ func (x *mediaApiSystemChannelClient) Recv() (*SystemEvent, error) {
 	return m, nil
 }
 
+func (c *mediaApiClient) GetSessionStats(ctx context.Context, in *SessionStatsParam, opts ...grpc.CallOption) (*SessionStats, error) {
+	out := new(SessionStats)
+	err := c.cc.Invoke(ctx, "/rpc.MediaApi/GetSessionStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaApiClient) ListSessions(ctx context.Context, in *ListSessionsParam, opts ...grpc.CallOption) (*SessionList, error) {
+	out := new(SessionList)
+	err := c.cc.Invoke(ctx, "/rpc.MediaApi/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaApiClient) GetSession(ctx context.Context, in *GetSessionParam, opts ...grpc.CallOption) (*SessionInfo, error) {
+	out := new(SessionInfo)
+	err := c.cc.Invoke(ctx, "/rpc.MediaApi/GetSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaApiClient) WatchSessions(ctx context.Context, in *WatchSessionsParam, opts ...grpc.CallOption) (MediaApi_WatchSessionsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &MediaApi_ServiceDesc.Streams[3], "/rpc.MediaApi/WatchSessions", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mediaApiWatchSessionsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MediaApi_WatchSessionsClient interface {
+	Recv() (*SessionEvent, error)
+	grpc.ClientStream
+}
+
+type mediaApiWatchSessionsClient struct {
+	grpc.ClientStream
+}
+
+func (x *mediaApiWatchSessionsClient) Recv() (*SessionEvent, error) {
+	m := new(SessionEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *mediaApiClient) DescribeCapabilities(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Capabilities, error) {
+	out := new(Capabilities)
+	err := c.cc.Invoke(ctx, "/rpc.MediaApi/DescribeCapabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaApiClient) ValidateGraph(ctx context.Context, in *ValidateGraphParam, opts ...grpc.CallOption) (*GraphValidation, error) {
+	out := new(GraphValidation)
+	err := c.cc.Invoke(ctx, "/rpc.MediaApi/ValidateGraph", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaApiClient) GetLoad(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LoadReport, error) {
+	out := new(LoadReport)
+	err := c.cc.Invoke(ctx, "/rpc.MediaApi/GetLoad", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MediaApiServer is the server API for MediaApi service.
 // All implementations must embed UnimplementedMediaApiServer
 // for forward compatibility
@@ -201,6 +294,13 @@ type MediaApiServer interface {
 	ExecuteActionWithNotify(*Action, MediaApi_ExecuteActionWithNotifyServer) error
 	ExecuteActionWithPush(MediaApi_ExecuteActionWithPushServer) error
 	SystemChannel(MediaApi_SystemChannelServer) error
+	GetSessionStats(context.Context, *SessionStatsParam) (*SessionStats, error)
+	ListSessions(context.Context, *ListSessionsParam) (*SessionList, error)
+	GetSession(context.Context, *GetSessionParam) (*SessionInfo, error)
+	WatchSessions(*WatchSessionsParam, MediaApi_WatchSessionsServer) error
+	DescribeCapabilities(context.Context, *Empty) (*Capabilities, error)
+	ValidateGraph(context.Context, *ValidateGraphParam) (*GraphValidation, error)
+	GetLoad(context.Context, *Empty) (*LoadReport, error)
 	mustEmbedUnimplementedMediaApiServer()
 }
 
@@ -235,6 +335,27 @@ func (UnimplementedMediaApiServer) ExecuteActionWithPush(MediaApi_ExecuteActionW
 func (UnimplementedMediaApiServer) SystemChannel(MediaApi_SystemChannelServer) error {
 	return status.Errorf(codes.Unimplemented, "method SystemChannel not implemented")
 }
+func (UnimplementedMediaApiServer) GetSessionStats(context.Context, *SessionStatsParam) (*SessionStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessionStats not implemented")
+}
+func (UnimplementedMediaApiServer) ListSessions(context.Context, *ListSessionsParam) (*SessionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedMediaApiServer) GetSession(context.Context, *GetSessionParam) (*SessionInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
+}
+func (UnimplementedMediaApiServer) WatchSessions(*WatchSessionsParam, MediaApi_WatchSessionsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSessions not implemented")
+}
+func (UnimplementedMediaApiServer) DescribeCapabilities(context.Context, *Empty) (*Capabilities, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeCapabilities not implemented")
+}
+func (UnimplementedMediaApiServer) ValidateGraph(context.Context, *ValidateGraphParam) (*GraphValidation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateGraph not implemented")
+}
+func (UnimplementedMediaApiServer) GetLoad(context.Context, *Empty) (*LoadReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoad not implemented")
+}
 func (UnimplementedMediaApiServer) mustEmbedUnimplementedMediaApiServer() {}
 
 // UnsafeMediaApiServer may be embedded to opt out of forward compatibility for this service.
@@ -429,6 +550,135 @@ func (x *mediaApiSystemChannelServer) Recv() (*SystemEvent, error) {
 	return m, nil
 }
 
+func _MediaApi_GetSessionStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionStatsParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaApiServer).GetSessionStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.MediaApi/GetSessionStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaApiServer).GetSessionStats(ctx, req.(*SessionStatsParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaApi_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaApiServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.MediaApi/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaApiServer).ListSessions(ctx, req.(*ListSessionsParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaApi_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaApiServer).GetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.MediaApi/GetSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaApiServer).GetSession(ctx, req.(*GetSessionParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaApi_WatchSessions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSessionsParam)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MediaApiServer).WatchSessions(m, &mediaApiWatchSessionsServer{stream})
+}
+
+type MediaApi_WatchSessionsServer interface {
+	Send(*SessionEvent) error
+	grpc.ServerStream
+}
+
+type mediaApiWatchSessionsServer struct {
+	grpc.ServerStream
+}
+
+func (x *mediaApiWatchSessionsServer) Send(m *SessionEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MediaApi_DescribeCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaApiServer).DescribeCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.MediaApi/DescribeCapabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaApiServer).DescribeCapabilities(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaApi_ValidateGraph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateGraphParam)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaApiServer).ValidateGraph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.MediaApi/ValidateGraph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaApiServer).ValidateGraph(ctx, req.(*ValidateGraphParam))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaApi_GetLoad_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaApiServer).GetLoad(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/rpc.MediaApi/GetLoad",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaApiServer).GetLoad(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MediaApi_ServiceDesc is the grpc.ServiceDesc for MediaApi service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -460,6 +710,30 @@ var MediaApi_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ExecuteAction",
 			Handler:    _MediaApi_ExecuteAction_Handler,
 		},
+		{
+			MethodName: "GetSessionStats",
+			Handler:    _MediaApi_GetSessionStats_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _MediaApi_ListSessions_Handler,
+		},
+		{
+			MethodName: "GetSession",
+			Handler:    _MediaApi_GetSession_Handler,
+		},
+		{
+			MethodName: "DescribeCapabilities",
+			Handler:    _MediaApi_DescribeCapabilities_Handler,
+		},
+		{
+			MethodName: "ValidateGraph",
+			Handler:    _MediaApi_ValidateGraph_Handler,
+		},
+		{
+			MethodName: "GetLoad",
+			Handler:    _MediaApi_GetLoad_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -478,6 +752,11 @@ var MediaApi_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "WatchSessions",
+			Handler:       _MediaApi_WatchSessions_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "msapi.proto",
 }
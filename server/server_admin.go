@@ -0,0 +1,127 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/appcrash/media/server/comp/nmd"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"net/http"
+	"net/http/pprof"
+	"slices"
+	"strings"
+	"time"
+)
+
+// adminHandler serves metrics, pprof and event graph inspection, they have no authentication so listen on private
+// address only. draining stops sessions of all instances, so it is authorized like MediaApi if enabled. metrics are
+// collected by the default prometheus registry, see prom.InitCollector
+func (srv *GrpcServer) adminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.Handler())
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	// the whole event graph, or the scope given by query "scope"
+	mux.HandleFunc("GET /graph", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, srv.graph.Snapshot(r.URL.Query().Get("scope")))
+	})
+	// drain state, POST starts draining before server stops, i.e. for rolling upgrade. query "timeout"(i.e. 10m)
+	// overrides the configured one
+	mux.HandleFunc("GET /drain", func(w http.ResponseWriter, r *http.Request) {
+		writeJson(w, srv.drainState())
+	})
+	mux.HandleFunc("POST /drain", func(w http.ResponseWriter, r *http.Request) {
+		if !srv.adminAuthorize(w, r) {
+			return
+		}
+		timeout := srv.drainTimeout
+		if s := r.URL.Query().Get("timeout"); s != "" {
+			var err error
+			if timeout, err = time.ParseDuration(s); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if timeout <= 0 {
+				// draining can not be undone, never stop all sessions at once by a typo
+				http.Error(w, "drain timeout must be positive", http.StatusBadRequest)
+				return
+			}
+		}
+		srv.startDrain(timeout)
+		writeJson(w, srv.drainState())
+	})
+	mux.HandleFunc("GET /sessions/{id}/graph.json", func(w http.ResponseWriter, r *http.Request) {
+		if session := srv.adminSession(w, r); session != nil {
+			writeJson(w, srv.graph.Snapshot(session.sessionId.String()))
+		}
+	})
+	mux.HandleFunc("GET /sessions/{id}/graph.dot", func(w http.ResponseWriter, r *http.Request) {
+		if session := srv.adminSession(w, r); session != nil {
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			writeGraphDot(w, session.sessionId.String(), session.composer.GetSortedNodes())
+		}
+	})
+	return mux
+}
+
+// adminAuthorize requires caller to access all instances if authentication is enabled
+func (srv *GrpcServer) adminAuthorize(w http.ResponseWriter, r *http.Request) bool {
+	if srv.authenticator == nil {
+		return true
+	}
+	p, err := authenticateBearer(r.Context(), srv.authenticator, r.Header.Get(authorizationHeader))
+	if err != nil {
+		http.Error(w, status.Convert(err).Message(), http.StatusUnauthorized)
+		return false
+	}
+	if !slices.Contains(p.Instances, AllInstances) {
+		logger.Warnf("%v is denied to access admin endpoint %v", p.Name, r.URL.Path)
+		http.Error(w, fmt.Sprintf("%v can not access all instances", p.Name), http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func (srv *GrpcServer) adminSession(w http.ResponseWriter, r *http.Request) *RtpMediaSession {
+	session, err := srv.lookupSession(r.PathValue("id"))
+	if err != nil {
+		code := http.StatusNotFound
+		if status.Code(err) == codes.InvalidArgument {
+			code = http.StatusBadRequest
+		}
+		http.Error(w, status.Convert(err).Message(), code)
+		return nil
+	}
+	return session
+}
+
+func writeJson(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Errorf("admin write json error: %v", err)
+	}
+}
+
+// writeGraphDot renders composed topology of session in graphviz, nodes are labeled with name and type, links
+// with preferred offers
+func writeGraphDot(w io.Writer, name string, nodeDefs []*nmd.NodeDef) {
+	fmt.Fprintf(w, "digraph %q {\n", name)
+	for _, n := range nodeDefs {
+		fmt.Fprintf(w, "  %q [label=%q];\n", n.Name, n.Name+"\n"+n.Type)
+	}
+	for _, n := range nodeDefs {
+		for _, dep := range n.Deps {
+			if len(dep.PreferOffer) > 0 {
+				fmt.Fprintf(w, "  %q -> %q [label=%q];\n", n.Name, dep.LinkTo.Name, strings.Join(dep.PreferOffer, ","))
+			} else {
+				fmt.Fprintf(w, "  %q -> %q;\n", n.Name, dep.LinkTo.Name)
+			}
+		}
+	}
+	fmt.Fprintln(w, "}")
+}
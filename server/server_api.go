@@ -8,9 +8,14 @@ import (
 	"github.com/appcrash/media/server/rpc"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"math/rand/v2"
 	"net"
+	"net/http"
 	"sync"
+	"time"
 )
 
 var logger *logrus.Entry
@@ -29,11 +34,25 @@ type GrpcServer struct {
 	rtpServerIpAddr   *net.IPAddr
 	portPool          *PortPool
 	sessionListener   []SessionListener
+	events            *sessionEventHub
+	stopC             chan struct{} // closed when server stops
+	health            *health.Server
+	cpu               cpuSampler
+	limits            LimitConfig
+	watchdogConfig    WatchdogConfig
+	composeLimits     *comp.ComposeLimits
 
 	graph *event.Graph
 
-	sessionMutex sync.Mutex
-	sessionMap   map[SessionIdType]*RtpMediaSession
+	sessionMutex  sync.Mutex
+	sessionMap    map[SessionIdType]*RtpMediaSession
+	draining      bool          // guarded by sessionMutex
+	drainDeadline time.Time     // guarded by sessionMutex
+	drainDoneC    chan struct{} // closed once drained, guarded by sessionMutex
+	drainTimeout  time.Duration
+	authenticator Authenticator // nil if authentication is not enabled
+	// recovered sessions whose instances are not notified yet, guarded by sessionMutex
+	recovered map[string][]SessionIdType
 
 	simpleExecutorMap map[string]CommandExecute
 	streamExecutorMap map[string]CommandExecute
@@ -48,6 +67,30 @@ type Config struct {
 	GrpcIp           string
 	GrpcPort         uint16
 	GrpcRegisterMore RegisterMore
+
+	// Tls makes grpc endpoint listen on TLS instead of plaintext
+	Tls *TlsConfig
+	// Authenticator requires every call to carry a bearer token, and restricts callers to their own instances' sessions
+	Authenticator Authenticator
+
+	// Watchdog sets periods of session watchdog
+	Watchdog WatchdogConfig
+
+	// Limits refuses new sessions with ResourceExhausted error once reached
+	Limits LimitConfig
+
+	// AdminAddress serves metrics, pprof, graph inspection and draining over http, empty means disabled. draining
+	// requires a token of AllInstances if Authenticator is set
+	AdminAddress string
+
+	// DrainTimeout is how long draining server waits for sessions to end, then the remaining ones are stopped.
+	// draining starts when server stops, or earlier by admin endpoint. zero means DefaultDrainTimeout, negative
+	// stops sessions at once
+	DrainTimeout time.Duration
+
+	// Store persists alive sessions, they are recovered when server is created again with the same store. sessions
+	// stopped by draining are deleted from it. nil means sessions are lost after restart
+	Store SessionStore
 }
 
 type RegisterMore func(s grpc.ServiceRegistrar)
@@ -73,17 +116,36 @@ func (b *BaseSessionListener) OnSessionStopped(_ *RtpMediaSession) {}
 func NewGrpcServer(c *Config) (start StartServerFunc, stop StopServerFunc, err error) {
 	var lis net.Listener
 	var ip *net.IPAddr
+	var opts []grpc.ServerOption
 
+	if c.Tls != nil {
+		var creds credentials.TransportCredentials
+		if creds, err = c.Tls.serverCredentials(); err != nil {
+			logger.Errorf("failed to setup tls for grpc: %v", err)
+			return
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
 	if lis, err = net.Listen("tcp", fmt.Sprintf("%s:%d", c.GrpcIp, c.GrpcPort)); err != nil {
 		logger.Errorf("failed to listen to port(%v) for grpc", c.GrpcPort)
 		return
 	}
 	rtpIp, rtpStartPort, rtpEndPort := c.RtpIp, c.StartPort, c.EndPort
+	events := newSessionEventHub()
 	server := GrpcServer{
 		rtpServerIpString: rtpIp,
 		portPool:          NewPortPool(),
-		sessionListener:   c.SessionListenerList,
+		sessionListener:   append([]SessionListener{events}, c.SessionListenerList...),
+		events:            events,
+		stopC:             make(chan struct{}),
+		health:            health.NewServer(),
+		limits:            c.Limits,
+		watchdogConfig:    c.Watchdog.withDefaults(),
+		composeLimits:     c.Limits.composeLimits(),
+		drainTimeout:      c.DrainTimeout,
+		authenticator:     c.Authenticator,
 		sessionMap:        make(map[SessionIdType]*RtpMediaSession),
+		recovered:         make(map[string][]SessionIdType),
 
 		// read-only maps once executors registered
 		simpleExecutorMap: make(map[string]CommandExecute),
@@ -91,6 +153,9 @@ func NewGrpcServer(c *Config) (start StartServerFunc, stop StopServerFunc, err e
 
 		graph: event.NewEventGraph(),
 	}
+	if server.drainTimeout == 0 {
+		server.drainTimeout = DefaultDrainTimeout
+	}
 	if ip, err = net.ResolveIPAddr("ip", rtpIp); err != nil {
 		return
 	}
@@ -103,21 +168,58 @@ func NewGrpcServer(c *Config) (start StartServerFunc, stop StopServerFunc, err e
 		}
 	}
 
-	var opts []grpc.ServerOption
+	if c.Authenticator != nil {
+		opts = append(opts, grpc.ChainUnaryInterceptor(unaryAuthInterceptor(c.Authenticator)),
+			grpc.ChainStreamInterceptor(streamAuthInterceptor(c.Authenticator)))
+	}
 	grpcServer := grpc.NewServer(opts...)
+	var adminLis net.Listener
+	var adminServer *http.Server
+	if c.AdminAddress != "" {
+		if adminLis, err = net.Listen("tcp", c.AdminAddress); err != nil {
+			logger.Errorf("failed to listen to %v for admin", c.AdminAddress)
+			lis.Close()
+			return
+		}
+		adminServer = &http.Server{Handler: server.adminHandler()}
+	}
+	if c.Store != nil {
+		server.recoverSessions(c.Store)
+		// sessions are saved once recovered, records of them are still up-to-date
+		server.sessionListener = append(server.sessionListener, &sessionPersister{store: c.Store})
+	}
 	rpc.RegisterMediaApiServer(grpcServer, &server)
+	healthpb.RegisterHealthServer(grpcServer, server.health)
+	server.health.SetServingStatus(rpc.MediaApi_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
 	if c.GrpcRegisterMore != nil {
 		c.GrpcRegisterMore(grpcServer)
 	}
 
 	start = func() error {
 		logger.Infof("starting GRPC/RTP endpoint")
+		if adminServer != nil {
+			go func() {
+				if err := adminServer.Serve(adminLis); err != http.ErrServerClosed {
+					logger.Errorf("admin endpoint exits: %v", err)
+				}
+			}()
+		}
 		return grpcServer.Serve(lis)
 	}
+	var stopOnce sync.Once
 	stop = func() {
-		logger.Infof("try to gracefully stop GRPC/RTP endpoint")
-		grpcServer.GracefulStop()
-		logger.Infof("GRPC/RTP endpoint has stopped")
+		// i.e. called by both signal handler and deferred cleanup
+		stopOnce.Do(func() {
+			logger.Infof("try to gracefully stop GRPC/RTP endpoint")
+			server.drain(server.drainTimeout)
+			// end system channels, otherwise graceful stop waits for instances forever
+			close(server.stopC)
+			grpcServer.GracefulStop()
+			if adminServer != nil {
+				adminServer.Close()
+			}
+			logger.Infof("GRPC/RTP endpoint has stopped")
+		})
 	}
 	return
 }
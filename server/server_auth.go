@@ -0,0 +1,184 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+	// health checks come from load balancers that have no token
+	healthMethodPrefix = "/grpc.health.v1.Health/"
+	// AllInstances in Principal.Instances grants access to sessions of any instance
+	AllInstances = "*"
+)
+
+// TlsConfig enables TLS of grpc endpoint, and mutual TLS if ClientCaFile is set
+type TlsConfig struct {
+	CertFile string
+	KeyFile  string
+	// ClientCaFile is PEM encoded CA certificates, clients must present a certificate issued by one of them
+	ClientCaFile string
+}
+
+// Principal is the authenticated caller of MediaApi
+type Principal struct {
+	Name string
+	// Instances the caller can act as, it can only operate sessions prepared by these instances. AllInstances means
+	// any, empty means none
+	Instances []string
+}
+
+// Authenticator verifies the bearer token of "authorization" metadata sent with every call
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Principal, error)
+}
+
+// StaticTokenAuthenticator maps preconfigured tokens to principals
+type StaticTokenAuthenticator map[string]*Principal
+
+func (a StaticTokenAuthenticator) Authenticate(_ context.Context, token string) (*Principal, error) {
+	if p, ok := a[token]; ok {
+		return p, nil
+	}
+	return nil, errors.New("unknown token")
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns caller of the call, nil if authentication is not enabled
+func PrincipalFromContext(ctx context.Context) *Principal {
+	p, _ := ctx.Value(principalKey{}).(*Principal)
+	return p
+}
+
+// CanAccess tells whether the caller can act as the instance, nil principal means authentication is not enabled
+func (p *Principal) CanAccess(instanceId string) bool {
+	return p == nil || slices.Contains(p.Instances, AllInstances) || slices.Contains(p.Instances, instanceId)
+}
+
+func (c *TlsConfig) serverCredentials() (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load tls key pair error: %v", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.ClientCaFile != "" {
+		pem, err := os.ReadFile(c.ClientCaFile)
+		if err != nil {
+			return nil, fmt.Errorf("read client ca file error: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in client ca file %v", c.ClientCaFile)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return credentials.NewTLS(config), nil
+}
+
+func authenticate(ctx context.Context, a Authenticator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var authorization string
+	if values := md.Get(authorizationHeader); len(values) > 0 {
+		authorization = values[0]
+	}
+	p, err := authenticateBearer(ctx, a, authorization)
+	if err != nil {
+		return nil, err
+	}
+	return context.WithValue(ctx, principalKey{}, p), nil
+}
+
+// authenticateBearer returns principal of the bearer token in authorization, shared by grpc and admin endpoint
+func authenticateBearer(ctx context.Context, a Authenticator, authorization string) (*Principal, error) {
+	if authorization == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+	if len(authorization) < len(bearerPrefix) || !strings.EqualFold(authorization[:len(bearerPrefix)], bearerPrefix) {
+		return nil, status.Error(codes.Unauthenticated, "authorization is not a bearer token")
+	}
+	p, err := a.Authenticate(ctx, authorization[len(bearerPrefix):])
+	if err != nil {
+		return nil, rpcError(err, codes.Unauthenticated)
+	}
+	if p == nil {
+		// never let a broken authenticator disable authorization
+		return nil, status.Error(codes.Unauthenticated, "token is not bound to any principal")
+	}
+	return p, nil
+}
+
+// authStream overrides context of stream with the authenticated one
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
+
+func unaryAuthInterceptor(a Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+			return handler(ctx, req)
+		}
+		ctx, err := authenticate(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func streamAuthInterceptor(a Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, healthMethodPrefix) {
+			return handler(srv, ss)
+		}
+		ctx, err := authenticate(ss.Context(), a)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authorize checks the caller can act as the instance
+func authorize(ctx context.Context, instanceId string) error {
+	p := PrincipalFromContext(ctx)
+	if p.CanAccess(instanceId) {
+		return nil
+	}
+	logger.Warnf("%v is denied to access instance(%v)", p.Name, instanceId)
+	return status.Errorf(codes.PermissionDenied, "%v can not access instance(%v)", p.Name, instanceId)
+}
+
+// accessSession finds session that the caller is authorized to operate
+func (srv *GrpcServer) accessSession(ctx context.Context, id string) (*RtpMediaSession, error) {
+	session, err := srv.lookupSession(id)
+	if err != nil {
+		return nil, err
+	}
+	if err = authorize(ctx, session.instanceId); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
@@ -0,0 +1,209 @@
+package server_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/appcrash/media/server"
+	"github.com/appcrash/media/server/rpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const (
+	authGrpcPort     = 5679
+	authAdminAddress = "127.0.0.1:5687"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func issueCert(t *testing.T, serial int64, parent *testCert, tmpl *x509.Certificate) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl.SerialNumber = big.NewInt(serial)
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+	parentCert, parentKey := tmpl, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parentCert, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (c *testCert) writePem(t *testing.T, dir, name string) (certFile, keyFile string) {
+	keyDer, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile = filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	return
+}
+
+func (c *testCert) tlsCert() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.der}, PrivateKey: c.key}
+}
+
+func TestAuthServer(t *testing.T) {
+	dir := t.TempDir()
+	ca := issueCert(t, 1, nil, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "test ca"},
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	})
+	srvCert := issueCert(t, 2, ca, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "media server"},
+		IPAddresses: []net.IP{net.ParseIP(grpcIp)},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	cliCert := issueCert(t, 3, ca, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "media client"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	caFile, _ := ca.writePem(t, dir, "ca")
+	certFile, keyFile := srvCert.writePem(t, dir, "server")
+
+	start, stop, err := server.NewGrpcServer(&server.Config{
+		RtpIp:        "127.0.0.1",
+		StartPort:    20000,
+		EndPort:      21000,
+		GrpcIp:       grpcIp,
+		GrpcPort:     authGrpcPort,
+		Tls:          &server.TlsConfig{CertFile: certFile, KeyFile: keyFile, ClientCaFile: caFile},
+		AdminAddress: authAdminAddress,
+		Authenticator: server.StaticTokenAuthenticator{
+			"token_a":     {Name: "a", Instances: []string{"instance_a"}},
+			"token_b":     {Name: "b", Instances: []string{"instance_b"}},
+			"token_admin": {Name: "admin", Instances: []string{server.AllInstances}},
+			"token_none":  {Name: "none"},
+			"token_nil":   nil,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go start()
+	defer stop()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	dial := func(certs ...tls.Certificate) *grpc.ClientConn {
+		creds := credentials.NewTLS(&tls.Config{RootCAs: roots, Certificates: certs})
+		conn, err := grpc.NewClient(net.JoinHostPort(grpcIp, strconv.Itoa(authGrpcPort)), grpc.WithTransportCredentials(creds))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return conn
+	}
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	}
+	expectCode := func(err error, code codes.Code) {
+		t.Helper()
+		if status.Code(err) != code {
+			t.Fatalf("expect code %v but got error: %v", code, err)
+		}
+	}
+
+	// mutual tls requires client certificate
+	_, err = rpc.NewMediaApiClient(dial()).GetVersion(withToken("token_a"), &rpc.Empty{})
+	expectCode(err, codes.Unavailable)
+
+	conn := dial(cliCert.tlsCert())
+	c := rpc.NewMediaApiClient(conn)
+	if _, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("health check should not require token: %v", err)
+	}
+	_, err = c.GetVersion(context.Background(), &rpc.Empty{})
+	expectCode(err, codes.Unauthenticated)
+	_, err = c.GetVersion(withToken("bad"), &rpc.Empty{})
+	expectCode(err, codes.Unauthenticated)
+	_, err = c.GetVersion(withToken("token_nil"), &rpc.Empty{})
+	expectCode(err, codes.Unauthenticated)
+
+	param := &rpc.CreateParam{
+		PeerIp:     "127.0.0.1",
+		PeerPort:   2000,
+		Codecs:     []*rpc.CodecInfo{{PayloadNumber: 8, PayloadType: rpc.CodecType_PCM_ALAW}},
+		GraphDesc:  "[ep:echo]",
+		InstanceId: "instance_a",
+	}
+	_, err = c.PrepareSession(withToken("token_b"), param)
+	expectCode(err, codes.PermissionDenied)
+	session, err := c.PrepareSession(withToken("token_a"), param)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.StopSession(withToken("token_b"), &rpc.StopParam{SessionId: session.SessionId})
+	expectCode(err, codes.PermissionDenied)
+	_, err = c.ListSessions(withToken("token_b"), &rpc.ListSessionsParam{InstanceId: "instance_a"})
+	expectCode(err, codes.PermissionDenied)
+	// principal without instances is not an admin
+	_, err = c.StopSession(withToken("token_none"), &rpc.StopParam{SessionId: session.SessionId})
+	expectCode(err, codes.PermissionDenied)
+	if list, err := c.ListSessions(withToken("token_b"), &rpc.ListSessionsParam{}); err != nil || len(list.Sessions) != 0 {
+		t.Fatalf("instance b should see no session: %v %v", list, err)
+	}
+	if list, err := c.ListSessions(withToken("token_admin"), &rpc.ListSessionsParam{}); err != nil || len(list.Sessions) != 1 {
+		t.Fatalf("admin should see all sessions: %v %v", list, err)
+	}
+	if _, err = c.StopSession(withToken("token_a"), &rpc.StopParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+
+	// draining by admin endpoint stops sessions of all instances
+	drain := func(token, query string, code int) {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodPost, "http://"+authAdminAddress+"/drain"+query, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != code {
+			t.Fatalf("expect drain status %v but got %v", code, resp.StatusCode)
+		}
+	}
+	drain("", "", http.StatusUnauthorized)
+	drain("token_nil", "", http.StatusUnauthorized)
+	drain("token_a", "", http.StatusForbidden)
+	drain("token_admin", "?timeout=0s", http.StatusBadRequest)
+	if ver, err := c.GetVersion(withToken("token_a"), &rpc.Empty{}); err != nil || ver.Drain.GetDraining() {
+		t.Fatalf("server should not be draining: %v %v", ver, err)
+	}
+	drain("token_admin", "?timeout=1s", http.StatusOK)
+}
@@ -0,0 +1,81 @@
+package server
+
+import (
+	"github.com/appcrash/media/server/prom"
+	"github.com/appcrash/media/server/rpc"
+	"time"
+)
+
+const drainCheckPeriod = 100 * time.Millisecond
+
+// DefaultDrainTimeout is used if Config.DrainTimeout is zero
+const DefaultDrainTimeout = 30 * time.Second
+
+func (srv *GrpcServer) isDraining() bool {
+	srv.sessionMutex.Lock()
+	defer srv.sessionMutex.Unlock()
+	return srv.draining
+}
+
+func (srv *GrpcServer) drainState() *rpc.DrainState {
+	srv.sessionMutex.Lock()
+	defer srv.sessionMutex.Unlock()
+	if !srv.draining {
+		return &rpc.DrainState{}
+	}
+	return &rpc.DrainState{
+		Draining:          true,
+		RemainingSessions: int32(len(srv.sessionMap)),
+		Deadline:          srv.drainDeadline.UnixMilli(),
+	}
+}
+
+// drain refuses new sessions and waits for existing ones to end until timeout, then the remaining sessions are
+// stopped with DRAIN_TIMEOUT reason. it waits for the ongoing one if already draining
+func (srv *GrpcServer) drain(timeout time.Duration) {
+	<-srv.startDrain(timeout)
+}
+
+// startDrain begins draining in background, the returned channel is closed once all sessions are drained
+func (srv *GrpcServer) startDrain(timeout time.Duration) <-chan struct{} {
+	srv.sessionMutex.Lock()
+	defer srv.sessionMutex.Unlock()
+	if srv.draining {
+		return srv.drainDoneC
+	}
+	srv.draining = true
+	srv.drainDeadline = time.Now().Add(timeout)
+	srv.drainDoneC = make(chan struct{})
+	prom.GrpcDraining.Set(1)
+	// load balancers stop choosing this server
+	srv.health.Shutdown()
+	go srv.drainSessions(len(srv.sessionMap), timeout, srv.drainDoneC)
+	return srv.drainDoneC
+}
+
+func (srv *GrpcServer) drainSessions(remaining int, timeout time.Duration, doneC chan struct{}) {
+	defer close(doneC)
+	logger.Infof("start draining %v sessions in %v", remaining, timeout)
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(drainCheckPeriod)
+	defer ticker.Stop()
+	for remaining > 0 {
+		select {
+		case <-ticker.C:
+			if n := int(srv.drainState().RemainingSessions); n != remaining {
+				remaining = n
+				logger.Infof("draining, %v sessions remain", remaining)
+			}
+			continue
+		case <-timer.C:
+		}
+		sessions := srv.listSessions(nil, "", nil)
+		logger.Warnf("drain timeout, stop the remaining %v sessions", len(sessions))
+		for _, session := range sessions {
+			session.terminate(rpc.StopReason_DRAIN_TIMEOUT)
+		}
+		break
+	}
+	logger.Infof("all sessions are drained")
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"fmt"
+	"github.com/appcrash/media/server/comp"
+	"github.com/appcrash/media/server/rpc"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"strings"
+)
+
+// errors returned by MediaApi carry grpc status code and error details, so clients can tell failures apart
+// without matching message strings
+
+const (
+	resourceTypeSession   = "session"
+	resourceTypeCommand   = "command"
+	resourceTypeRtpPort   = "rtp_port"
+	preconditionStatus    = "SESSION_STATUS"
+	errorDomainMediaApi   = "media.appcrash.github.com"
+	errorReasonFallBehind = "WATCHER_FALLS_BEHIND"
+	errorReasonEventsLost = "SESSION_EVENTS_LOST"
+	errorReasonDraining   = "SERVER_DRAINING"
+)
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	if ds, err := st.WithDetails(details...); err == nil {
+		return ds.Err()
+	}
+	return st.Err()
+}
+
+func errInvalidArgument(field string, format string, args ...interface{}) error {
+	desc := fmt.Sprintf(format, args...)
+	return withDetails(status.New(codes.InvalidArgument, desc), &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: field, Description: desc}},
+	})
+}
+
+func errInvalidSessionId(id string) error {
+	return errInvalidArgument("session_id", "invalid session id: %v", id)
+}
+
+func errNotFound(resourceType, name string) error {
+	return withDetails(status.Newf(codes.NotFound, "%v(%v) not exist", resourceType, name), &errdetails.ResourceInfo{
+		ResourceType: resourceType,
+		ResourceName: name,
+	})
+}
+
+func errSessionNotFound(id string) error {
+	return errNotFound(resourceTypeSession, id)
+}
+
+func errCommandNotFound(cmd string) error {
+	return errNotFound(resourceTypeCommand, cmd)
+}
+
+// errSessionStatus means the operation is not allowed at current status of session
+func errSessionStatus(sessionId SessionIdType, sessionStatus int, op string) error {
+	st := sessionStatusToRpc(sessionStatus).String()
+	return withDetails(status.Newf(codes.FailedPrecondition, "can not %v session(%v) at status %v", op, sessionId, st),
+		&errdetails.PreconditionFailure{
+			Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        preconditionStatus,
+				Subject:     sessionId.String(),
+				Description: st,
+			}},
+		})
+}
+
+func errFailedPrecondition(sessionId SessionIdType, format string, args ...interface{}) error {
+	desc := fmt.Sprintf(format, args...)
+	return withDetails(status.New(codes.FailedPrecondition, desc), &errdetails.PreconditionFailure{
+		Violations: []*errdetails.PreconditionFailure_Violation{{
+			Type:        resourceTypeSession,
+			Subject:     sessionId.String(),
+			Description: desc,
+		}},
+	})
+}
+
+func errPortExhausted() error {
+	desc := "grpc server runs out of port resource"
+	return withDetails(status.New(codes.ResourceExhausted, desc), &errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{{Subject: resourceTypeRtpPort, Description: desc}},
+	})
+}
+
+func errLimitExceeded(resource string, limit int) error {
+	desc := fmt.Sprintf("%v exceed the limit %v", resource, limit)
+	return withDetails(status.New(codes.ResourceExhausted, desc), &errdetails.QuotaFailure{
+		Violations: []*errdetails.QuotaFailure_Violation{{Subject: resource, Description: desc}},
+	})
+}
+
+func errWatcherFallsBehind(lastSeq uint64) error {
+	return withDetails(status.New(codes.Aborted, "session watcher falls behind, resume with the last sequence number"),
+		&errdetails.ErrorInfo{
+			Reason:   errorReasonFallBehind,
+			Domain:   errorDomainMediaApi,
+			Metadata: map[string]string{"last_seq": fmt.Sprint(lastSeq)},
+		})
+}
+
+// errWatchOutOfRange means events after afterSeq are dropped from buffer, client should resync by ListSessions
+func errWatchOutOfRange(afterSeq, oldestSeq uint64) error {
+	return withDetails(status.Newf(codes.OutOfRange, "events after seq %v are lost, the oldest one is %v",
+		afterSeq, oldestSeq),
+		&errdetails.ErrorInfo{
+			Reason:   errorReasonEventsLost,
+			Domain:   errorDomainMediaApi,
+			Metadata: map[string]string{"oldest_seq": fmt.Sprint(oldestSeq)},
+		})
+}
+
+// errDraining tells client to retry on the other servers
+func errDraining() error {
+	return withDetails(status.New(codes.Unavailable, "server is draining, no new session is accepted"),
+		&errdetails.ErrorInfo{Reason: errorReasonDraining, Domain: errorDomainMediaApi})
+}
+
+// rpcError keeps status of err if it has one, otherwise wraps it with code
+func rpcError(err error, code codes.Code) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	return status.Error(code, err.Error())
+}
+
+// actionResultOf converts replies of command executor to structured result, replies of successive calls are
+// separated by a single "\n". no reply at all means the action has nothing to call, it is ok
+func actionResultOf(sessionId string, replies []string) *rpc.ActionResult {
+	result := &rpc.ActionResult{SessionId: sessionId, State: strings.Join(replies, " "), Ok: true}
+	if len(replies) == 0 {
+		return result
+	}
+	start := 0
+	for i := 0; i <= len(replies); i++ {
+		if i < len(replies) && replies[i] != "\n" {
+			continue
+		}
+		ok, args := comp.ParseReply(replies[start:i])
+		result.Replies = append(result.Replies, &rpc.ActionReply{Ok: ok, Args: args})
+		result.Ok = result.Ok && ok
+		start = i + 1
+	}
+	return result
+}
@@ -1,12 +1,16 @@
 package server
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"github.com/appcrash/media/server/channel"
+	"github.com/appcrash/media/server/comp"
 	"github.com/appcrash/media/server/prom"
 	"github.com/appcrash/media/server/rpc"
+	"google.golang.org/grpc/codes"
 	"net"
+	"slices"
+	"sort"
 )
 
 func (srv *GrpcServer) init(ip *net.IPAddr, portStart, portEnd uint16) {
@@ -15,18 +19,56 @@ func (srv *GrpcServer) init(ip *net.IPAddr, portStart, portEnd uint16) {
 	channel.GetSystemChannel().AddListener(srv)
 }
 
-func (srv *GrpcServer) addToSessionMap(session *RtpMediaSession) {
+// addToSessionMap fails if server is draining or limits are reached, then the session should be discarded
+func (srv *GrpcServer) addToSessionMap(session *RtpMediaSession) error {
 	srv.sessionMutex.Lock()
 	defer srv.sessionMutex.Unlock()
+	if err := srv.admit(session.instanceId); err != nil {
+		return err
+	}
 	srv.sessionMap[session.sessionId] = session
 	prom.RtpCreatedSession.Inc()
+	return nil
 }
 
-func (srv *GrpcServer) removeFromSessionMap(session *RtpMediaSession) {
+// lookupSession finds session by id string
+func (srv *GrpcServer) lookupSession(id string) (*RtpMediaSession, error) {
+	sessionId, err := SessionIdFromString(id)
+	if err != nil {
+		return nil, errInvalidSessionId(id)
+	}
 	srv.sessionMutex.Lock()
-	defer srv.sessionMutex.Unlock()
-	delete(srv.sessionMap, session.sessionId)
-	prom.RtpCreatedSession.Dec()
+	session, exist := srv.sessionMap[sessionId]
+	srv.sessionMutex.Unlock()
+	if !exist {
+		return nil, errSessionNotFound(id)
+	}
+	return session, nil
+}
+
+// listSessions returns sessions of instance and status ordered by id, empty instanceId or status matches all.
+// sessions the principal can not access are excluded
+func (srv *GrpcServer) listSessions(p *Principal, instanceId string, status []rpc.SessionStatus) (sessions []*RtpMediaSession) {
+	var candidates []*RtpMediaSession
+	srv.sessionMutex.Lock()
+	for _, session := range srv.sessionMap {
+		if instanceId != "" && session.instanceId != instanceId || !p.CanAccess(session.instanceId) {
+			continue
+		}
+		candidates = append(candidates, session)
+	}
+	srv.sessionMutex.Unlock()
+	// status is guarded by session mutex, check it without holding server's
+	for _, session := range candidates {
+		if len(status) > 0 && !slices.Contains(status, sessionStatusToRpc(session.GetStatus())) {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].sessionId < sessions[j].sessionId
+	})
+	return
 }
 
 func (srv *GrpcServer) getNextAvailableRtpPort() uint16 {
@@ -39,6 +81,36 @@ func (srv *GrpcServer) reclaimRtpPort(port uint16) {
 	}
 }
 
+// releaseSession removes stopped session from map and puts its local ports back to pool, returns false if it is
+// already released
+func (srv *GrpcServer) releaseSession(session *RtpMediaSession) bool {
+	srv.sessionMutex.Lock()
+	exist := srv.sessionMap[session.sessionId] == session
+	if exist {
+		delete(srv.sessionMap, session.sessionId)
+		prom.RtpCreatedSession.Dec()
+	}
+	srv.sessionMutex.Unlock()
+	if !exist {
+		return false
+	}
+	for _, ms := range session.streams {
+		srv.reclaimRtpPort(ms.localPort)
+	}
+	return true
+}
+
+// onSessionStatus handles status changed by session itself, such as stopped by watchdog or peer
+func (srv *GrpcServer) onSessionStatus(session *RtpMediaSession, status int) {
+	if status == sessionStatusStopped {
+		if !srv.releaseSession(session) {
+			return
+		}
+		session.notifyInstanceOfStop()
+	}
+	srv.invokeSessionListener(session, status)
+}
+
 func (srv *GrpcServer) invokeSessionListener(session *RtpMediaSession, status int) {
 	switch status {
 	case sessionStatusCreated:
@@ -60,159 +132,239 @@ func (srv *GrpcServer) invokeSessionListener(session *RtpMediaSession, status in
 	}
 }
 
+// streamParamsOf returns media streams of create param, legacy peer_port and codecs define the only stream if no
+// streams are given
+func streamParamsOf(param *rpc.CreateParam) []*rpc.StreamParam {
+	if len(param.GetStreams()) > 0 {
+		return param.GetStreams()
+	}
+	return []*rpc.StreamParam{{
+		PeerPort:  param.GetPeerPort(),
+		Codecs:    param.GetCodecs(),
+		Direction: param.GetDirection(),
+	}}
+}
+
 func (srv *GrpcServer) createSession(param *rpc.CreateParam) (session *RtpMediaSession, err error) {
-	var localIp, remoteIp *net.IPAddr
-	var localPort, remotePort uint16
+	var localPorts []uint16
 	defer func() {
 		if err != nil {
-			if localPort > 0 {
-				// if create session failed, avoid port leaking
-				srv.reclaimRtpPort(localPort)
-			}
-			if session != nil {
-				session.Stop()
+			// if create session failed, avoid port leaking
+			for _, port := range localPorts {
+				srv.reclaimRtpPort(port)
 			}
 		}
 	}()
 
 	logger.Infof("create rtp session param: %v", param)
-
-	if localPort = srv.getNextAvailableRtpPort(); localPort == 0 {
-		err = errors.New("grpc server runs out of port resource")
+	if err = srv.checkAdmission(param.GetInstanceId()); err != nil {
 		return
 	}
-	if param.GetPeerPort()&0xffff0000 != 0 {
-		// not an uint16 port number
-		err = fmt.Errorf("invalid peer port: %v", param.GetPeerPort())
-		return
+
+	streamParams := streamParamsOf(param)
+	for _, sp := range streamParams {
+		if len(sp.GetCodecs()) == 0 {
+			err = errInvalidArgument("codecs", "create session without any codec info")
+			return
+		}
 	}
-	remotePort = uint16(param.GetPeerPort())
+	for range streamParams {
+		var port uint16
+		if port = srv.getNextAvailableRtpPort(); port == 0 {
+			prom.GrpcSessionRejected.WithLabelValues(resourceTypeRtpPort).Inc()
+			err = errPortExhausted()
+			return
+		}
+		localPorts = append(localPorts, port)
+	}
+	return srv.setupSession(nextSessionId(), localPorts, param)
+}
+
+// setupSession makes session of id listen on local ports and adds it to server, the session is stopped if anything
+// fails but ports are left to caller
+func (srv *GrpcServer) setupSession(sid SessionIdType, localPorts []uint16, param *rpc.CreateParam) (
+	session *RtpMediaSession, err error) {
+	var remoteIp *net.IPAddr
+	defer func() {
+		if err != nil && session != nil {
+			session.Stop()
+		}
+	}()
+
 	if remoteIp, err = net.ResolveIPAddr("ip", param.GetPeerIp()); err != nil {
+		err = errInvalidArgument("peer_ip", "invalid peer ip address: %v", param.GetPeerIp())
 		return
 	}
-	codecInfos := param.GetCodecs()
-	if len(codecInfos) == 0 {
-		err = errors.New("create session without any codec info")
+	if session, err = newRtpMediaSession(sid, srv.rtpServerIpAddr, remoteIp, localPorts, streamParamsOf(param),
+		param.GetInstanceId(), param.GetGraphDesc(), srv.graph); err != nil {
 		return
 	}
-	localIp = srv.rtpServerIpAddr
-	gd := param.GetGraphDesc()
-
-	if session, err = NewRtpMediaSession(localIp, remoteIp, localPort, remotePort, codecInfos, gd, srv.graph); err != nil {
+	session.notifyDtmf = param.GetNotifyDtmf()
+	if err = session.setupJitterBuffer(param.GetJitterBuffer()); err != nil {
 		return
 	}
+	if err = session.setupSrtp(param.GetSrtp()); err != nil {
+		return
+	}
+	session.setupLatch(param.GetLatch())
+	session.statusListener = srv.onSessionStatus
+	session.composer.SetLimits(srv.composeLimits)
+	session.watchdog.setup(srv.watchdogConfig, param.GetWatchdog())
+	session.record = newSessionRecord(session, localPorts, param)
 
 	// connect source/sink into event graph of this session
 	// then listen on udp messages
 	if err = session.activate(); err != nil {
+		err = rpcError(composeError(err), codes.Internal)
+		return
+	}
+	if err = srv.addToSessionMap(session); err != nil {
 		return
 	}
 	prom.RtpAllSession.Inc()
-	srv.addToSessionMap(session)
 	srv.invokeSessionListener(session, sessionStatusCreated)
 	return session, nil
 }
 
-func (srv *GrpcServer) updateSession(param *rpc.UpdateParam) (err error) {
-	var sessionId SessionIdType
-	if sessionId, err = SessionIdFromString(param.GetSessionId()); err != nil {
-		err = errors.New("invalid session id")
+func (srv *GrpcServer) updateSession(ctx context.Context, param *rpc.UpdateParam) (err error) {
+	var session *RtpMediaSession
+	if session, err = srv.accessSession(ctx, param.GetSessionId()); err != nil {
 		return
 	}
+	return srv.applyUpdate(session, param)
+}
+
+func (srv *GrpcServer) applyUpdate(session *RtpMediaSession, param *rpc.UpdateParam) (err error) {
+	sessionId := session.sessionId
 	var remoteIp *net.IPAddr
 	var err1 error
-	if remoteIp, err1 = net.ResolveIPAddr("ip", param.GetPeerIp()); err1 != nil {
-		return fmt.Errorf("update with invalid peer ip address: %v", param.GetPeerIp())
+	if param.GetPeerIp() != "" {
+		if remoteIp, err1 = net.ResolveIPAddr("ip", param.GetPeerIp()); err1 != nil {
+			return errInvalidArgument("peer_ip", "update with invalid peer ip address: %v", param.GetPeerIp())
+		}
 	}
 	if param.GetPeerPort()&0xffff0000 != 0 {
 		// not a uint16 port number
-		return fmt.Errorf("invalid peer port: %v", param.GetPeerPort())
+		return errInvalidArgument("peer_port", "invalid peer port: %v", param.GetPeerPort())
 	}
-
-	srv.sessionMutex.Lock()
-	session, exist := srv.sessionMap[sessionId]
-	srv.sessionMutex.Unlock()
-	if exist {
-		if session.status != sessionStatusCreated {
-			// if session already started or stopped, no updating is done
-			err = fmt.Errorf("try to update already started/stopped session(%v)", sessionId)
-			return
+	for _, su := range param.GetStreams() {
+		if su.GetPeerPort()&0xffff0000 != 0 {
+			return errInvalidArgument("streams.peer_port", "invalid peer port of stream(%v): %v",
+				su.GetName(), su.GetPeerPort())
+		}
+		if session.getStream(su.GetName()) == nil {
+			return errInvalidArgument("streams.name", "update session(%v) with unknown stream(%v)",
+				sessionId, su.GetName())
+		}
+		if _, ok := rpc.MediaDirection_name[int32(su.GetDirection())]; !ok {
+			return errInvalidArgument("streams.direction", "invalid media direction of stream(%v): %v",
+				su.GetName(), su.GetDirection())
 		}
-		logger.Infof("update session(%v) with param:%v", sessionId, param)
-		session.remoteIp = remoteIp
-		session.remotePort = uint16(param.GetPeerPort())
+	}
+	if _, ok := rpc.MediaDirection_name[int32(param.GetDirection())]; !ok {
+		return errInvalidArgument("direction", "invalid media direction: %v", param.GetDirection())
+	}
 
-		//update rtp params when necessary
-		pt := param.GetPayloadNumber()
-		if pt > 0 { // ignore pt==0(PCMU) static payload type/default value
-			_pt := uint8(pt)
-			if _pt != session.avPayloadNumber { //whether update
-				logger.Infof("update payload number from previous=%v,to current=%v", session.avPayloadNumber, pt)
-				session.avPayloadNumber = _pt
-				//session.UpdateRtpParams()
-			}
+	logger.Infof("update session(%v) with param:%v", sessionId, param)
+	if err = rpcError(session.updatePeer(param, remoteIp), codes.InvalidArgument); err != nil {
+		return
+	}
 
+	//update rtp params when necessary
+	switchCodec := func(field, name string, pt int32) error {
+		if pt <= 0 { // ignore pt==0(PCMU) static payload type/default value
+			return nil
 		}
-
-		srv.invokeSessionListener(session, sessionStatusUpdated)
-	} else {
-		err = errors.New("session not exist")
+		if pt > 127 {
+			return errInvalidArgument(field, "invalid payload number: %v", pt)
+		}
+		return rpcError(session.UpdateRtpParams(name, uint8(pt)), codes.InvalidArgument)
+	}
+	if err = switchCodec("payload_number", "", param.GetPayloadNumber()); err != nil {
+		return
 	}
+	for _, su := range param.GetStreams() {
+		if err = switchCodec("streams.payload_number", su.GetName(), su.GetPayloadNumber()); err != nil {
+			return
+		}
+	}
+
+	session.updateDirection(param)
+	session.recordUpdate(param)
+	srv.invokeSessionListener(session, sessionStatusUpdated)
 	return
 }
 
-func (srv *GrpcServer) startSession(param *rpc.StartParam) (err error) {
-	var sessionId SessionIdType
-	if sessionId, err = SessionIdFromString(param.GetSessionId()); err != nil {
-		err = errors.New("invalid session id")
+func (srv *GrpcServer) startSession(ctx context.Context, param *rpc.StartParam) (err error) {
+	var session *RtpMediaSession
+	if session, err = srv.accessSession(ctx, param.GetSessionId()); err != nil {
 		return
 	}
-	logger.Infof("rpc: start session %v", sessionId)
-	srv.sessionMutex.Lock()
-	session, exist := srv.sessionMap[sessionId]
-	srv.sessionMutex.Unlock()
-	if exist {
-		err = session.Start()
-	} else {
-		err = fmt.Errorf("session:%v not exist", sessionId)
-	}
-	if err == nil {
-		srv.invokeSessionListener(session, sessionStatusStarted)
-	} else {
-		if exist {
-			// session exists but start failed, reclaim resource
-			srv.sessionMutex.Lock()
-			delete(srv.sessionMap, sessionId)
-			srv.sessionMutex.Unlock()
-			srv.reclaimRtpPort(session.localPort)
+	logger.Infof("rpc: start session %v", session.sessionId)
+	if err = session.Start(); err != nil {
+		err = rpcError(err, codes.Internal)
+		// start failed and stopped the session, reclaim resource. a session at wrong status is left intact
+		if session.GetStatus() == sessionStatusStopped && srv.releaseSession(session) {
 			srv.invokeSessionListener(session, sessionStatusStopped)
 		}
+		return
 	}
+	srv.invokeSessionListener(session, sessionStatusStarted)
 	return
 }
 
-func (srv *GrpcServer) stopSession(param *rpc.StopParam) (err error) {
-	var sessionId SessionIdType
-	if sessionId, err = SessionIdFromString(param.GetSessionId()); err != nil {
-		err = errors.New("invalid session id")
+func (srv *GrpcServer) stopSession(ctx context.Context, param *rpc.StopParam) (err error) {
+	var session *RtpMediaSession
+	if session, err = srv.accessSession(ctx, param.GetSessionId()); err != nil {
 		return
 	}
-	logger.Infof("rpc: stop session %v", sessionId)
-	srv.sessionMutex.Lock()
-	session, exist := srv.sessionMap[sessionId]
-	srv.sessionMutex.Unlock()
-	if exist {
-		session.Stop()
-		srv.sessionMutex.Lock()
-		delete(srv.sessionMap, sessionId)
-		srv.sessionMutex.Unlock()
+	logger.Infof("rpc: stop session %v", session.sessionId)
+	session.Stop()
+	if srv.releaseSession(session) {
 		srv.invokeSessionListener(session, sessionStatusStopped)
-	} else {
-		err = errors.New("session not exist")
 	}
 	return
 }
 
+// describeCapabilities reports registered node types and message types for clients composing graphs
+func describeCapabilities() *rpc.Capabilities {
+	c := &rpc.Capabilities{Codecs: supportedCodecs}
+	messageName := func(mt comp.MessageType) string {
+		if trait, ok := comp.MessageTraitOfType(mt); ok {
+			return trait.Name()
+		}
+		return fmt.Sprintf("unknown(%v)", int(mt))
+	}
+	messageNames := func(types []comp.MessageType) (names []string) {
+		for _, mt := range types {
+			names = append(names, messageName(mt))
+		}
+		return
+	}
+	comp.VisitNodeTrait(func(trait *comp.NodeTrait) {
+		accept, offer := trait.MessageTypes()
+		info := &rpc.NodeTypeInfo{
+			Name:   trait.NodeType,
+			Accept: messageNames(accept),
+			Offer:  messageNames(offer),
+		}
+		for _, p := range trait.Properties() {
+			info.Properties = append(info.Properties, &rpc.NodePropertyInfo{Name: p.Name, Type: p.Type})
+		}
+		c.NodeTypes = append(c.NodeTypes, info)
+	})
+	sort.Slice(c.NodeTypes, func(i, j int) bool {
+		return c.NodeTypes[i].Name < c.NodeTypes[j].Name
+	})
+	comp.VisitMessageTrait(func(trait *comp.MessageTrait) {
+		c.MessageTypes = append(c.MessageTypes, &rpc.MessageTypeInfo{Name: trait.Name(), TypeId: int32(trait.TypeId)})
+	})
+	comp.VisitMessageConversion(func(from, to comp.MessageType) {
+		c.Conversions = append(c.Conversions, &rpc.MessageConversion{From: messageName(from), To: messageName(to)})
+	})
+	return c
+}
+
 // APIs that allow plugging in method to:
 // 1. handle command(take new actions), listen to state change
 // 2. pull data from media server
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"errors"
+	"github.com/appcrash/media/server/comp"
+	"github.com/appcrash/media/server/prom"
+)
+
+const (
+	limitSessions         = "sessions"
+	limitInstanceSessions = "instance_sessions"
+)
+
+// LimitConfig restricts resources sessions can take, zero value means unlimited
+type LimitConfig struct {
+	MaxSessions            int
+	MaxSessionsPerInstance int
+	MaxGraphNodes          int // nodes of one session graph
+	MaxGraphLinks          int // links of all session graphs
+	MaxTranscodeContexts   int // transcode contexts held by nodes of all sessions, see comp.TranscodeNode
+}
+
+func (c *LimitConfig) composeLimits() *comp.ComposeLimits {
+	limits := &comp.ComposeLimits{MaxNodes: c.MaxGraphNodes}
+	if c.MaxGraphLinks > 0 {
+		limits.Links = comp.NewBudget(c.MaxGraphLinks)
+	}
+	if c.MaxTranscodeContexts > 0 {
+		limits.Transcode = comp.NewBudget(c.MaxTranscodeContexts)
+	}
+	return limits
+}
+
+func rejectSession(resource string, limit int) error {
+	prom.GrpcSessionRejected.WithLabelValues(resource).Inc()
+	logger.Warnf("reject session as %v reach the limit %v", resource, limit)
+	return errLimitExceeded(resource, limit)
+}
+
+// admit checks a new session of the instance can be accepted, must be called with sessionMutex held
+func (srv *GrpcServer) admit(instanceId string) error {
+	if srv.draining {
+		return errDraining()
+	}
+	if limit := srv.limits.MaxSessions; limit > 0 && len(srv.sessionMap) >= limit {
+		return rejectSession(limitSessions, limit)
+	}
+	if limit := srv.limits.MaxSessionsPerInstance; limit > 0 {
+		n := 0
+		for _, session := range srv.sessionMap {
+			if session.instanceId == instanceId {
+				n++
+			}
+		}
+		if n >= limit {
+			return rejectSession(limitInstanceSessions, limit)
+		}
+	}
+	return nil
+}
+
+// checkAdmission refuses session early before any resource is allocated for it
+func (srv *GrpcServer) checkAdmission(instanceId string) error {
+	srv.sessionMutex.Lock()
+	defer srv.sessionMutex.Unlock()
+	return srv.admit(instanceId)
+}
+
+// composeError converts error of composing graph to the one returned to client
+func composeError(err error) error {
+	var le *comp.LimitError
+	if errors.As(err, &le) {
+		prom.GrpcSessionRejected.WithLabelValues(le.Resource).Inc()
+		return errLimitExceeded(le.Resource, le.Limit)
+	}
+	return err
+}
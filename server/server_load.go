@@ -0,0 +1,66 @@
+package server
+
+import (
+	"github.com/appcrash/media/server/rpc"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// cpuSampler computes cpu usage of the process between successive samples
+type cpuSampler struct {
+	mutex     sync.Mutex
+	lastWall  time.Time
+	lastCpu   time.Duration
+	lastUsage float64
+}
+
+// minCpuSamplePeriod avoids noisy usage when load is reported too frequently
+const minCpuSamplePeriod = time.Second
+
+func processCpuTime() time.Duration {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0
+	}
+	return time.Duration(ru.Utime.Nano() + ru.Stime.Nano())
+}
+
+func (c *cpuSampler) usage() float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	now, cpu := time.Now(), processCpuTime()
+	if c.lastWall.IsZero() {
+		c.lastWall, c.lastCpu = now, cpu
+		return 0
+	}
+	wall := now.Sub(c.lastWall)
+	if wall < minCpuSamplePeriod {
+		return c.lastUsage
+	}
+	usage := float64(cpu-c.lastCpu) / float64(wall) / float64(runtime.NumCPU())
+	c.lastWall, c.lastCpu, c.lastUsage = now, cpu, min(usage, 1)
+	return c.lastUsage
+}
+
+func (srv *GrpcServer) loadReport() *rpc.LoadReport {
+	free, total := srv.portPool.Free()
+	sessions := srv.listSessions(nil, "", nil)
+	report := &rpc.LoadReport{
+		FreePorts:  int32(free),
+		TotalPorts: int32(total),
+		Sessions:   int32(len(sessions)),
+		GraphNodes: int32(srv.graph.NodeCount()),
+		GraphLinks: int32(srv.graph.LinkCount()),
+		CpuUsage:   srv.cpu.usage(),
+		NumCpu:     int32(runtime.NumCPU()),
+		Draining:   srv.isDraining(),
+	}
+	for _, session := range sessions {
+		if session.GetStatus() == sessionStatusStarted {
+			report.StartedSessions++
+		}
+	}
+	return report
+}
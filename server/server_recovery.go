@@ -0,0 +1,119 @@
+package server
+
+import (
+	"fmt"
+	"github.com/appcrash/media/server/channel"
+	"github.com/appcrash/media/server/prom"
+	"github.com/appcrash/media/server/rpc"
+)
+
+// recoverSessions makes sessions of store again before server serves, their instances are notified when
+// registering. records can not be recovered are deleted
+func (srv *GrpcServer) recoverSessions(store SessionStore) {
+	records, err := store.Load()
+	if err != nil {
+		logger.Errorf("load sessions from %v error: %v", store, err)
+		return
+	}
+	var nbRecovered int
+	for _, r := range records {
+		session, err := srv.recoverSession(r)
+		if err != nil {
+			logger.Errorf("recover session(%v) of instance(%v) failed: %v", r.SessionId, r.InstanceId, err)
+			prom.RtpRecoveredSession.WithLabelValues("failed").Inc()
+			if err = store.Delete(r.SessionId); err != nil {
+				logger.Errorf("delete session(%v) from store error: %v", r.SessionId, err)
+			}
+			continue
+		}
+		nbRecovered++
+		prom.RtpRecoveredSession.WithLabelValues("recovered").Inc()
+		srv.sessionMutex.Lock()
+		srv.recovered[session.instanceId] = append(srv.recovered[session.instanceId], session.sessionId)
+		srv.sessionMutex.Unlock()
+	}
+	logger.Infof("recovered %v of %v sessions from %v", nbRecovered, len(records), store)
+}
+
+// recoverSession replays creation, updates and start of the record
+func (srv *GrpcServer) recoverSession(r *SessionRecord) (session *RtpMediaSession, err error) {
+	var sid SessionIdType
+	var localPorts []uint16
+	if sid, err = SessionIdFromString(r.SessionId); err != nil {
+		return nil, fmt.Errorf("invalid session id: %v", err)
+	}
+	defer func() {
+		for _, port := range localPorts {
+			srv.reclaimRtpPort(port)
+		}
+	}()
+	for _, port := range r.LocalPorts {
+		if !srv.portPool.Reserve(port) {
+			return nil, fmt.Errorf("port %v is out of range or taken", port)
+		}
+		localPorts = append(localPorts, port)
+	}
+	if session, err = srv.setupSession(sid, localPorts, r.Create); err != nil {
+		return nil, err
+	}
+	// ports are released along with session from now on
+	localPorts = nil
+
+	replay := func(afterStart bool) error {
+		for _, u := range r.Updates {
+			if u.AfterStart != afterStart {
+				continue
+			}
+			if err := srv.applyUpdate(session, u.Param); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err = replay(false); err == nil && r.Started {
+		if err = session.Start(); err == nil {
+			srv.invokeSessionListener(session, sessionStatusStarted)
+			err = replay(true)
+		}
+	}
+	if err != nil {
+		session.Stop()
+		if srv.releaseSession(session) {
+			srv.invokeSessionListener(session, sessionStatusStopped)
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+// notifyRecovered tells registered instance its recovered sessions which are still alive, the ones failed to
+// notify are kept for next registration
+func (srv *GrpcServer) notifyRecovered(instanceId string) {
+	var sessions []*RtpMediaSession
+	srv.sessionMutex.Lock()
+	for _, id := range srv.recovered[instanceId] {
+		if session, exist := srv.sessionMap[id]; exist {
+			sessions = append(sessions, session)
+		}
+	}
+	delete(srv.recovered, instanceId)
+	srv.sessionMutex.Unlock()
+
+	sc := channel.GetSystemChannel()
+	for i, session := range sessions {
+		if err := sc.NotifyInstance(&rpc.SystemEvent{
+			Cmd:        rpc.SystemCommand_SESSION_RECOVERED,
+			InstanceId: instanceId,
+			SessionId:  session.sessionId.String(),
+			Event:      fmt.Sprintf("status=%v", sessionStatusToRpc(session.GetStatus())),
+		}); err != nil {
+			logger.Warnf("notify instance(%v) of %v recovered sessions error: %v", instanceId, len(sessions)-i, err)
+			srv.sessionMutex.Lock()
+			for _, s := range sessions[i:] {
+				srv.recovered[instanceId] = append(srv.recovered[instanceId], s.sessionId)
+			}
+			srv.sessionMutex.Unlock()
+			return
+		}
+	}
+}
@@ -2,179 +2,248 @@ package server
 
 import (
 	"context"
-	"errors"
-	"fmt"
 	"github.com/appcrash/media/server/channel"
+	"github.com/appcrash/media/server/comp"
 	"github.com/appcrash/media/server/prom"
 	"github.com/appcrash/media/server/rpc"
 	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"io"
 	"runtime/debug"
-	"strings"
-	"sync"
 	"time"
 )
 
 func (srv *GrpcServer) GetVersion(_ context.Context, _ *rpc.Empty) (*rpc.VersionNumber, error) {
-	return &rpc.VersionNumber{Ver: rpc.Version_DEFAULT}, nil
+	return &rpc.VersionNumber{Ver: rpc.Version_DEFAULT, Drain: srv.drainState()}, nil
 }
 
-func (srv *GrpcServer) PrepareSession(_ context.Context, param *rpc.CreateParam) (*rpc.Session, error) {
+func (srv *GrpcServer) PrepareSession(ctx context.Context, param *rpc.CreateParam) (*rpc.Session, error) {
 	var session *RtpMediaSession
 	var err error
+	if err = authorize(ctx, param.GetInstanceId()); err != nil {
+		return nil, err
+	}
 	if session, err = srv.createSession(param); err != nil {
 		logger.Errorf("fail to prepare session with error:%v", err)
-		return nil, err
+		return nil, rpcError(err, codes.InvalidArgument)
 	}
 
 	logger.Infof("rpc: prepared session %v", session.sessionId)
 	rpcSession := rpc.Session{}
 	rpcSession.SessionId = session.sessionId.String()
 	rpcSession.PeerIp = param.GetPeerIp()
-	rpcSession.PeerRtpPort = param.GetPeerPort()
-	rpcSession.LocalRtpPort = uint32(session.localPort)
+	rpcSession.Streams = session.streamInfos(false)
+	rpcSession.PeerRtpPort = rpcSession.Streams[0].PeerRtpPort
+	rpcSession.LocalRtpPort = rpcSession.Streams[0].LocalRtpPort
 	rpcSession.LocalIp = session.localIp.String()
+	rpcSession.SrtpLocalKey = session.GetSrtpLocalKey()
 
 	return &rpcSession, nil
 }
 
-func (srv *GrpcServer) UpdateSession(_ context.Context, param *rpc.UpdateParam) (*rpc.Status, error) {
+func (srv *GrpcServer) UpdateSession(ctx context.Context, param *rpc.UpdateParam) (*rpc.Status, error) {
 	// only remote (ip, port) can be updated
-	err := srv.updateSession(param)
-	return &rpc.Status{Status: "ok"}, err
+	if err := srv.updateSession(ctx, param); err != nil {
+		return nil, err
+	}
+	return &rpc.Status{Status: "ok"}, nil
 }
 
-func (srv *GrpcServer) StartSession(_ context.Context, param *rpc.StartParam) (*rpc.Status, error) {
-	err := srv.startSession(param)
-	return &rpc.Status{Status: "ok"}, err
+func (srv *GrpcServer) StartSession(ctx context.Context, param *rpc.StartParam) (*rpc.Status, error) {
+	if err := srv.startSession(ctx, param); err != nil {
+		return nil, err
+	}
+	return &rpc.Status{Status: "ok"}, nil
 }
 
-func (srv *GrpcServer) StopSession(_ context.Context, param *rpc.StopParam) (*rpc.Status, error) {
-	if err := srv.stopSession(param); err != nil {
+func (srv *GrpcServer) StopSession(ctx context.Context, param *rpc.StopParam) (*rpc.Status, error) {
+	if err := srv.stopSession(ctx, param); err != nil {
 		return nil, err
 	} else {
 		return &rpc.Status{Status: "ok"}, nil
 	}
 }
 
-func (srv *GrpcServer) ExecuteAction(_ context.Context, action *rpc.Action) (*rpc.ActionResult, error) {
-	var sessionId SessionIdType
-	var err error
-	if sessionId, err = SessionIdFromString(action.SessionId); err != nil {
-		err = errors.New("invalid session id")
+func (srv *GrpcServer) GetSessionStats(ctx context.Context, param *rpc.SessionStatsParam) (*rpc.SessionStats, error) {
+	session, err := srv.accessSession(ctx, param.GetSessionId())
+	if err != nil {
 		return nil, err
 	}
-	result := rpc.ActionResult{
-		SessionId: action.SessionId,
+	return session.GetStats(), nil
+}
+
+func (srv *GrpcServer) ListSessions(ctx context.Context, param *rpc.ListSessionsParam) (*rpc.SessionList, error) {
+	if id := param.GetInstanceId(); id != "" {
+		if err := authorize(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	list := &rpc.SessionList{}
+	p := PrincipalFromContext(ctx)
+	for _, session := range srv.listSessions(p, param.GetInstanceId(), param.GetStatus()) {
+		list.Sessions = append(list.Sessions, session.GetInfo(false))
+	}
+	return list, nil
+}
+
+func (srv *GrpcServer) GetSession(ctx context.Context, param *rpc.GetSessionParam) (*rpc.SessionInfo, error) {
+	session, err := srv.accessSession(ctx, param.GetSessionId())
+	if err != nil {
+		return nil, err
+	}
+	return session.GetInfo(true), nil
+}
+
+func (srv *GrpcServer) WatchSessions(param *rpc.WatchSessionsParam, stream rpc.MediaApi_WatchSessionsServer) error {
+	p := PrincipalFromContext(stream.Context())
+	if id := param.GetInstanceId(); id != "" {
+		if err := authorize(stream.Context(), id); err != nil {
+			return err
+		}
+	}
+	w, replay, err := srv.events.watch(p, param.GetInstanceId(), param.GetAfterSeq())
+	if err != nil {
+		return err
+	}
+	defer srv.events.unwatch(w)
+	for _, e := range replay {
+		if err := stream.Send(e); err != nil {
+			return err
+		}
+	}
+	var lastSeq uint64
+	if len(replay) > 0 {
+		lastSeq = replay[len(replay)-1].Seq
+	}
+	done := stream.Context().Done()
+	for {
+		select {
+		case e, more := <-w.C:
+			if !more {
+				return errWatcherFallsBehind(lastSeq)
+			}
+			if err := stream.Send(e); err != nil {
+				return err
+			}
+			lastSeq = e.Seq
+		case <-done:
+			return nil
+		}
+	}
+}
+
+func (srv *GrpcServer) DescribeCapabilities(_ context.Context, _ *rpc.Empty) (*rpc.Capabilities, error) {
+	return describeCapabilities(), nil
+}
+
+func (srv *GrpcServer) GetLoad(_ context.Context, _ *rpc.Empty) (*rpc.LoadReport, error) {
+	return srv.loadReport(), nil
+}
+
+func (srv *GrpcServer) ValidateGraph(_ context.Context, param *rpc.ValidateGraphParam) (*rpc.GraphValidation, error) {
+	result := &rpc.GraphValidation{}
+	for _, d := range comp.ValidateGraph(param.GetGraphDesc()) {
+		result.Diagnostics = append(result.Diagnostics, &rpc.GraphDiagnostic{
+			Line:    int32(d.Line),
+			Column:  int32(d.Column),
+			Message: d.Message,
+		})
+	}
+	result.Ok = len(result.Diagnostics) == 0
+	return result, nil
+}
+
+func (srv *GrpcServer) ExecuteAction(ctx context.Context, action *rpc.Action) (result *rpc.ActionResult, err error) {
+	var session *RtpMediaSession
+	if session, err = srv.accessSession(ctx, action.GetSessionId()); err != nil {
+		return
+	}
+	cmd := action.GetCmd()
+	exec, exist := srv.simpleExecutorMap[cmd]
+	if !exist {
+		return nil, errCommandNotFound(cmd)
 	}
-	srv.sessionMutex.Lock()
-	session, ok := srv.sessionMap[sessionId]
-	srv.sessionMutex.Unlock()
 
 	defer func() {
 		if r := recover(); r != nil {
 			debug.PrintStack()
 			logger.Errorln("ExecuteAction panic(recovered)")
+			result, err = nil, status.Errorf(codes.Internal, "execute action %v panic: %v", cmd, r)
 		}
 	}()
 
-	if ok {
-		cmd := action.GetCmd()
-		arg := action.GetCmdArg()
-		exec, exist := srv.simpleExecutorMap[cmd]
-		if exist {
-			re, err1 := exec.Execute(session, cmd, arg)
-			prom.GrpcSessionAction.With(prometheus.Labels{"cmd": cmd, "type": "simple"}).Inc()
-			result.State = strings.Join(re, " ")
-			return &result, err1
-		}
+	re, err := exec.Execute(session, cmd, action.GetCmdArg())
+	prom.GrpcSessionAction.With(prometheus.Labels{"cmd": cmd, "type": "simple"}).Inc()
+	if err != nil {
+		return nil, rpcError(err, codes.InvalidArgument)
 	}
-	result.State = "error execute not exist"
-	return &result, nil
+	return actionResultOf(action.GetSessionId(), re), nil
 }
 
-func (srv *GrpcServer) ExecuteActionWithNotify(action *rpc.Action, stream rpc.MediaApi_ExecuteActionWithNotifyServer) error {
-	var sessionId SessionIdType
-	var err error
-	if sessionId, err = SessionIdFromString(action.SessionId); err != nil {
-		err = errors.New("invalid session id")
-		return err
+func (srv *GrpcServer) ExecuteActionWithNotify(action *rpc.Action, stream rpc.MediaApi_ExecuteActionWithNotifyServer) (err error) {
+	var session *RtpMediaSession
+	if session, err = srv.accessSession(stream.Context(), action.GetSessionId()); err != nil {
+		return
+	}
+	cmd := action.GetCmd()
+	exec, exist := srv.streamExecutorMap[cmd]
+	if !exist {
+		return errCommandNotFound(cmd)
 	}
-	srv.sessionMutex.Lock()
-	session, ok := srv.sessionMap[sessionId]
-	srv.sessionMutex.Unlock()
 	defer func() {
 		if r := recover(); r != nil {
 			debug.PrintStack()
 			logger.Errorln("ExecuteActionWithNotify panic(recovered)")
+			err = status.Errorf(codes.Internal, "execute action %v panic: %v", cmd, r)
 		}
 	}()
 
-	if ok {
-		cmd := action.GetCmd()
-		arg := action.GetCmdArg()
-		exec, exist := srv.streamExecutorMap[cmd]
-		if exist {
-			ctx, cancel := context.WithCancel(context.Background())
-			ctrlOut := make(ExecuteCtrlChan, 32)
-			defer func() {
-				prom.GrpcSessionAction.With(prometheus.Labels{"cmd": cmd, "type": "pull_stream"}).Inc()
-				// notify executor loop to exit
-				cancel()
-			}()
-			go exec.ExecuteWithNotify(session, arg, ctx, ctrlOut)
+	ctx, cancel := context.WithCancel(context.Background())
+	ctrlOut := make(ExecuteCtrlChan, 32)
+	defer func() {
+		prom.GrpcSessionAction.With(prometheus.Labels{"cmd": cmd, "type": "pull_stream"}).Inc()
+		// notify executor loop to exit
+		cancel()
+	}()
+	go exec.ExecuteWithNotify(session, action.GetCmdArg(), ctx, ctrlOut)
 
-		outLoop:
-			for {
-				select {
-				case msg, more := <-ctrlOut:
-					event := rpc.ActionEvent{
-						SessionId: action.SessionId,
-						Event:     msg,
-					}
-					if !more {
-						// executor loop already exits by itself, break without notification
-						break outLoop
-					}
-					if err := stream.Send(&event); err != nil {
-						logger.Errorf("send action event of stream(%v) with event %v error", session, event.String())
-						break outLoop
-					}
-				}
-			}
+	for {
+		msg, more := <-ctrlOut
+		if !more {
+			// executor loop already exits by itself, break without notification
+			return nil
+		}
+		event := rpc.ActionEvent{
+			SessionId: action.SessionId,
+			Event:     msg,
+		}
+		if err := stream.Send(&event); err != nil {
+			logger.Errorf("send action event of stream(%v) with event %v error", session, event.String())
+			return nil
 		}
-
-		return nil
 	}
-
-	return errors.New("cmd not exist")
 }
 
 func (srv *GrpcServer) ExecuteActionWithPush(stream rpc.MediaApi_ExecuteActionWithPushServer) error {
 	var sessionId SessionIdType
+	var cmd string
 	var dataIn chan *rpc.PushData
 
 	// receive the first data to retrieve the session id
 	if data, err := stream.Recv(); err != nil {
 		return err
 	} else {
-		sidStr := data.GetSessionId()
-		if sidStr == "" {
-			return errors.New("push action with empty session id")
-		}
-		if sessionId, err = SessionIdFromString(sidStr); err != nil {
-			return errors.New("push action with invalid session id")
-		}
-		srv.sessionMutex.Lock()
-		session, ok := srv.sessionMap[sessionId]
-		srv.sessionMutex.Unlock()
-		if !ok {
-			return fmt.Errorf("push action with session(%v) that is not exist", sessionId)
+		session, err := srv.accessSession(stream.Context(), data.GetSessionId())
+		if err != nil {
+			return err
 		}
-		exec := srv.streamExecutorMap[data.Cmd]
+		sessionId = session.sessionId
+		cmd = data.Cmd
+		exec := srv.streamExecutorMap[cmd]
 		if exec == nil {
 			logger.Errorf("no push executor cmd: %v registered", data.Cmd)
-			return fmt.Errorf("push cmd %v not found", data.Cmd)
+			return errCommandNotFound(data.Cmd)
 		}
 		dataIn = make(chan *rpc.PushData, 32)
 		go exec.ExecuteWithPush(session, dataIn)
@@ -183,7 +252,7 @@ func (srv *GrpcServer) ExecuteActionWithPush(stream rpc.MediaApi_ExecuteActionWi
 	}
 
 	defer func() {
-		prom.GrpcSessionAction.With(prometheus.Labels{"type": "push_stream"}).Inc()
+		prom.GrpcSessionAction.With(prometheus.Labels{"cmd": cmd, "type": "push_stream"}).Inc()
 		// let push loop stop
 		if dataIn != nil {
 			close(dataIn)
@@ -193,10 +262,7 @@ func (srv *GrpcServer) ExecuteActionWithPush(stream rpc.MediaApi_ExecuteActionWi
 	for {
 		data, err := stream.Recv()
 		if err == io.EOF {
-			return stream.SendAndClose(&rpc.ActionResult{
-				SessionId: sessionId.String(),
-				State:     "ok",
-			})
+			return stream.SendAndClose(actionResultOf(sessionId.String(), comp.WithOk()))
 		}
 		if err != nil {
 			return err
@@ -210,10 +276,8 @@ func (srv *GrpcServer) ExecuteActionWithPush(stream rpc.MediaApi_ExecuteActionWi
 
 // SystemChannel is long-keepalive connection to ease bidirectional system-level message exchange
 func (srv *GrpcServer) SystemChannel(stream rpc.MediaApi_SystemChannelServer) error {
-	wg := &sync.WaitGroup{}
 	var instanceId string
 	var errorLogged bool
-	var fromC, toC chan *rpc.SystemEvent
 
 	// only work after REGISTER is seen
 	for {
@@ -225,10 +289,13 @@ func (srv *GrpcServer) SystemChannel(stream rpc.MediaApi_SystemChannelServer) er
 		if in.Cmd == rpc.SystemCommand_REGISTER {
 			instanceId = in.InstanceId
 			if instanceId == "" {
-				err = fmt.Errorf("system channel got null instance id when registering")
+				err = errInvalidArgument("instance_id", "system channel got null instance id when registering")
 				logger.Error(err)
 				return err
 			}
+			if err = authorize(stream.Context(), instanceId); err != nil {
+				return err
+			}
 			break
 		} else {
 			if !errorLogged {
@@ -241,62 +308,48 @@ func (srv *GrpcServer) SystemChannel(stream rpc.MediaApi_SystemChannelServer) er
 	logger.Infof("instance (%v) enters system channel rpc", instanceId)
 	// the client has registered itself
 	sc := channel.GetSystemChannel()
-	if is, err := sc.RegisterInstance(instanceId); err != nil {
+	is, err := sc.RegisterInstance(instanceId)
+	if err != nil {
 		return err
-	} else {
-		fromC, toC = is.FromInstanceC, is.ToInstanceC
 	}
 	logger.Infof("instance:%v has registered system channel", instanceId)
-	wg.Add(2)
+	go srv.notifyRecovered(instanceId)
 
-	// the receive&send goroutines are independent, one exit wouldn't end the other one
-	// only network exception or instance state close would end them
+	// the receive loop forwards events until client closes sending or instance state is closed, the rpc ends with
+	// send loop when instance state is closed(timeout or re-registered) or server stops
 	now := time.Now().Format("2006-01-02 15:04:05")
 	go func() {
-		defer func() {
-			wg.Done()
-			logger.Infof("instance:%v at %v system channel rpc, exit recv loop", instanceId, now)
-		}()
-
+		defer logger.Infof("instance:%v at %v system channel rpc, exit recv loop", instanceId, now)
 		for {
 			in, err := stream.Recv()
 			if err != nil {
 				break
 			}
-			_, ok := <-fromC
-			if !ok {
+			if !sc.ReceiveFromInstance(is, in) {
 				// Channel is closed
 				break
 			}
-			select {
-			case fromC <- in:
-			default:
-			}
-
 		}
 	}()
 
-	go func() {
-		defer func() {
-			wg.Done()
-			logger.Infof("instance:%v at %v system channel rpc, exit send loop", instanceId, now)
-		}()
-
-		for {
-			select {
-			case msg, more := <-toC:
-				if !more {
-					return
-				}
-				if err := stream.Send(msg); err != nil {
-					logger.Errorf("instance:%v system channel rpc, send message error: %v", instanceId, err)
-					return
-				}
+	toC := sc.ToInstance(is)
+	if toC == nil {
+		logger.Infof("instance:%v at %v has exited system channel rpc normally", instanceId, now)
+		return nil
+	}
+	for {
+		select {
+		case msg, more := <-toC:
+			if !more {
+				logger.Infof("instance:%v at %v has exited system channel rpc normally", instanceId, now)
+				return nil
 			}
+			if err := stream.Send(msg); err != nil {
+				logger.Errorf("instance:%v system channel rpc, send message error: %v", instanceId, err)
+				return err
+			}
+		case <-srv.stopC:
+			return status.Error(codes.Unavailable, "server is stopping")
 		}
-	}()
-
-	wg.Wait()
-	logger.Infof("instance:%v at %v has exited system channel rpc normally", instanceId, now)
-	return nil
+	}
 }
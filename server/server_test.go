@@ -2,26 +2,36 @@ package server_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/appcrash/GoRTP/rtp"
 	"github.com/appcrash/media/server"
 	"github.com/appcrash/media/server/channel"
 	"github.com/appcrash/media/server/comp"
+	"github.com/appcrash/media/server/event"
 	"github.com/appcrash/media/server/rpc"
 	"github.com/appcrash/media/server/utils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
 	"io"
 	"log"
 	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 )
 
 const (
-	grpcIp   = "127.0.0.1"
-	grpcPort = 5678
+	grpcIp       = "127.0.0.1"
+	grpcPort     = 5678
+	adminAddress = "127.0.0.1:5682"
 )
 
 type echo struct {
@@ -29,6 +39,11 @@ type echo struct {
 	comp.ChannelNode
 
 	channel chan *utils.RtpPacketList
+	stream  string
+}
+
+func (n *echo) MediaStream() string {
+	return n.stream
 }
 
 func (n *echo) PullPacketChannel() <-chan *utils.RtpPacketList {
@@ -43,6 +58,32 @@ func (n *echo) OnCast(_ string, args []string) {
 	n.NotifyInstance(strings.Join(args, "#"))
 }
 
+// directionProbe forwards media direction messages of graph to directionProbeC
+type directionProbe struct {
+	comp.SessionNode
+}
+
+var directionProbeC = make(chan *comp.MediaDirectionMessage, 8)
+
+func (n *directionProbe) Accept() []comp.MessageType {
+	return []comp.MessageType{comp.MtMediaDirection}
+}
+
+func (n *directionProbe) handleMediaDirectionEvent(evt *event.Event) {
+	if msg, ok := comp.EventToMessage[*comp.MediaDirectionMessage](evt); ok {
+		directionProbeC <- msg
+	}
+}
+
+// transcoder holds a transcode context charged to limits
+type transcoder struct {
+	comp.SessionNode
+}
+
+func (n *transcoder) TranscodeContexts() int {
+	return 1
+}
+
 type recvFunc func(event *rpc.SystemEvent)
 
 type client struct {
@@ -162,7 +203,10 @@ func mockSendRtp(localIpStr string, localPort int, remoteIpStr string, remotePor
 				packet.SetPayloadType(8)
 				packet.SetPayload(samples[:])
 				session.WriteData(packet)
+				pts += 160
 			case <-ctx.Done():
+				ticker.Stop()
+				session.CloseSession() // say bye to peer
 				return
 			}
 		}
@@ -170,13 +214,30 @@ func mockSendRtp(localIpStr string, localPort int, remoteIpStr string, remotePor
 	return cancel, nil
 }
 
+// updateListener records updated sessions
+type updateListener struct {
+	server.BaseSessionListener
+	updatedC chan *server.RtpMediaSession
+}
+
+func (l *updateListener) OnSessionUpdated(s *server.RtpMediaSession) {
+	select {
+	case l.updatedC <- s:
+	default:
+	}
+}
+
+var sessionUpdated = &updateListener{updatedC: make(chan *server.RtpMediaSession, 8)}
+
 func startServer() {
 	config := &server.Config{
-		RtpIp:     "127.0.0.1",
-		StartPort: 10000,
-		EndPort:   20000,
-		GrpcIp:    grpcIp,
-		GrpcPort:  grpcPort,
+		RtpIp:               "127.0.0.1",
+		StartPort:           10000,
+		EndPort:             20000,
+		GrpcIp:              grpcIp,
+		GrpcPort:            grpcPort,
+		SessionListenerList: []server.SessionListener{sessionUpdated},
+		AdminAddress:        adminAddress,
 	}
 	if start, _, err := server.NewGrpcServer(config); err != nil {
 		panic(err)
@@ -192,6 +253,19 @@ func initComposer() {
 		n.Trait, _ = comp.NodeTraitOfType("echo")
 		return n
 	}))
+	comp.RegisterNodeTrait(comp.NT[directionProbe]("direction_probe", func() comp.SessionAware {
+		n := &directionProbe{}
+		n.Self = n
+		n.Trait, _ = comp.NodeTraitOfType("direction_probe")
+		n.SetMessageHandler(comp.MtMediaDirection, comp.ChainSetHandler(n.handleMediaDirectionEvent))
+		return n
+	}))
+	comp.RegisterNodeTrait(comp.NT[transcoder]("transcoder", func() comp.SessionAware {
+		n := &transcoder{}
+		n.Self = n
+		n.Trait, _ = comp.NodeTraitOfType("transcoder")
+		return n
+	}))
 }
 
 func TestMain(m *testing.M) {
@@ -279,6 +353,17 @@ func TestOperateSession(t *testing.T) {
 		panic(err)
 	}
 	time.Sleep(server.SessionAuditPeriod)
+	stats, err := c.mediaClient.GetSessionStats(ctx, &rpc.SessionStatsParam{SessionId: session.SessionId}, opts...)
+	if err != nil {
+		panic(err)
+	}
+	if len(stats.Streams) == 0 || stats.Streams[0].Outbound || stats.Streams[0].ReceivedPackets == 0 {
+		t.Fatalf("inbound stream should be measured: %v", stats)
+	}
+	t.Logf("session stats: %v", stats)
+	if stats.Mos < 4 {
+		t.Fatalf("mos of lossless local stream: %v", stats.Mos)
+	}
 	if _, err = c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId}, opts...); err != nil {
 		panic(err)
 	}
@@ -286,3 +371,1163 @@ func TestOperateSession(t *testing.T) {
 	cancelRtp()
 	time.Sleep(1 * time.Second)
 }
+
+func TestSrtpSession(t *testing.T) {
+	instanceId := "srtp_session"
+	c := &client{instanceId: instanceId}
+	c.connect(func(event *rpc.SystemEvent) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.keepalive(ctx)
+	create := func(srtpParam *rpc.SrtpParam) (*rpc.Session, error) {
+		return c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+			PeerIp:   "127.0.0.1",
+			PeerPort: 2000,
+			Codecs: []*rpc.CodecInfo{{
+				PayloadNumber: 8,
+				PayloadType:   rpc.CodecType_PCM_ALAW,
+			}},
+			GraphDesc:  "[echo]",
+			InstanceId: instanceId,
+			Srtp:       srtpParam,
+		})
+	}
+	if _, err := create(&rpc.SrtpParam{Profile: rpc.SrtpProfile_AES_CM_128_HMAC_SHA1_80, LocalKey: "abcd"}); err == nil {
+		t.Fatal("invalid key should be rejected")
+	}
+	a, err := create(&rpc.SrtpParam{Profile: rpc.SrtpProfile_AES_CM_128_HMAC_SHA1_80})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.SrtpLocalKey == "" {
+		t.Fatal("local key should be generated")
+	}
+	b, err := create(&rpc.SrtpParam{Profile: rpc.SrtpProfile_AES_CM_128_HMAC_SHA1_80,
+		RemoteKey: "inline:" + a.SrtpLocalKey + "|2^20"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: a.SessionId}); err == nil {
+		t.Fatal("session should not start without remote key")
+	}
+	if _, err = c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: b.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: b.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLatchSession(t *testing.T) {
+	instanceId := "latch_session"
+	c := &client{instanceId: instanceId}
+	c.connect(func(event *rpc.SystemEvent) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.keepalive(ctx)
+	session, err := c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+		PeerIp:   "127.0.0.1",
+		PeerPort: 2000,
+		Codecs: []*rpc.CodecInfo{{
+			PayloadNumber: 8,
+			PayloadType:   rpc.CodecType_PCM_ALAW,
+		}},
+		GraphDesc:  "[echo]",
+		InstanceId: instanceId,
+		Latch:      &rpc.LatchParam{Window: 2000},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	// peer sends from an address other than the one in signalling
+	cancelRtp, err := mockSendRtp("127.0.0.1", 3100, session.LocalIp, int(session.LocalRtpPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancelRtp()
+	timeout := time.After(time.Second)
+	for latched := false; !latched; {
+		select {
+		case s := <-sessionUpdated.updatedC:
+			if s.GetSessionId().String() != session.SessionId {
+				continue
+			}
+			if ip, port := s.GetRemoteAddress(); port != 3100 || ip.String() != "127.0.0.1" {
+				t.Fatalf("latched to wrong address %v:%v", ip, port)
+			}
+			latched = true
+		case <-timeout:
+			t.Fatal("session should latch to actual address of peer")
+		}
+	}
+	if _, err = c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMultiStreamSession(t *testing.T) {
+	instanceId := "multi_stream_session"
+	c := &client{instanceId: instanceId}
+	c.connect(func(event *rpc.SystemEvent) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.keepalive(ctx)
+	audio := &rpc.StreamParam{PeerPort: 2000, Codecs: []*rpc.CodecInfo{{
+		PayloadNumber: 8,
+		PayloadType:   rpc.CodecType_PCM_ALAW,
+	}}}
+	video := &rpc.StreamParam{PeerPort: 2002, Codecs: []*rpc.CodecInfo{{
+		PayloadNumber: 96,
+		PayloadType:   rpc.CodecType_H264,
+	}}}
+	create := func(gd string, streams ...*rpc.StreamParam) (*rpc.Session, error) {
+		return c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+			PeerIp:     "127.0.0.1",
+			GraphDesc:  gd,
+			InstanceId: instanceId,
+			Streams:    streams,
+		})
+	}
+	if _, err := create("[a:echo];[b:echo stream=audio]", audio, audio); err == nil {
+		t.Fatal("duplicated stream names should be rejected")
+	}
+	if _, err := create("[a:echo];[v:echo stream=screen]", audio, video); err == nil {
+		t.Fatal("node binding to unknown stream should be rejected")
+	}
+	if _, err := create("[a:echo]", audio, video); err == nil {
+		t.Fatal("stream without provider and consumer should be rejected")
+	}
+	session, err := create("[a:echo];[v:echo stream=video]", audio, video)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(session.Streams) != 2 || session.Streams[0].Name != "audio" || session.Streams[1].Name != "video" ||
+		session.Streams[0].LocalRtpPort == session.Streams[1].LocalRtpPort ||
+		session.LocalRtpPort != session.Streams[0].LocalRtpPort {
+		t.Fatalf("wrong streams: %v", session)
+	}
+	if _, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId, PeerIp: "127.0.0.1",
+		PeerPort: 3200, Streams: []*rpc.StreamUpdate{{Name: "screen", PeerPort: 3202}}}); err == nil {
+		t.Fatal("unknown stream should not be updated")
+	}
+	if _, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId, PeerIp: "127.0.0.1",
+		PeerPort: 3200, Streams: []*rpc.StreamUpdate{{Name: "video", PeerPort: 3202}}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	cancelRtp, err := mockSendRtp("127.0.0.1", 3200, session.LocalIp, int(session.Streams[0].LocalRtpPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancelRtp()
+	time.Sleep(time.Second)
+	stats, err := c.mediaClient.GetSessionStats(ctx, &rpc.SessionStatsParam{SessionId: session.SessionId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats.Streams) == 0 || stats.Streams[0].Stream != "audio" || stats.Streams[0].ReceivedPackets == 0 {
+		t.Fatalf("audio stream should be measured: %v", stats)
+	}
+	if _, err = c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitUpdated(t *testing.T, sessionId string) *server.RtpMediaSession {
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case s := <-sessionUpdated.updatedC:
+			if s.GetSessionId().String() == sessionId {
+				return s
+			}
+		case <-timeout:
+			t.Fatalf("session(%v) should be updated", sessionId)
+		}
+	}
+}
+
+func TestSwitchCodec(t *testing.T) {
+	instanceId := "switch_codec_session"
+	c := &client{instanceId: instanceId}
+	c.connect(func(event *rpc.SystemEvent) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.keepalive(ctx)
+	create := func(codecs ...*rpc.CodecInfo) (*rpc.Session, error) {
+		return c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+			PeerIp:     "127.0.0.1",
+			PeerPort:   2000,
+			Codecs:     codecs,
+			GraphDesc:  "[echo]",
+			InstanceId: instanceId,
+		})
+	}
+	pcma := &rpc.CodecInfo{PayloadNumber: 8, PayloadType: rpc.CodecType_PCM_ALAW}
+	amr := &rpc.CodecInfo{PayloadNumber: 96, PayloadType: rpc.CodecType_AMRNB}
+	h264 := &rpc.CodecInfo{PayloadNumber: 97, PayloadType: rpc.CodecType_H264}
+	if _, err := create(pcma, h264); err == nil {
+		t.Fatal("audio and video codecs should not be mixed in a stream")
+	}
+	if _, err := create(pcma, &rpc.CodecInfo{PayloadNumber: 8, PayloadType: rpc.CodecType_AMRNB}); err == nil {
+		t.Fatal("duplicated payload number should be rejected")
+	}
+
+	// legacy clients renumber codec before starting
+	legacy, err := create(amr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: legacy.SessionId,
+		PeerIp: "127.0.0.1", PeerPort: 2000, PayloadNumber: 98}); err != nil {
+		t.Fatal(err)
+	}
+	if s := waitUpdated(t, legacy.SessionId); s.GetAVPayloadType() != 98 || s.GetAVCodecType() != rpc.CodecType_AMRNB {
+		t.Fatalf("payload number should be updated: %v", s.GetAVPayloadType())
+	}
+	c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: legacy.SessionId})
+
+	session, err := create(pcma, amr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.Streams[0].PayloadNumber != 8 {
+		t.Fatalf("the first codec should be used for sending: %v", session)
+	}
+	if _, err = c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId,
+		Streams: []*rpc.StreamUpdate{{Name: "audio", PayloadNumber: 96}}}); err != nil {
+		t.Fatal(err)
+	}
+	s := waitUpdated(t, session.SessionId)
+	if s.GetAVPayloadType() != 96 || s.GetAVCodecType() != rpc.CodecType_AMRNB || len(s.GetCodecs("")) != 2 {
+		t.Fatalf("codec should be switched: %v %v", s.GetAVPayloadType(), s.GetAVCodecType())
+	}
+	if _, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId,
+		PayloadNumber: 100}); err == nil {
+		t.Fatal("started session should not switch to codec not negotiated")
+	}
+	if _, err = c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestListSessions(t *testing.T) {
+	instanceId := "list_session"
+	c := &client{instanceId: instanceId}
+	c.connect(func(event *rpc.SystemEvent) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.keepalive(ctx)
+	var ids []string
+	for i := 0; i < 2; i++ {
+		session, err := c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+			PeerIp:   "127.0.0.1",
+			PeerPort: 2000,
+			Codecs: []*rpc.CodecInfo{{
+				PayloadNumber: 8,
+				PayloadType:   rpc.CodecType_PCM_ALAW,
+			}, {
+				PayloadNumber: 101,
+				PayloadType:   rpc.CodecType_TELEPHONE_EVENT_8K,
+			}},
+			GraphDesc:  "[ep:echo]",
+			InstanceId: instanceId,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId})
+		ids = append(ids, session.SessionId)
+	}
+	if _, err := c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: ids[1]}); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := c.mediaClient.ListSessions(ctx, &rpc.ListSessionsParam{InstanceId: instanceId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Sessions) != 2 || list.Sessions[0].SessionId != ids[0] || list.Sessions[1].SessionId != ids[1] ||
+		list.Sessions[0].Detail != nil {
+		t.Fatalf("wrong sessions of instance: %v", list)
+	}
+	list, err = c.mediaClient.ListSessions(ctx, &rpc.ListSessionsParam{InstanceId: instanceId,
+		Status: []rpc.SessionStatus{rpc.SessionStatus_SESSION_STARTED}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Sessions) != 1 || list.Sessions[0].SessionId != ids[1] {
+		t.Fatalf("wrong started sessions: %v", list)
+	}
+
+	if _, err = c.mediaClient.GetSession(ctx, &rpc.GetSessionParam{SessionId: "0"}); err == nil {
+		t.Fatal("non-existent session should not be found")
+	}
+	info, err := c.mediaClient.GetSession(ctx, &rpc.GetSessionParam{SessionId: ids[1]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Status != rpc.SessionStatus_SESSION_STARTED || info.InstanceId != instanceId || info.CreateTime == 0 {
+		t.Fatalf("wrong session info: %v", info)
+	}
+	if len(info.Streams) != 1 || info.Streams[0].PeerIp != "127.0.0.1" || len(info.Streams[0].Codecs) != 2 {
+		t.Fatalf("wrong streams: %v", info.Streams)
+	}
+	detail := info.Detail
+	if detail == nil || detail.GraphDesc != "[ep:echo]" || len(detail.Graph) != 1 || detail.Graph[0].Type != "echo" {
+		t.Fatalf("wrong graph: %v", detail)
+	}
+	if len(detail.Nodes) != 1 || detail.Nodes[0].Name != "ep" || detail.Nodes[0].Stream != "audio" ||
+		detail.Watchdog == nil {
+		t.Fatalf("wrong live nodes: %v", detail)
+	}
+}
+
+func TestWatchSessions(t *testing.T) {
+	instanceId := "watch_session"
+	c := &client{instanceId: instanceId}
+	c.connect(func(event *rpc.SystemEvent) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.keepalive(ctx)
+	watch := func(afterSeq uint64) rpc.MediaApi_WatchSessionsClient {
+		stream, err := c.mediaClient.WatchSessions(ctx, &rpc.WatchSessionsParam{InstanceId: instanceId,
+			AfterSeq: afterSeq})
+		if err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(100 * time.Millisecond) // wait for watcher registered
+		return stream
+	}
+	expect := func(stream rpc.MediaApi_WatchSessionsClient, eventType rpc.SessionEventType,
+		reason rpc.StopReason) *rpc.SessionEvent {
+		e, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Type != eventType || e.Reason != reason || e.InstanceId != instanceId {
+			t.Fatalf("expect %v(%v) but got %v", eventType, reason, e)
+		}
+		return e
+	}
+	create := func(srtpParam *rpc.SrtpParam) *rpc.Session {
+		session, err := c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+			PeerIp:   "127.0.0.1",
+			PeerPort: 2000,
+			Codecs: []*rpc.CodecInfo{{
+				PayloadNumber: 8,
+				PayloadType:   rpc.CodecType_PCM_ALAW,
+			}},
+			GraphDesc:  "[echo]",
+			InstanceId: instanceId,
+			Srtp:       srtpParam,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return session
+	}
+
+	stream := watch(0)
+	session := create(nil)
+	c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: session.SessionId})
+	c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId})
+	created := expect(stream, rpc.SessionEventType_SESSION_EVENT_CREATED, rpc.StopReason_STOP_REASON_NONE)
+	if created.SessionId != session.SessionId || len(created.Streams) != 1 {
+		t.Fatalf("wrong created event: %v", created)
+	}
+	expect(stream, rpc.SessionEventType_SESSION_EVENT_STARTED, rpc.StopReason_STOP_REASON_NONE)
+	stopped := expect(stream, rpc.SessionEventType_SESSION_EVENT_STOPPED, rpc.StopReason_RPC_STOP)
+	if stopped.Seq != created.Seq+2 {
+		t.Fatalf("sequence numbers should be consecutive: %v, %v", created.Seq, stopped.Seq)
+	}
+
+	// peer says bye
+	session = create(nil)
+	c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: session.SessionId})
+	cancelRtp, err := mockSendRtp("127.0.0.1", 3300, session.LocalIp, int(session.LocalRtpPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	cancelRtp()
+	expect(stream, rpc.SessionEventType_SESSION_EVENT_CREATED, rpc.StopReason_STOP_REASON_NONE)
+	expect(stream, rpc.SessionEventType_SESSION_EVENT_STARTED, rpc.StopReason_STOP_REASON_NONE)
+	expect(stream, rpc.SessionEventType_SESSION_EVENT_STOPPED, rpc.StopReason_RTCP_BYE)
+	if _, err = c.mediaClient.GetSession(ctx, &rpc.GetSessionParam{SessionId: session.SessionId}); err == nil {
+		t.Fatal("session stopped by peer should be released")
+	}
+
+	// srtp session can not start without remote key
+	session = create(&rpc.SrtpParam{Profile: rpc.SrtpProfile_AES_CM_128_HMAC_SHA1_80})
+	c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: session.SessionId})
+	expect(stream, rpc.SessionEventType_SESSION_EVENT_CREATED, rpc.StopReason_STOP_REASON_NONE)
+	expect(stream, rpc.SessionEventType_SESSION_EVENT_STOPPED, rpc.StopReason_START_FAILED)
+
+	// reconnected client catches up
+	resumed := watch(created.Seq)
+	expect(resumed, rpc.SessionEventType_SESSION_EVENT_STARTED, rpc.StopReason_STOP_REASON_NONE)
+	if e := expect(resumed, rpc.SessionEventType_SESSION_EVENT_STOPPED, rpc.StopReason_RPC_STOP); e.Seq != stopped.Seq {
+		t.Fatalf("wrong replayed event: %v", e)
+	}
+
+	// events are dropped from buffer, client is told to resync
+	session = create(nil)
+	defer c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId})
+	for i := 0; i < 1100; i++ {
+		if _, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lost := watch(created.Seq)
+	_, err = lost.Recv()
+	st, _ := status.FromError(err)
+	if st.Code() != codes.OutOfRange {
+		t.Fatalf("expect out of range but got: %v", err)
+	}
+	for _, d := range st.Details() {
+		if info, ok := d.(*errdetails.ErrorInfo); !ok || info.Metadata["oldest_seq"] == "" {
+			t.Fatalf("oldest sequence number should be returned: %v", d)
+		}
+	}
+	if len(st.Details()) == 0 {
+		t.Fatal("error has no details")
+	}
+}
+
+func TestRpcErrorCode(t *testing.T) {
+	instanceId := "rpc_error"
+	c := &client{instanceId: instanceId}
+	c.connect(func(event *rpc.SystemEvent) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.keepalive(ctx)
+	expectCode := func(err error, code codes.Code) *status.Status {
+		t.Helper()
+		st, _ := status.FromError(err)
+		if st.Code() != code {
+			t.Fatalf("expect code %v but got error: %v", code, err)
+		}
+		return st
+	}
+
+	_, err := c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{PeerIp: "127.0.0.1", PeerPort: 2000})
+	expectCode(err, codes.InvalidArgument)
+	_, err = c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: "bad id"})
+	st := expectCode(err, codes.InvalidArgument)
+	if br, ok := st.Details()[0].(*errdetails.BadRequest); !ok || br.FieldViolations[0].Field != "session_id" {
+		t.Fatalf("wrong error details: %v", st.Details())
+	}
+
+	session, err := c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+		PeerIp:   "127.0.0.1",
+		PeerPort: 2000,
+		Codecs: []*rpc.CodecInfo{{
+			PayloadNumber: 8,
+			PayloadType:   rpc.CodecType_PCM_ALAW,
+		}},
+		GraphDesc:  "[ep:echo]",
+		InstanceId: instanceId,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId, PeerIp: "127.0.0.1",
+		PeerPort: 2000, PayloadNumber: 200})
+	expectCode(err, codes.InvalidArgument)
+	_, err = c.mediaClient.ExecuteAction(ctx, &rpc.Action{SessionId: session.SessionId, Cmd: "no_such_cmd"})
+	st = expectCode(err, codes.NotFound)
+	if ri, ok := st.Details()[0].(*errdetails.ResourceInfo); !ok || ri.ResourceType != "command" {
+		t.Fatalf("wrong error details: %v", st.Details())
+	}
+	result, err := c.mediaClient.ExecuteAction(ctx, &rpc.Action{
+		SessionId: session.SessionId,
+		Cmd:       "exec",
+		CmdArg:    "[ep] <-> 'hello'; [nobody] <-> 'hello'",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Ok || len(result.Replies) != 2 || !result.Replies[0].Ok || result.Replies[1].Ok ||
+		result.Replies[1].Args[0] != "to node not exist" {
+		t.Fatalf("wrong action result: %v", result)
+	}
+
+	if _, err = c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: session.SessionId})
+	st = expectCode(err, codes.FailedPrecondition)
+	if pf, ok := st.Details()[0].(*errdetails.PreconditionFailure); !ok ||
+		pf.Violations[0].Description != rpc.SessionStatus_SESSION_STARTED.String() {
+		t.Fatalf("wrong error details: %v", st.Details())
+	}
+	if _, err = c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId})
+	expectCode(err, codes.NotFound)
+}
+
+func TestDescribeCapabilities(t *testing.T) {
+	c := &client{instanceId: "capabilities"}
+	c.connect(func(event *rpc.SystemEvent) {})
+	caps, err := c.mediaClient.DescribeCapabilities(context.Background(), &rpc.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodeTypes := make(map[string]*rpc.NodeTypeInfo)
+	for _, nt := range caps.NodeTypes {
+		nodeTypes[nt.Name] = nt
+	}
+	if nt := nodeTypes["echo"]; nt == nil || len(nt.Properties) != 1 || nt.Properties[0].Name != "stream" {
+		t.Fatalf("wrong echo node type: %v", nt)
+	}
+	if nt := nodeTypes["rtp_sink"]; nt == nil || len(nt.Accept) != 1 || nt.Accept[0] != "rtp_packet" {
+		t.Fatalf("wrong rtp_sink node type: %v", nt)
+	}
+	if len(caps.MessageTypes) == 0 || !slices.Contains(caps.Codecs, rpc.CodecType_PCM_ALAW) {
+		t.Fatalf("wrong capabilities: %v", caps)
+	}
+}
+
+func TestValidateGraph(t *testing.T) {
+	c := &client{instanceId: "validate_graph"}
+	c.connect(func(event *rpc.SystemEvent) {})
+	ctx := context.Background()
+	result, err := c.mediaClient.ValidateGraph(ctx, &rpc.ValidateGraphParam{GraphDesc: "[ep:echo stream=audio]"})
+	if err != nil || !result.Ok {
+		t.Fatalf("graph should be valid: %v %v", result, err)
+	}
+	result, err = c.mediaClient.ValidateGraph(ctx, &rpc.ValidateGraphParam{GraphDesc: "[ep:echo]\n[x:nope]"})
+	if err != nil || result.Ok || len(result.Diagnostics) != 1 || result.Diagnostics[0].Line != 2 {
+		t.Fatalf("unknown node type should be located: %v %v", result, err)
+	}
+}
+
+// stopListener records why sessions are stopped
+type stopListener struct {
+	server.BaseSessionListener
+	stoppedC chan rpc.StopReason
+}
+
+func (l *stopListener) OnSessionStopped(s *server.RtpMediaSession) {
+	l.stoppedC <- s.GetStopReason()
+}
+
+func TestDrainServer(t *testing.T) {
+	const drainGrpcPort = 5680
+	const drainAdminAddress = "127.0.0.1:5686"
+	listener := &stopListener{stoppedC: make(chan rpc.StopReason, 8)}
+	start, stop, err := server.NewGrpcServer(&server.Config{
+		RtpIp:               "127.0.0.1",
+		StartPort:           21000,
+		EndPort:             22000,
+		GrpcIp:              grpcIp,
+		GrpcPort:            drainGrpcPort,
+		DrainTimeout:        time.Minute,
+		AdminAddress:        drainAdminAddress,
+		SessionListenerList: []server.SessionListener{listener},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go start()
+
+	instanceId := "drain"
+	terminatedC := make(chan *rpc.SystemEvent, 1)
+	c := &client{instanceId: instanceId}
+	c.connect(func(event *rpc.SystemEvent) {
+		if event.Cmd == rpc.SystemCommand_SESSION_TERMINATED {
+			terminatedC <- event
+		}
+	})
+	conn, err := grpc.NewClient(net.JoinHostPort(grpcIp, fmt.Sprint(drainGrpcPort)), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	mc := rpc.NewMediaApiClient(conn)
+	ctx := context.Background()
+	param := &rpc.CreateParam{
+		PeerIp:     "127.0.0.1",
+		PeerPort:   2000,
+		Codecs:     []*rpc.CodecInfo{{PayloadNumber: 8, PayloadType: rpc.CodecType_PCM_ALAW}},
+		GraphDesc:  "[ep:echo]",
+		InstanceId: instanceId,
+	}
+	var sessions []*rpc.Session
+	for i := 0; i < 2; i++ {
+		session, err := mc.PrepareSession(ctx, param)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	// drain by admin endpoint before stopping server, timeout must be positive
+	for _, timeout := range []string{"0s", "-1s"} {
+		resp, err := http.Post("http://"+drainAdminAddress+"/drain?timeout="+timeout, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("drain timeout %v should be rejected: %v", timeout, resp.Status)
+		}
+	}
+	resp, err := http.Post("http://"+drainAdminAddress+"/drain?timeout=500ms", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state rpc.DrainState
+	err = json.NewDecoder(resp.Body).Decode(&state)
+	resp.Body.Close()
+	if err != nil || !state.Draining || state.RemainingSessions != 2 {
+		t.Fatalf("server should start draining: %v %v", &state, err)
+	}
+	ver, err := mc.GetVersion(ctx, &rpc.Empty{})
+	if err != nil || !ver.Drain.GetDraining() || ver.Drain.RemainingSessions != 2 || ver.Drain.Deadline == 0 {
+		t.Fatalf("server should be draining: %v %v", ver, err)
+	}
+	if _, err = mc.PrepareSession(ctx, param); status.Code(err) != codes.Unavailable {
+		t.Fatalf("draining server should refuse new session: %v", err)
+	}
+	if h, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil ||
+		h.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("draining server should not be serving: %v %v", h, err)
+	}
+
+	// one session ends in time, the other is stopped by drain timeout
+	if _, err = mc.StopSession(ctx, &rpc.StopParam{SessionId: sessions[0].SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	if reason := <-listener.stoppedC; reason != rpc.StopReason_RPC_STOP {
+		t.Fatalf("wrong stop reason: %v", reason)
+	}
+	if reason := <-listener.stoppedC; reason != rpc.StopReason_DRAIN_TIMEOUT {
+		t.Fatalf("wrong stop reason: %v", reason)
+	}
+	select {
+	case event := <-terminatedC:
+		if event.SessionId != sessions[1].SessionId || event.Event != "reason=DRAIN_TIMEOUT" {
+			t.Fatalf("wrong terminated event: %v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("instance is not notified of terminated session")
+	}
+	// server is drained, stopping it doesn't wait for the configured timeout again
+	stoppedC := make(chan struct{})
+	go func() {
+		stop()
+		close(stoppedC)
+	}()
+	select {
+	case <-stoppedC:
+	case <-time.After(5 * time.Second):
+		t.Fatal("drained server should stop at once")
+	}
+	// stopping again is harmless
+	stop()
+}
+
+func TestLoadReport(t *testing.T) {
+	c := &client{instanceId: "load"}
+	c.connect(func(event *rpc.SystemEvent) {})
+	ctx := context.Background()
+	h, err := healthpb.NewHealthClient(c.conn).Check(ctx, &healthpb.HealthCheckRequest{Service: "rpc.MediaApi"})
+	if err != nil || h.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("server should be serving: %v %v", h, err)
+	}
+
+	before, err := c.mediaClient.GetLoad(ctx, &rpc.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if before.TotalPorts != 5000 || before.NumCpu == 0 || before.Draining {
+		t.Fatalf("wrong load report: %v", before)
+	}
+	session, err := c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+		PeerIp:     "127.0.0.1",
+		PeerPort:   2000,
+		Codecs:     []*rpc.CodecInfo{{PayloadNumber: 8, PayloadType: rpc.CodecType_PCM_ALAW}},
+		GraphDesc:  "[ep:echo]",
+		InstanceId: c.instanceId,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId})
+	after, err := c.mediaClient.GetLoad(ctx, &rpc.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if after.FreePorts != before.FreePorts-1 || after.Sessions != before.Sessions+1 || after.GraphNodes <= before.GraphNodes {
+		t.Fatalf("load report should count the new session, before: %v, after: %v", before, after)
+	}
+}
+
+func TestSessionLimits(t *testing.T) {
+	const limitGrpcPort = 5681
+	limits := server.LimitConfig{MaxSessions: 2, MaxSessionsPerInstance: 1, MaxGraphNodes: 2, MaxTranscodeContexts: 1}
+	start, stop, err := server.NewGrpcServer(&server.Config{
+		RtpIp:        "127.0.0.1",
+		StartPort:    22000,
+		EndPort:      23000,
+		GrpcIp:       grpcIp,
+		GrpcPort:     limitGrpcPort,
+		Limits:       limits,
+		DrainTimeout: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go start()
+	defer stop()
+	conn, err := grpc.NewClient(net.JoinHostPort(grpcIp, fmt.Sprint(limitGrpcPort)), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	mc := rpc.NewMediaApiClient(conn)
+	ctx := context.Background()
+	prepare := func(instanceId, gd string) (*rpc.Session, error) {
+		return mc.PrepareSession(ctx, &rpc.CreateParam{
+			PeerIp:     "127.0.0.1",
+			PeerPort:   2000,
+			Codecs:     []*rpc.CodecInfo{{PayloadNumber: 8, PayloadType: rpc.CodecType_PCM_ALAW}},
+			GraphDesc:  gd,
+			InstanceId: instanceId,
+		})
+	}
+	expectQuota := func(err error, resource string) {
+		t.Helper()
+		st := status.Convert(err)
+		if st.Code() != codes.ResourceExhausted {
+			t.Fatalf("expect resource exhausted but got: %v", err)
+		}
+		for _, d := range st.Details() {
+			if qf, ok := d.(*errdetails.QuotaFailure); ok && qf.Violations[0].Subject == resource {
+				return
+			}
+		}
+		t.Fatalf("expect quota failure of %v but got: %v", resource, st.Details())
+	}
+
+	transcoding, err := prepare("limit_a", "[ep:echo];[t:transcoder]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = prepare("limit_a", "[ep:echo]")
+	expectQuota(err, "instance_sessions")
+	_, err = prepare("limit_b", "[ep:echo];[t:transcoder]")
+	expectQuota(err, "transcode_contexts")
+	_, err = prepare("limit_b", "[ep:echo];[ep2:echo];[ep3:echo]")
+	expectQuota(err, "nodes")
+	if _, err = prepare("limit_b", "[ep:echo]"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = prepare("limit_c", "[ep:echo]")
+	expectQuota(err, "sessions")
+
+	// transcode context is given back once session stops
+	if _, err = mc.StopSession(ctx, &rpc.StopParam{SessionId: transcoding.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = prepare("limit_c", "[ep:echo];[t:transcoder]"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAdminEndpoint(t *testing.T) {
+	c := &client{instanceId: "admin"}
+	c.connect(func(event *rpc.SystemEvent) {})
+	ctx := context.Background()
+	session, err := c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+		PeerIp:     "127.0.0.1",
+		PeerPort:   2000,
+		Codecs:     []*rpc.CodecInfo{{PayloadNumber: 8, PayloadType: rpc.CodecType_PCM_ALAW}},
+		GraphDesc:  "[ep:echo];[src:chan_src] -> [sink:chan_sink]",
+		InstanceId: c.instanceId,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId})
+	get := func(path string, expectCode int) string {
+		t.Helper()
+		resp, err := http.Get("http://" + adminAddress + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != expectCode {
+			t.Fatalf("get %v expect status %v but got %v: %s", path, expectCode, resp.StatusCode, body)
+		}
+		return string(body)
+	}
+
+	var snapshot event.GraphSnapshot
+	if err = json.Unmarshal([]byte(get("/sessions/"+session.SessionId+"/graph.json", http.StatusOK)), &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshot.Scopes) != 1 || len(snapshot.Scopes[0].Nodes) != 3 {
+		t.Fatalf("wrong graph of session: %+v", snapshot)
+	}
+	dot := get("/sessions/"+session.SessionId+"/graph.dot", http.StatusOK)
+	if !strings.Contains(dot, `"src" -> "sink";`) || !strings.Contains(dot, `"ep" [label="ep\necho"];`) {
+		t.Fatalf("wrong graph dot: %v", dot)
+	}
+	get("/sessions/123456/graph.dot", http.StatusNotFound)
+	get("/sessions/bad/graph.dot", http.StatusBadRequest)
+	get("/graph", http.StatusOK)
+	get("/metrics", http.StatusOK)
+	get("/debug/pprof/", http.StatusOK)
+}
+
+func TestSessionRecovery(t *testing.T) {
+	newServer := func(grpcPort uint16, dir string) (rpc.MediaApiClient, server.StopServerFunc) {
+		t.Helper()
+		store, err := server.NewFileSessionStore(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		start, stop, err := server.NewGrpcServer(&server.Config{
+			RtpIp:     "127.0.0.1",
+			StartPort: 24000,
+			EndPort:   25000,
+			GrpcIp:    grpcIp,
+			GrpcPort:  grpcPort,
+			Store:     store,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		go start()
+		conn, err := grpc.NewClient(net.JoinHostPort(grpcIp, fmt.Sprint(grpcPort)), grpc.WithInsecure())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return rpc.NewMediaApiClient(conn), stop
+	}
+	recordExists := func(dir, sessionId string) bool {
+		_, err := os.Stat(filepath.Join(dir, sessionId+".json"))
+		return err == nil
+	}
+
+	instanceId := "recovery"
+	dir, recoveryDir := t.TempDir(), t.TempDir()
+	mc, stop := newServer(5684, dir)
+	ctx := context.Background()
+	param := &rpc.CreateParam{
+		PeerIp:   "127.0.0.1",
+		PeerPort: 2000,
+		Codecs: []*rpc.CodecInfo{{PayloadNumber: 8, PayloadType: rpc.CodecType_PCM_ALAW},
+			{PayloadNumber: 96, PayloadType: rpc.CodecType_AMRNB}},
+		GraphDesc:  "[ep:echo]",
+		InstanceId: instanceId,
+		Srtp:       &rpc.SrtpParam{Profile: rpc.SrtpProfile_AES_CM_128_HMAC_SHA1_80},
+	}
+	session, err := mc.PrepareSession(ctx, param)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = mc.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId, PeerIp: "127.0.0.1",
+		PeerPort: 3000, Srtp: &rpc.SrtpParam{RemoteKey: session.SrtpLocalKey}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = mc.StartSession(ctx, &rpc.StartParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = mc.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId, PayloadNumber: 96}); err != nil {
+		t.Fatal(err)
+	}
+	store, _ := server.NewFileSessionStore(dir)
+	records, err := store.Load()
+	if err != nil || len(records) != 1 {
+		t.Fatalf("session should be saved: %v %v", records, err)
+	}
+	if r := records[0]; r.SessionId != session.SessionId || !r.Started || len(r.Updates) != 2 ||
+		r.Updates[0].AfterStart || !r.Updates[1].AfterStart || r.Create.Srtp.LocalKey != session.SrtpLocalKey ||
+		!slices.Equal(r.LocalPorts, []uint16{uint16(session.LocalRtpPort)}) {
+		t.Fatalf("wrong session record: %+v", r)
+	}
+
+	// simulate crash by copying records to another store before the session stops
+	recoveryStore, _ := server.NewFileSessionStore(recoveryDir)
+	if err = recoveryStore.Save(records[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err = recoveryStore.Save(&server.SessionRecord{SessionId: "0000000001", InstanceId: instanceId,
+		LocalPorts: []uint16{30000}, Create: param}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = mc.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	if recordExists(dir, session.SessionId) {
+		t.Fatal("record of stopped session should be deleted")
+	}
+	stop()
+
+	mc, stop = newServer(5685, recoveryDir)
+	defer stop()
+	info, err := mc.GetSession(ctx, &rpc.GetSessionParam{SessionId: session.SessionId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Status != rpc.SessionStatus_SESSION_STARTED || info.InstanceId != instanceId ||
+		info.Streams[0].LocalRtpPort != session.LocalRtpPort || info.Streams[0].PeerRtpPort != 3000 ||
+		info.Streams[0].PayloadNumber != 96 {
+		t.Fatalf("wrong recovered session: %v", info)
+	}
+	if recordExists(recoveryDir, "0000000001") {
+		t.Fatal("record failed to recover should be deleted")
+	}
+
+	stream, err := mc.SystemChannel(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = stream.Send(&rpc.SystemEvent{Cmd: rpc.SystemCommand_REGISTER, InstanceId: instanceId}); err != nil {
+		t.Fatal(err)
+	}
+	if se, err := stream.Recv(); err != nil || se.Cmd != rpc.SystemCommand_SESSION_RECOVERED ||
+		se.SessionId != session.SessionId || se.Event != "status=SESSION_STARTED" {
+		t.Fatalf("instance should be notified of recovered session: %v %v", se, err)
+	}
+	stream.CloseSend()
+
+	if _, err = mc.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	if recordExists(recoveryDir, session.SessionId) {
+		t.Fatal("record of stopped session should be deleted")
+	}
+}
+
+func TestWatchdogPolicies(t *testing.T) {
+	instanceId := "watchdog"
+	terminatedC := make(chan *rpc.SystemEvent, 8)
+	c := &client{instanceId: instanceId}
+	c.connect(func(event *rpc.SystemEvent) {
+		if event.Cmd == rpc.SystemCommand_SESSION_TERMINATED {
+			terminatedC <- event
+		}
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.keepalive(ctx)
+	prepare := func(gd string, peerPort uint32, policy *rpc.WatchdogParam) *rpc.Session {
+		t.Helper()
+		session, err := c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+			PeerIp:     "127.0.0.1",
+			PeerPort:   peerPort,
+			Codecs:     []*rpc.CodecInfo{{PayloadNumber: 8, PayloadType: rpc.CodecType_PCM_ALAW}},
+			GraphDesc:  gd,
+			InstanceId: instanceId,
+			Watchdog:   policy,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return session
+	}
+	start := func(session *rpc.Session) {
+		t.Helper()
+		if _, err := c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: session.SessionId}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectStop := func(session *rpc.Session, reason rpc.StopReason) {
+		t.Helper()
+		select {
+		case event := <-terminatedC:
+			if event.SessionId != session.SessionId || event.Event != "reason="+reason.String() {
+				t.Fatalf("expect session(%v) stopped by %v but got: %v", session.SessionId, reason, event)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("session is not stopped by %v", reason)
+		}
+	}
+
+	session := prepare("[ep:echo]", 2000, &rpc.WatchdogParam{MaxDuration: 300, InactivityTimeout: 60000})
+	info, err := c.mediaClient.GetSession(ctx, &rpc.GetSessionParam{SessionId: session.SessionId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := info.Detail.Watchdog.Policy; p.MaxDuration != 300 || p.InactivityTimeout != 60000 || p.OneWayTimeout != 0 {
+		t.Fatalf("wrong watchdog policy: %v", p)
+	}
+	expectStop(session, rpc.StopReason_MAX_DURATION)
+
+	// receive timeout of server config is the default inactivity policy
+	session = prepare("[ep:echo]", 2000, nil)
+	if info, err = c.mediaClient.GetSession(ctx, &rpc.GetSessionParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+	if p := info.Detail.Watchdog.Policy; p.InactivityTimeout != uint32(server.SessionTimeoutPeriod.Milliseconds()) {
+		t.Fatalf("wrong default watchdog policy: %v", p)
+	}
+	c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId})
+
+	// heartbeat keeps session alive until instance stops reporting
+	session = prepare("[ep:echo]", 2000, &rpc.WatchdogParam{HeartbeatTimeout: 400})
+	for i := 0; i < 6; i++ {
+		c.sysStream.Send(&rpc.SystemEvent{Cmd: rpc.SystemCommand_SESSION_INFO, InstanceId: instanceId,
+			SessionId: session.SessionId})
+		time.Sleep(100 * time.Millisecond)
+	}
+	select {
+	case event := <-terminatedC:
+		t.Fatalf("session reporting heartbeat should be alive: %v", event)
+	default:
+	}
+	expectStop(session, rpc.StopReason_HEARTBEAT_LOST)
+
+	session = prepare("[ep:echo]", 2000, &rpc.WatchdogParam{InactivityTimeout: 300})
+	start(session)
+	expectStop(session, rpc.StopReason_MEDIA_INACTIVE)
+
+	// received packets are dropped by rtp_src, and rtp_sink has nothing to send
+	session = prepare("[src:rtp_src];[sink:rtp_sink]", 3300, &rpc.WatchdogParam{OneWayTimeout: 500})
+	start(session)
+	cancelRtp, err := mockSendRtp("127.0.0.1", 3300, session.LocalIp, int(session.LocalRtpPort))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancelRtp()
+	expectStop(session, rpc.StopReason_ONE_WAY_MEDIA)
+}
+
+func TestMediaDirection(t *testing.T) {
+	instanceId := "media_direction"
+	c := &client{instanceId: instanceId}
+	c.connect(func(event *rpc.SystemEvent) {})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go c.keepalive(ctx)
+	expectDirection := func(direction rpc.MediaDirection) {
+		t.Helper()
+		select {
+		case msg := <-directionProbeC:
+			if msg.Stream != "audio" || msg.Direction != direction {
+				t.Fatalf("expect direction %v of audio stream but got: %v %v", direction, msg.Stream, msg.Direction)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("graph is not notified of direction %v", direction)
+		}
+	}
+	getDirection := func(sessionId string) rpc.MediaDirection {
+		t.Helper()
+		info, err := c.mediaClient.GetSession(ctx, &rpc.GetSessionParam{SessionId: sessionId})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info.Streams[0].Direction
+	}
+
+	if _, err := c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+		PeerIp:     "127.0.0.1",
+		PeerPort:   2000,
+		Codecs:     []*rpc.CodecInfo{{PayloadNumber: 8, PayloadType: rpc.CodecType_PCM_ALAW}},
+		GraphDesc:  "[echo]",
+		InstanceId: instanceId,
+		Direction:  rpc.MediaDirection(100),
+	}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("invalid direction should be rejected: %v", err)
+	}
+	session, err := c.mediaClient.PrepareSession(ctx, &rpc.CreateParam{
+		PeerIp:     "127.0.0.1",
+		PeerPort:   2000,
+		Codecs:     []*rpc.CodecInfo{{PayloadNumber: 8, PayloadType: rpc.CodecType_PCM_ALAW}},
+		GraphDesc:  "[ep:echo];[dir:direction_src] -> [probe:direction_probe]",
+		InstanceId: instanceId,
+		Direction:  rpc.MediaDirection_SENDONLY,
+		Watchdog:   &rpc.WatchdogParam{InactivityTimeout: 300},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.mediaClient.StopSession(ctx, &rpc.StopParam{SessionId: session.SessionId})
+	expectDirection(rpc.MediaDirection_SENDONLY)
+	if d := getDirection(session.SessionId); d != rpc.MediaDirection_SENDONLY {
+		t.Fatalf("session should be created with sendonly: %v", d)
+	}
+
+	// direction only update before start keeps the peer address
+	if _, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId,
+		Direction: rpc.MediaDirection_SENDRECV}); err != nil {
+		t.Fatal(err)
+	}
+	expectDirection(rpc.MediaDirection_SENDRECV)
+	info, err := c.mediaClient.GetSession(ctx, &rpc.GetSessionParam{SessionId: session.SessionId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stream := info.Streams[0]; stream.PeerIp != "127.0.0.1" || stream.PeerRtpPort != 2000 {
+		t.Fatalf("peer address should be kept: %v", stream)
+	}
+	if _, err = c.mediaClient.StartSession(ctx, &rpc.StartParam{SessionId: session.SessionId}); err != nil {
+		t.Fatal(err)
+	}
+
+	// peer address of started session can not be changed, nor the rest of the update
+	for _, update := range []*rpc.UpdateParam{
+		{SessionId: session.SessionId, PeerIp: "127.0.0.2", Direction: rpc.MediaDirection_INACTIVE},
+		{SessionId: session.SessionId, PeerPort: 3000, Direction: rpc.MediaDirection_INACTIVE},
+		{SessionId: session.SessionId, Streams: []*rpc.StreamUpdate{{Name: "audio", PeerPort: 3000}}},
+		{SessionId: session.SessionId, Srtp: &rpc.SrtpParam{}},
+	} {
+		if _, err = c.mediaClient.UpdateSession(ctx, update); status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("update %v of started session should be rejected: %v", update, err)
+		}
+	}
+	if d := getDirection(session.SessionId); d != rpc.MediaDirection_SENDRECV {
+		t.Fatalf("direction of rejected update should not be applied: %v", d)
+	}
+
+	// inactive stream is on hold, watchdog doesn't count it as inactive media
+	if _, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId,
+		Direction: rpc.MediaDirection_INACTIVE}); err != nil {
+		t.Fatal(err)
+	}
+	expectDirection(rpc.MediaDirection_INACTIVE)
+	time.Sleep(600 * time.Millisecond)
+	info, err = c.mediaClient.GetSession(ctx, &rpc.GetSessionParam{SessionId: session.SessionId})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Status != rpc.SessionStatus_SESSION_STARTED || info.Streams[0].Direction != rpc.MediaDirection_INACTIVE {
+		t.Fatalf("session on hold should be alive: %v", info)
+	}
+
+	if _, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId,
+		Streams: []*rpc.StreamUpdate{{Name: "audio", Direction: rpc.MediaDirection(100)}}}); status.Code(err) !=
+		codes.InvalidArgument {
+		t.Fatalf("invalid direction should be rejected: %v", err)
+	}
+	// unchanged direction is not notified again
+	if _, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId,
+		Streams: []*rpc.StreamUpdate{{Name: "audio", Direction: rpc.MediaDirection_INACTIVE}}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = c.mediaClient.UpdateSession(ctx, &rpc.UpdateParam{SessionId: session.SessionId,
+		Streams: []*rpc.StreamUpdate{{Name: "audio", Direction: rpc.MediaDirection_SENDRECV}}}); err != nil {
+		t.Fatal(err)
+	}
+	expectDirection(rpc.MediaDirection_SENDRECV)
+	if d := getDirection(session.SessionId); d != rpc.MediaDirection_SENDRECV {
+		t.Fatalf("session should be resumed: %v", d)
+	}
+}
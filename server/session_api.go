@@ -2,14 +2,14 @@ package server
 
 import (
 	"context"
-	"fmt"
-	"github.com/appcrash/GoRTP/rtp"
+	"encoding/base64"
 	"github.com/appcrash/media/server/comp"
 	"github.com/appcrash/media/server/event"
 	"github.com/appcrash/media/server/prom"
 	"github.com/appcrash/media/server/rpc"
-	"github.com/appcrash/media/server/utils"
+	"github.com/appcrash/media/server/srtp"
 	"net"
+	"sort"
 	"sync"
 	"time"
 )
@@ -22,33 +22,34 @@ const (
 )
 
 type RtpMediaSession struct {
-	sessionId             SessionIdType
-	localIp, remoteIp     *net.IPAddr
-	localPort, remotePort uint16
-	rtpSession            *rtp.Session
-	rtpSessionLocalId     uint32 // rtpSession id which update rtp params
-	instanceId            string // which instance created this session
+	sessionId  SessionIdType
+	localIp    *net.IPAddr
+	instanceId string         // which instance created this session
+	streams    []*mediaStream // at least one stream, the first one is used by legacy single stream APIs
 
-	avPayloadNumber uint8
-	avPayloadCodec  rpc.CodecType
-	avCodecParam    string
+	notifyDtmf bool
 
-	telephoneEventPayloadNumber uint8
-	telephoneEventPayloadCodec  rpc.CodecType
-	telephoneEventCodecParam    string
+	srtpProfile   srtp.Profile
+	srtpLocalKey  []byte // master key and salt of outgoing packets
+	srtpRemoteKey []byte // master key and salt of incoming packets
+
+	latchWindow time.Duration // zero if latching is not enabled
 
 	mutex sync.Mutex
 
 	status     int
+	stopReason rpc.StopReason
 	cancelFunc context.CancelFunc
 	doneC      chan string // notify this channel when loop is done
 
-	pullC        <-chan *utils.RtpPacketList
-	handleC      chan<- *utils.RtpPacketList
 	interceptors []RtpPacketInterceptor
 	composer     *comp.Composer
+	graphDesc    string
 	watchdog     *WatchDog
 	graph        *event.Graph
+
+	statusListener func(s *RtpMediaSession, status int) // notify server of status changed by session itself
+	record         *SessionRecord                       // how to recover the session, guarded by mutex
 }
 
 func (s *RtpMediaSession) GetSessionId() SessionIdType {
@@ -56,27 +57,161 @@ func (s *RtpMediaSession) GetSessionId() SessionIdType {
 }
 
 func (s *RtpMediaSession) GetStatus() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	return s.status
 }
 
+// GetStopReason returns why session is stopped, STOP_REASON_NONE if still alive
+func (s *RtpMediaSession) GetStopReason() rpc.StopReason {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.stopReason
+}
+
+// GetRemoteAddress returns address of peer of the first stream, which is the learned one if latching happened
+func (s *RtpMediaSession) GetRemoteAddress() (ip *net.IPAddr, port uint16) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.streams[0].remoteIp, s.streams[0].remotePort
+}
+
+// GetStreamNames returns names of all media streams in creation order
+func (s *RtpMediaSession) GetStreamNames() (names []string) {
+	for _, ms := range s.streams {
+		names = append(names, ms.name)
+	}
+	return
+}
+
+// GetLocalPort returns local rtp port of stream, the first stream if name is empty, or zero if no such stream
+func (s *RtpMediaSession) GetLocalPort(name string) uint16 {
+	if ms := s.getStream(name); ms != nil {
+		return ms.localPort
+	}
+	return 0
+}
+
+// GetAVPayloadType returns payload type of the current sending codec of the first stream
 func (s *RtpMediaSession) GetAVPayloadType() uint8 {
-	return s.avPayloadNumber
+	return uint8(s.streams[0].codec().PayloadNumber)
 }
 
 func (s *RtpMediaSession) GetAVCodecType() rpc.CodecType {
-	return s.avPayloadCodec
+	return s.streams[0].codec().PayloadType
 }
 
 func (s *RtpMediaSession) GetAVCodecParam() string {
-	return s.avCodecParam
+	return s.streams[0].codec().CodecParam
+}
+
+// GetCodecs returns negotiated audio/video codecs of stream ordered by payload type, the first stream if name is
+// empty
+func (s *RtpMediaSession) GetCodecs(name string) (codecs []*rpc.CodecInfo) {
+	ms := s.getStream(name)
+	if ms == nil {
+		return
+	}
+	for _, ci := range ms.codecs {
+		codecs = append(codecs, ci)
+	}
+	sort.Slice(codecs, func(i, j int) bool {
+		return codecs[i].PayloadNumber < codecs[j].PayloadNumber
+	})
+	return
 }
 
 func (s *RtpMediaSession) GetTelephoneEventPayloadType() uint8 {
-	return s.telephoneEventPayloadNumber
+	return s.streams[0].telephoneEventPayloadNumber
 }
 
 func (s *RtpMediaSession) GetTelephoneEventCodecType() rpc.CodecType {
-	return s.telephoneEventPayloadCodec
+	return s.streams[0].telephoneEventPayloadCodec
+}
+
+func (s *RtpMediaSession) GetSrtpLocalKey() string {
+	if s.srtpLocalKey == nil {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString(s.srtpLocalKey)
+}
+
+// GetStats returns statistics of rtp streams of all media streams, as well as quality estimate of the first audio
+// stream(or the first stream if there is no audio)
+func (s *RtpMediaSession) GetStats() *rpc.SessionStats {
+	result := &rpc.SessionStats{SessionId: s.sessionId.String()}
+	primary := s.streams[0]
+	for _, ms := range s.streams {
+		if !ms.isVideo() {
+			primary = ms
+			break
+		}
+	}
+	rtt, mos := primary.stats.quality()
+	result.Rtt, result.Mos = float32(rtt.Seconds()*1000), float32(mos)
+	for _, ms := range s.streams {
+		ms.stats.appendRpc(result, ms.name)
+	}
+	return result
+}
+
+// streamInfos returns endpoints of every stream, as well as negotiated codecs if withCodecs is true. it must be
+// called with mutex held once session is started as remote address may be latched
+func (s *RtpMediaSession) streamInfos(withCodecs bool) (infos []*rpc.StreamInfo) {
+	for _, ms := range s.streams {
+		info := &rpc.StreamInfo{
+			Name:          ms.name,
+			LocalRtpPort:  uint32(ms.localPort),
+			PeerRtpPort:   uint32(ms.remotePort),
+			PayloadNumber: ms.codec().PayloadNumber,
+			PeerIp:        ms.remoteIp.String(),
+			Direction:     ms.getDirection(),
+		}
+		if withCodecs {
+			info.Codecs = s.GetCodecs(ms.name)
+			if ms.dtmfDecoder != nil {
+				info.Codecs = append(info.Codecs, &rpc.CodecInfo{
+					PayloadNumber: uint32(ms.telephoneEventPayloadNumber),
+					PayloadType:   ms.telephoneEventPayloadCodec,
+					CodecParam:    ms.telephoneEventCodecParam,
+				})
+			}
+		}
+		infos = append(infos, info)
+	}
+	return
+}
+
+// GetInfo returns brief information of session, graph and watchdog details are included if detail is true
+func (s *RtpMediaSession) GetInfo(detail bool) *rpc.SessionInfo {
+	s.mutex.Lock()
+	info := &rpc.SessionInfo{
+		SessionId:  s.sessionId.String(),
+		InstanceId: s.instanceId,
+		Status:     sessionStatusToRpc(s.status),
+		CreateTime: s.GetCreateTime().UnixMilli(),
+		LocalIp:    s.localIp.String(),
+		Streams:    s.streamInfos(detail),
+		StopReason: s.stopReason,
+	}
+	s.mutex.Unlock()
+	if detail {
+		info.Detail = &rpc.SessionDetail{
+			GraphDesc: s.graphDesc,
+			Graph:     s.graphNodes(),
+			Nodes:     s.liveNodes(),
+			Watchdog:  s.watchdog.toRpc(),
+		}
+	}
+	return info
+}
+
+func (s *RtpMediaSession) GetInstanceId() string {
+	return s.instanceId
+}
+
+func (s *RtpMediaSession) GetCreateTime() time.Time {
+	return s.watchdog.createTimestamp
 }
 
 func (s *RtpMediaSession) GetController() comp.CommandInitiator {
@@ -87,7 +222,7 @@ func (s *RtpMediaSession) Start() (err error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	if s.status != sessionStatusCreated {
-		err = fmt.Errorf("RtpMediaSession(%v) start at status %v", s.sessionId, s.status)
+		err = errSessionStatus(s.sessionId, s.status, "start")
 		logger.Errorf("try to start session(%v) when status is %v", s.sessionId, s.status)
 		return
 	}
@@ -96,41 +231,57 @@ func (s *RtpMediaSession) Start() (err error) {
 		// if start failed, stop using this session anymore
 		if err != nil {
 			logger.Errorf("session(%v) start failed with error(%v), finalize it", s.sessionId, err)
-			s.Stop()
+			s.stop(rpc.StopReason_START_FAILED)
 		}
 	}()
 
-	port := int(s.remotePort)
-	if _, err = s.rtpSession.AddRemote(&rtp.Address{
-		IPAddr:   s.remoteIp.IP,
-		DataPort: port,
-		CtrlPort: 1 + port,
-		Zone:     "",
-	}); err != nil {
-		return
-	}
-	if err = s.rtpSession.StartSession(); err != nil {
-		return
+	for _, ms := range s.streams {
+		if err = ms.start(); err != nil {
+			return
+		}
 	}
 	prom.RtpStartedSession.Inc()
 
 	ctx, cancel := context.WithCancel(context.Background())
 	s.cancelFunc = cancel
-	go s.receiveRtcpLoop(ctx)
-	go s.receiveRtpLoop(ctx)
-	go s.sendRtpLoop(ctx)
+	for _, ms := range s.streams {
+		go ms.receiveRtcpLoop(ctx)
+		go ms.receiveRtpLoop(ctx)
+		go ms.sendRtpLoop(ctx)
+	}
 	s.status = sessionStatusStarted
+	s.watchdog.onSessionStart()
 	return
 }
 
 func (s *RtpMediaSession) Stop() {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
+	s.stop(rpc.StopReason_RPC_STOP)
+}
+
+// terminate stops session on its own initiative, then server is notified to release it. returns false if it is
+// already stopped
+func (s *RtpMediaSession) terminate(reason rpc.StopReason) bool {
+	s.mutex.Lock()
+	stopped := s.stop(reason)
+	s.mutex.Unlock()
+	if stopped && s.statusListener != nil {
+		s.statusListener(s, sessionStatusStopped)
+	}
+	return stopped
+}
+
+// stop must be called with mutex held, returns false if already stopped, the first reason is kept
+func (s *RtpMediaSession) stop(reason rpc.StopReason) bool {
 	var nbDone int
+	nbLoop := nbStreamLoop * len(s.streams)
 	if s.status == sessionStatusStopped {
 		//logger.Errorf("try to stop already terminated session(%v)", s.sessionId)
-		return
+		return false
 	}
+	s.stopReason = reason
+	logger.Infof("session(%v) stops due to %v", s.sessionId, reason)
 	if s.status == sessionStatusCreated {
 		// created but not started
 		goto cleanup
@@ -140,7 +291,7 @@ func (s *RtpMediaSession) Stop() {
 		s.cancelFunc()
 		// for debug purpose, check all loops are finished normally
 	done:
-		for nbDone < 3 {
+		for nbDone < nbLoop {
 			select {
 			case <-s.doneC:
 				nbDone++
@@ -148,7 +299,7 @@ func (s *RtpMediaSession) Stop() {
 				break done
 			}
 		}
-		if nbDone != 3 {
+		if nbDone != nbLoop {
 			logger.Errorf("session(%v) loops don't stop normally, finished number:%v", s.sessionId, nbDone)
 			prom.RtpAbnormalSession.Inc()
 		}
@@ -160,12 +311,17 @@ func (s *RtpMediaSession) Stop() {
 
 cleanup:
 	// release all resources this session occupied
+	if s.watchdog != nil {
+		s.watchdog.close()
+	}
 	if s.composer != nil {
 		s.composer.ExitGraph()
 	}
-	if s.rtpSession != nil {
-		s.rtpSession.CloseSession()
+	for _, ms := range s.streams {
+		ms.close()
 	}
+	unpublishStats(s.sessionId.String())
 	s.status = sessionStatusStopped
 	prom.RtpStartedSession.Dec()
+	return true
 }
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"github.com/appcrash/media/server/rpc"
+	"sync"
+	"time"
+)
+
+const (
+	sessionEventBacklog  = 1024 // number of latest events kept for resuming watchers
+	sessionWatcherBuffer = 256
+)
+
+// sessionWatcher receives events of an instance, or all instances if instanceId is empty. its channel is closed
+// if it falls behind, then the client should resume by the last sequence number it received
+type sessionWatcher struct {
+	principal  *Principal
+	instanceId string
+	C          chan *rpc.SessionEvent
+}
+
+// sessionEventHub turns session status changes into sequenced events for remote watchers, the latest events are
+// buffered so that reconnected watchers can catch up
+type sessionEventHub struct {
+	mutex    sync.Mutex
+	seq      uint64
+	backlog  []*rpc.SessionEvent
+	watchers map[*sessionWatcher]bool
+}
+
+func newSessionEventHub() *sessionEventHub {
+	return &sessionEventHub{watchers: make(map[*sessionWatcher]bool)}
+}
+
+func (w *sessionWatcher) match(e *rpc.SessionEvent) bool {
+	return (w.instanceId == "" || w.instanceId == e.InstanceId) && w.principal.CanAccess(e.InstanceId)
+}
+
+func (h *sessionEventHub) publish(s *RtpMediaSession, eventType rpc.SessionEventType) {
+	info := s.GetInfo(false)
+	e := &rpc.SessionEvent{
+		Type:       eventType,
+		SessionId:  info.SessionId,
+		InstanceId: info.InstanceId,
+		Time:       time.Now().UnixMilli(),
+		Streams:    info.Streams,
+	}
+	if eventType == rpc.SessionEventType_SESSION_EVENT_STOPPED {
+		e.Reason = info.StopReason
+	}
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.seq++
+	e.Seq = h.seq
+	h.backlog = append(h.backlog, e)
+	if len(h.backlog) >= 2*sessionEventBacklog {
+		h.backlog = append([]*rpc.SessionEvent(nil), h.backlog[len(h.backlog)-sessionEventBacklog:]...)
+	}
+	for w := range h.watchers {
+		if !w.match(e) {
+			continue
+		}
+		select {
+		case w.C <- e:
+		default:
+			logger.Warnf("session watcher of instance(%v) falls behind at seq %v", w.instanceId, e.Seq)
+			close(w.C)
+			delete(h.watchers, w)
+		}
+	}
+}
+
+// watch registers a watcher and returns buffered events after seq it should catch up with first. it fails if
+// events after seq are no longer buffered
+func (h *sessionEventHub) watch(p *Principal, instanceId string, afterSeq uint64) (w *sessionWatcher,
+	replay []*rpc.SessionEvent, err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	backlog := h.backlog
+	if len(backlog) > sessionEventBacklog {
+		backlog = backlog[len(backlog)-sessionEventBacklog:]
+	}
+	if afterSeq > h.seq {
+		// sequence numbers are from the previous run of server
+		afterSeq = 0
+	} else if afterSeq == 0 {
+		afterSeq = h.seq
+	} else if len(backlog) > 0 && afterSeq+1 < backlog[0].Seq {
+		return nil, nil, errWatchOutOfRange(afterSeq, backlog[0].Seq)
+	}
+	w = &sessionWatcher{principal: p, instanceId: instanceId, C: make(chan *rpc.SessionEvent, sessionWatcherBuffer)}
+	for _, e := range backlog {
+		if e.Seq > afterSeq && w.match(e) {
+			replay = append(replay, e)
+		}
+	}
+	h.watchers[w] = true
+	return
+}
+
+func (h *sessionEventHub) unwatch(w *sessionWatcher) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.watchers[w] {
+		close(w.C)
+		delete(h.watchers, w)
+	}
+}
+
+// *** SessionListener
+
+func (h *sessionEventHub) OnSessionCreated(s *RtpMediaSession) {
+	h.publish(s, rpc.SessionEventType_SESSION_EVENT_CREATED)
+}
+
+func (h *sessionEventHub) OnSessionUpdated(s *RtpMediaSession) {
+	h.publish(s, rpc.SessionEventType_SESSION_EVENT_UPDATED)
+}
+
+func (h *sessionEventHub) OnSessionStarted(s *RtpMediaSession) {
+	h.publish(s, rpc.SessionEventType_SESSION_EVENT_STARTED)
+}
+
+func (h *sessionEventHub) OnSessionStopped(s *RtpMediaSession) {
+	h.publish(s, rpc.SessionEventType_SESSION_EVENT_STOPPED)
+}
@@ -1,21 +1,39 @@
 package server
 
 import (
+	"encoding/base64"
 	"errors"
 	"fmt"
-	"github.com/appcrash/GoRTP/rtp"
+	"github.com/appcrash/media/server/channel"
 	"github.com/appcrash/media/server/comp"
+	"github.com/appcrash/media/server/dtmf"
 	"github.com/appcrash/media/server/event"
 	"github.com/appcrash/media/server/rpc"
+	"github.com/appcrash/media/server/srtp"
 	"net"
+	"sort"
 	"strconv"
+	"strings"
 	"sync/atomic"
+	"time"
 )
 
 type SessionIdType uint32
 
 var sessionIdCounter uint32 // fetch new id from here, use atomic increment
 
+const (
+	dtmfPacketInterval = 20 * time.Millisecond
+	defaultLatchWindow = 10 * time.Second
+	nbStreamLoop       = 3 // rtcp receive, rtp receive and rtp send loop of every stream
+)
+
+var srtpProfiles = map[rpc.SrtpProfile]srtp.Profile{
+	rpc.SrtpProfile_AES_CM_128_HMAC_SHA1_80: srtp.ProfileAesCm128HmacSha1_80,
+	rpc.SrtpProfile_AES_CM_128_HMAC_SHA1_32: srtp.ProfileAesCm128HmacSha1_32,
+	rpc.SrtpProfile_AEAD_AES_128_GCM:        srtp.ProfileAeadAes128Gcm,
+}
+
 func (id SessionIdType) String() string {
 	// math.MaxUint32 == 4294967295, max 10 zero ...
 	return fmt.Sprintf("%010d", id)
@@ -44,50 +62,53 @@ func profileOfCodec(c rpc.CodecType) (profile string) {
 	return
 }
 
-func NewRtpMediaSession(localIp, remoteIp *net.IPAddr, localPort, remotePort uint16,
-	codecInfos []*rpc.CodecInfo, gd string, graph *event.Graph) (s *RtpMediaSession, err error) {
-	sid := SessionIdType(atomic.AddUint32(&sessionIdCounter, 1))
+// NewRtpMediaSession creates a session of streams, each of which listens on the local port of the same index
+func NewRtpMediaSession(localIp, remoteIp *net.IPAddr, localPorts []uint16, streamParams []*rpc.StreamParam,
+	instanceId, gd string, graph *event.Graph) (s *RtpMediaSession, err error) {
+	return newRtpMediaSession(nextSessionId(), localIp, remoteIp, localPorts, streamParams, instanceId, gd, graph)
+}
+
+func nextSessionId() SessionIdType {
+	return SessionIdType(atomic.AddUint32(&sessionIdCounter, 1))
+}
 
-	composer := comp.NewSessionComposer(sid.String(), "")
+// newRtpMediaSession creates session of the given id, recovered session keeps its previous id
+func newRtpMediaSession(sid SessionIdType, localIp, remoteIp *net.IPAddr, localPorts []uint16,
+	streamParams []*rpc.StreamParam, instanceId, gd string, graph *event.Graph) (s *RtpMediaSession, err error) {
+	if len(streamParams) == 0 || len(localPorts) != len(streamParams) {
+		return nil, fmt.Errorf("create session with %v streams but %v local ports", len(streamParams), len(localPorts))
+	}
+
+	composer := comp.NewSessionComposer(sid.String(), instanceId)
 	if err = composer.ParseGraphDescription(gd); err != nil {
 		logger.Errorf("parse graph error: %v", err)
-		return nil, errors.New("composer parse graph description failed")
+		return nil, errInvalidArgument("graph_desc", "composer parse graph description failed: %v",
+			strings.TrimSpace(err.Error()))
 	}
 	s = &RtpMediaSession{
 		sessionId:  sid,
 		localIp:    localIp,
-		localPort:  localPort,
-		remoteIp:   remoteIp,
-		remotePort: remotePort,
-		instanceId: "",
+		instanceId: instanceId,
 
 		// use buffered version to avoid deadlock
-		doneC:  make(chan string, 3),
+		doneC:  make(chan string, nbStreamLoop*len(streamParams)),
 		status: sessionStatusCreated,
 
-		composer: composer,
-		graph:    graph,
+		composer:  composer,
+		graphDesc: gd,
+		graph:     graph,
 	}
 
-	for _, ci := range codecInfos {
-		switch ci.PayloadType {
-		case rpc.CodecType_PCM_ALAW, rpc.CodecType_AMRNB, rpc.CodecType_AMRWB, rpc.CodecType_H264, rpc.CodecType_EVS:
-			if s.avPayloadNumber != 0 {
-				err = fmt.Errorf("create session with more than one audio/video type:"+
-					" previous number:%v, this number:%v", s.avPayloadNumber, ci.PayloadNumber)
-				return
-			}
-			s.avPayloadNumber = uint8(ci.PayloadNumber)
-			s.avPayloadCodec = ci.PayloadType
-			s.avCodecParam = ci.CodecParam
-		case rpc.CodecType_TELEPHONE_EVENT_8K, rpc.CodecType_TELEPHONE_EVENT_16K:
-			s.telephoneEventPayloadNumber = uint8(ci.PayloadNumber)
-			s.telephoneEventPayloadCodec = ci.PayloadType
-			s.telephoneEventCodecParam = ci.CodecParam
+	for i, param := range streamParams {
+		var ms *mediaStream
+		if ms, err = newMediaStream(s, localPorts[i], remoteIp, param); err != nil {
+			return
 		}
-	}
-	if s.avPayloadNumber == 0 {
-		err = errors.New("create session without any audio/video codec info")
+		if s.getStream(ms.name) != nil {
+			err = fmt.Errorf("create session with duplicated stream name: %v", ms.name)
+			return
+		}
+		s.streams = append(s.streams, ms)
 	}
 
 	// everything is checked, setup the watchdog
@@ -95,85 +116,404 @@ func NewRtpMediaSession(localIp, remoteIp *net.IPAddr, localPort, remotePort uin
 	return
 }
 
-func (s *RtpMediaSession) setupGraph() error {
-	if err := s.composer.ComposeNodes(s.graph); err != nil {
+func sessionStatusToRpc(status int) rpc.SessionStatus {
+	switch status {
+	case sessionStatusStarted:
+		return rpc.SessionStatus_SESSION_STARTED
+	case sessionStatusStopped:
+		return rpc.SessionStatus_SESSION_STOPPED
+	}
+	return rpc.SessionStatus_SESSION_CREATED
+}
+
+// getStream returns stream of name, or the first stream if name is empty
+func (s *RtpMediaSession) getStream(name string) *mediaStream {
+	if name == "" && len(s.streams) > 0 {
+		return s.streams[0]
+	}
+	for _, ms := range s.streams {
+		if ms.name == name {
+			return ms
+		}
+	}
+	return nil
+}
+
+// streamOfNode returns the stream a provider/consumer node binds to, the first stream by default
+func (s *RtpMediaSession) streamOfNode(name string, node comp.SessionAware) (*mediaStream, error) {
+	var streamName string
+	if binder := comp.NodeTo[MediaStreamBinder](node); binder != nil {
+		streamName = binder.MediaStream()
+	}
+	if ms := s.getStream(streamName); ms != nil {
+		return ms, nil
+	}
+	return nil, fmt.Errorf("session(%v) node(%v) binds to unknown stream(%v)", s.sessionId, name, streamName)
+}
+
+// dtmfStream returns the stream dtmf nodes bind to if they don't specify one, i.e. the first stream with telephone
+// event negotiated
+func (s *RtpMediaSession) dtmfStream(name string, node comp.SessionAware) (*mediaStream, error) {
+	if binder := comp.NodeTo[MediaStreamBinder](node); binder != nil && binder.MediaStream() != "" {
+		return s.streamOfNode(name, node)
+	}
+	for _, ms := range s.streams {
+		if ms.dtmfGenerator != nil {
+			return ms, nil
+		}
+	}
+	return s.streams[0], nil
+}
+
+func (s *RtpMediaSession) setupGraph() (err error) {
+	if err = s.composer.ComposeNodes(s.graph); err != nil {
 		return err
 	}
-	// search rtp packet provider and consumer, this is the edge between rtp stack and graph
+	// search rtp packet provider and consumer of every stream, this is the edge between rtp stack and graph
 	s.composer.IterateNode(func(name string, node comp.SessionAware) {
-		if s.pullC != nil && s.handleC != nil {
+		var ms *mediaStream
+		provider := comp.NodeTo[RtpPacketProvider](node)
+		consumer := comp.NodeTo[RtpPacketConsumer](node)
+		if err != nil || (provider == nil && consumer == nil) {
 			return
 		}
-		if provider := comp.NodeTo[RtpPacketProvider](node); provider != nil {
-			if s.pullC != nil {
-				logger.Errorf("session(%v) has more than one rtp packet provider", s.GetSessionId())
+		if ms, err = s.streamOfNode(name, node); err != nil {
+			return
+		}
+		if provider != nil {
+			if ms.pullC != nil {
+				logger.Errorf("session(%v) stream(%v) has more than one rtp packet provider", s.sessionId, ms.name)
 			} else {
-				s.pullC = provider.PullPacketChannel()
+				ms.pullC = provider.PullPacketChannel()
 			}
 		}
-
-		if consumer := comp.NodeTo[RtpPacketConsumer](node); consumer != nil {
-			if s.handleC != nil {
-				logger.Errorf("session(%v) has more than one rtp packet consumer", s.GetSessionId())
+		if consumer != nil {
+			if ms.handleC != nil {
+				logger.Errorf("session(%v) stream(%v) has more than one rtp packet consumer", s.sessionId, ms.name)
 			} else {
-				s.handleC = consumer.HandlePacketChannel()
+				ms.handleC = consumer.HandlePacketChannel()
 			}
 		}
 	})
-	if s.pullC == nil || s.handleC == nil {
-		return fmt.Errorf("session(%v) has invalid rtp provider(with channel:%v) or consumer(with channel:%v) ",
-			s.GetSessionId(), s.pullC, s.handleC)
+	if err != nil {
+		return
+	}
+	// dtmf consumer and provider are optional
+	s.composer.IterateNode(func(name string, node comp.SessionAware) {
+		var ms *mediaStream
+		consumer := comp.NodeTo[DtmfConsumer](node)
+		provider := comp.NodeTo[DtmfProvider](node)
+		if err != nil || (provider == nil && consumer == nil) {
+			return
+		}
+		if ms, err = s.dtmfStream(name, node); err != nil {
+			return
+		}
+		if consumer != nil {
+			if ms.dtmfC != nil {
+				logger.Errorf("session(%v) stream(%v) has more than one dtmf consumer", s.sessionId, ms.name)
+			} else {
+				ms.dtmfC = consumer.HandleDtmfChannel()
+			}
+		}
+		if provider != nil {
+			if ms.dtmfPullC != nil {
+				logger.Errorf("session(%v) stream(%v) has more than one dtmf provider", s.sessionId, ms.name)
+			} else {
+				ms.dtmfPullC = provider.PullDtmfChannel()
+			}
+		}
+	})
+	if err != nil {
+		return
+	}
+	// direction consumers are optional too
+	s.composer.IterateNode(func(name string, node comp.SessionAware) {
+		var ms *mediaStream
+		consumer := comp.NodeTo[MediaDirectionConsumer](node)
+		if err != nil || consumer == nil {
+			return
+		}
+		if ms, err = s.streamOfNode(name, node); err != nil {
+			return
+		}
+		ms.directionC = append(ms.directionC, consumer.HandleMediaDirectionChannel())
+	})
+	if err != nil {
+		return
+	}
+	for _, ms := range s.streams {
+		if ms.pullC == nil || ms.handleC == nil {
+			return fmt.Errorf("session(%v) stream(%v) has invalid rtp provider(with channel:%v) or "+
+				"consumer(with channel:%v) ", s.sessionId, ms.name, ms.pullC, ms.handleC)
+		}
+	}
+	for _, ms := range s.streams {
+		ms.notifyDirection()
+	}
+	return nil
+}
+
+// graphNodes returns nodes of parsed graph description in topological order
+func (s *RtpMediaSession) graphNodes() (nodes []*rpc.GraphNode) {
+	for _, nd := range s.composer.GetSortedNodes() {
+		node := &rpc.GraphNode{Name: nd.Name, Type: nd.Type, Props: make(map[string]string)}
+		for _, p := range nd.Props {
+			node.Props[p.Key] = fmt.Sprint(p.Value)
+		}
+		for _, dep := range nd.Deps {
+			node.Receivers = append(node.Receivers, dep.LinkTo.Name)
+		}
+		nodes = append(nodes, node)
+	}
+	return
+}
+
+// liveNodes returns nodes running in event graph ordered by name, along with streams they bind to
+func (s *RtpMediaSession) liveNodes() (nodes []*rpc.LiveNode) {
+	s.composer.IterateNode(func(name string, node comp.SessionAware) {
+		live := &rpc.LiveNode{Name: name, Type: node.GetNodeTypeName()}
+		var ms *mediaStream
+		if comp.NodeTo[RtpPacketProvider](node) != nil || comp.NodeTo[RtpPacketConsumer](node) != nil ||
+			comp.NodeTo[MediaDirectionConsumer](node) != nil {
+			ms, _ = s.streamOfNode(name, node)
+		} else if comp.NodeTo[DtmfProvider](node) != nil || comp.NodeTo[DtmfConsumer](node) != nil {
+			ms, _ = s.dtmfStream(name, node)
+		}
+		if ms != nil {
+			live.Stream = ms.name
+		}
+		nodes = append(nodes, live)
+	})
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+	return
+}
+
+// setupJitterBuffer places a jitter buffer between rtp stack and graph for every audio stream, it plays out packets
+// at codec cadence
+func (s *RtpMediaSession) setupJitterBuffer(param *rpc.JitterBufferParam) error {
+	if param == nil {
+		return nil
+	}
+	if param.GetMaxDelay() < param.GetMinDelay() {
+		return fmt.Errorf("jitter buffer max delay(%v) is less than min delay(%v)",
+			param.GetMaxDelay(), param.GetMinDelay())
+	}
+	for _, ms := range s.streams {
+		if !ms.isVideo() {
+			ms.setupJitterBuffer(param)
+		}
 	}
 	return nil
 }
 
-// activate carry out actual work, such as listen on udp port, create rtp stream, create event node instances and
+// decodeSrtpKey accepts key-params of sdes crypto attribute, such as "inline:{base64}|2^20|1:4" or just base64 part
+func decodeSrtpKey(key string, profile srtp.Profile) ([]byte, error) {
+	key = strings.TrimPrefix(key, "inline:")
+	if i := strings.IndexByte(key, '|'); i >= 0 {
+		key = key[:i]
+	}
+	b, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid srtp key: %v", err)
+	}
+	if len(b) != profile.MasterKeyLength() {
+		return nil, fmt.Errorf("srtp key length %v mismatches profile %v", len(b), profile)
+	}
+	return b, nil
+}
+
+// setupSrtp enables srtp if profile is given, then the rtp stack will be built upon srtp transport
+func (s *RtpMediaSession) setupSrtp(param *rpc.SrtpParam) error {
+	if param == nil || param.GetProfile() == rpc.SrtpProfile_SRTP_NONE {
+		return nil
+	}
+	profile, ok := srtpProfiles[param.GetProfile()]
+	if !ok {
+		return fmt.Errorf("unsupported srtp profile %v", param.GetProfile())
+	}
+	s.srtpProfile = profile
+	return s.updateSrtpKeys(param)
+}
+
+// updateSrtpKeys sets keys if provided, local key is generated if never set
+func (s *RtpMediaSession) updateSrtpKeys(param *rpc.SrtpParam) (err error) {
+	if s.srtpProfile == srtp.ProfileNone {
+		return errors.New("srtp is not enabled for this session")
+	}
+	if p := param.GetProfile(); p != rpc.SrtpProfile_SRTP_NONE && srtpProfiles[p] != s.srtpProfile {
+		return fmt.Errorf("srtp profile can not be changed from %v", s.srtpProfile)
+	}
+	var localKey, remoteKey []byte
+	if param.GetLocalKey() != "" {
+		if localKey, err = decodeSrtpKey(param.GetLocalKey(), s.srtpProfile); err != nil {
+			return
+		}
+	} else if s.srtpLocalKey == nil {
+		if localKey, err = srtp.GenerateMasterKey(s.srtpProfile); err != nil {
+			return
+		}
+	}
+	if param.GetRemoteKey() != "" {
+		if remoteKey, err = decodeSrtpKey(param.GetRemoteKey(), s.srtpProfile); err != nil {
+			return
+		}
+	}
+	if localKey != nil {
+		s.srtpLocalKey = localKey
+	}
+	if remoteKey != nil {
+		s.srtpRemoteKey = remoteKey
+	}
+	return
+}
+
+// setupSrtpContext derives session keys when starting, by then keys of both sides must be known
+func (s *RtpMediaSession) setupSrtpContext(transport *srtp.Transport) error {
+	if s.srtpRemoteKey == nil {
+		return errFailedPrecondition(s.sessionId, "srtp remote key is not set")
+	}
+	local, err := srtp.NewContext(s.srtpProfile, s.srtpLocalKey)
+	if err != nil {
+		return err
+	}
+	remote, err := srtp.NewContext(s.srtpProfile, s.srtpRemoteKey)
+	if err != nil {
+		return err
+	}
+	transport.SetContext(local, remote)
+	return nil
+}
+
+// setupLatch enables learning remote address from received packets
+func (s *RtpMediaSession) setupLatch(param *rpc.LatchParam) {
+	if param == nil {
+		return
+	}
+	s.latchWindow = time.Duration(param.GetWindow()) * time.Millisecond
+	if s.latchWindow == 0 {
+		s.latchWindow = defaultLatchWindow
+	}
+}
+
+// activate carry out actual work, such as listen on udp ports, create rtp streams, create event node instances and
 // add them to graph
 func (s *RtpMediaSession) activate() (err error) {
-	var tpLocal *rtp.TransportUDP
-	var localPort = int(s.localPort)
 	if err = s.setupGraph(); err != nil {
 		return
 	}
-	if tpLocal, err = rtp.NewTransportUDP(s.localIp, localPort, ""); err != nil {
-		return
+	for _, ms := range s.streams {
+		if err = ms.activate(); err != nil {
+			return
+		}
 	}
-	s.rtpSession = rtp.NewSession(tpLocal, tpLocal)
-	strLocalIdx, errStr := s.rtpSession.NewSsrcStreamOut(&rtp.Address{
-		IPAddr:   s.localIp.IP,
-		DataPort: localPort,
-		CtrlPort: 1 + localPort,
-		Zone:     "",
-	}, 0, 0)
-	if errStr != "" {
-		return errors.New(string(errStr))
+
+	s.watchdog.start()
+	return nil
+}
+
+// UpdateRtpParams switches the sending codec of stream to another negotiated one, the first stream if name is
+// empty. it takes effect immediately even if session is started
+func (s *RtpMediaSession) UpdateRtpParams(name string, payloadNumber uint8) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.status == sessionStatusStopped {
+		return errSessionStatus(s.sessionId, s.status, "update")
 	}
-	if profile := profileOfCodec(s.avPayloadCodec); profile != "" {
-		s.rtpSession.SsrcStreamOutForIndex(strLocalIdx).SetProfile(profile, byte(s.avPayloadNumber))
-		s.rtpSessionLocalId = strLocalIdx //add by sean
-	} else {
-		return errors.New("unsupported rtp payload profile")
+	ms := s.getStream(name)
+	if ms == nil {
+		return fmt.Errorf("session(%v) has no stream(%v)", s.sessionId, name)
 	}
+	if _, ok := ms.codecs[payloadNumber]; !ok && s.status == sessionStatusCreated {
+		// answer may use another payload number for the offered codec, legacy clients update it this way
+		ms.renumberCodec(payloadNumber)
+		return nil
+	}
+	return ms.switchCodec(payloadNumber)
+}
 
-	//s.watchdog.start()
+// updatePeer changes remote address and srtp keys of an unstarted session, fields not set in param are kept.
+// a started session refuses to change them as media is flowing already, only codec and direction can be updated
+func (s *RtpMediaSession) updatePeer(param *rpc.UpdateParam, remoteIp *net.IPAddr) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	switch s.status {
+	case sessionStatusStopped:
+		return errSessionStatus(s.sessionId, s.status, "update")
+	case sessionStatusCreated:
+	default:
+		if changesPeer(param) {
+			return errFailedPrecondition(s.sessionId, "peer address and srtp keys of started session(%v) "+
+				"can not be updated", s.sessionId)
+		}
+		return nil
+	}
+	if param.GetSrtp() != nil {
+		if err := s.updateSrtpKeys(param.GetSrtp()); err != nil {
+			return err
+		}
+	}
+	if remoteIp != nil {
+		for _, ms := range s.streams {
+			ms.remoteIp = remoteIp
+		}
+	}
+	if port := param.GetPeerPort(); port != 0 {
+		s.streams[0].remotePort = uint16(port)
+	}
+	for _, su := range param.GetStreams() {
+		if port := su.GetPeerPort(); port != 0 {
+			s.getStream(su.GetName()).remotePort = uint16(port)
+		}
+	}
 	return nil
 }
 
-//author:sean. purpose:update rtp params.but does not use
-func (s *RtpMediaSession) UpdateRtpParams() (err error) {
-	if s.rtpSession == nil {
-		logger.Errorln("Please initialize mediaSession before update payload")
+func changesPeer(param *rpc.UpdateParam) bool {
+	if param.GetPeerIp() != "" || param.GetPeerPort() != 0 || param.GetSrtp() != nil {
+		return true
 	}
+	for _, su := range param.GetStreams() {
+		if su.GetPeerPort() != 0 {
+			return true
+		}
+	}
+	return false
+}
 
-	if profile := profileOfCodec(s.avPayloadCodec); profile != "" {
-		ssrcStream := s.rtpSession.SsrcStreamOutForIndex(s.rtpSessionLocalId)
-		ssrcStream.SetProfile(profile, byte(s.avPayloadNumber))
-		logger.Infof("update payload_number.current=%v", ssrcStream.PayloadTypeNumber())
-	} else {
-		return errors.New("unsupported rtp payload profile")
+// updateDirection holds or resumes streams, under session mutex so graph nodes see changes in order
+func (s *RtpMediaSession) updateDirection(param *rpc.UpdateParam) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.streams[0].setDirection(param.GetDirection())
+	for _, su := range param.GetStreams() {
+		s.getStream(su.GetName()).setDirection(su.GetDirection())
 	}
+}
 
-	return nil
+func (s *RtpMediaSession) notifyInstanceOfDtmf(d dtmf.Digit) {
+	if err := channel.GetSystemChannel().NotifyInstance(&rpc.SystemEvent{
+		Cmd:        rpc.SystemCommand_DTMF,
+		InstanceId: s.instanceId,
+		SessionId:  s.sessionId.String(),
+		Event:      fmt.Sprintf("digit=%c;duration=%v", d.Digit, d.Duration.Milliseconds()),
+	}); err != nil {
+		logger.Errorf("session(%v) notify dtmf error: %v", s.sessionId, err)
+	}
+}
+
+// notifyInstanceOfStop tells instance the session is stopped without StopSession called
+func (s *RtpMediaSession) notifyInstanceOfStop() {
+	if err := channel.GetSystemChannel().NotifyInstance(&rpc.SystemEvent{
+		Cmd:        rpc.SystemCommand_SESSION_TERMINATED,
+		InstanceId: s.instanceId,
+		SessionId:  s.sessionId.String(),
+		Event:      fmt.Sprintf("reason=%v", s.GetStopReason()),
+	}); err != nil {
+		logger.Warnf("session(%v) notify stop error: %v", s.sessionId, err)
+	}
 }
 
 func (s *RtpMediaSession) onSystemEvent(se *rpc.SystemEvent) {
@@ -3,15 +3,20 @@ package server
 import (
 	"context"
 	"github.com/appcrash/GoRTP/rtp"
+	"github.com/appcrash/media/server/comp"
+	"github.com/appcrash/media/server/dtmf"
 	"github.com/appcrash/media/server/prom"
+	"github.com/appcrash/media/server/rpc"
 	"github.com/appcrash/media/server/utils"
 	"github.com/prometheus/client_golang/prometheus"
 	"runtime/debug"
+	"time"
 )
 
 // receive rtcp packet
-func (s *RtpMediaSession) receiveRtcpLoop(ctx context.Context) {
-	rtcpReceiver := s.rtpSession.CreateCtrlEventChan()
+func (ms *mediaStream) receiveRtcpLoop(ctx context.Context) {
+	s := ms.session
+	rtcpReceiver := ms.rtpSession.CreateCtrlEventChan()
 	gauge := prom.RtpSessionGoroutine.With(prometheus.Labels{"type": "recv_ctrl"})
 	gauge.Inc()
 
@@ -20,6 +25,8 @@ func (s *RtpMediaSession) receiveRtcpLoop(ctx context.Context) {
 		logger.Debugf("session:%v stop ctrl recv", s.GetSessionId())
 		s.doneC <- "done"
 	}()
+	statsTicker := time.NewTicker(SessionStatsPeriod)
+	defer statsTicker.Stop()
 	cancelC := ctx.Done()
 	for {
 		select {
@@ -28,21 +35,36 @@ func (s *RtpMediaSession) receiveRtcpLoop(ctx context.Context) {
 				// RTP stack closed rtcp channel, just return
 				return
 			}
+			s.watchdog.reportLoopInfo(rtcpLoop)
 			for _, evt := range eventArray {
-				if evt.EventType == rtp.RtcpBye {
+				switch evt.EventType {
+				case rtp.RtcpSR:
+					// index of input stream that sends the report
+					if str := ms.rtpSession.SsrcStreamInForIndex(evt.Index); str != nil {
+						ms.stats.onSenderReport(evt.Ssrc, str.SenderInfoData)
+					}
+				case rtp.RtcpRR:
+					// index of output stream that the report block is about
+					if str := ms.rtpSession.SsrcStreamOutForIndex(evt.Index); str != nil {
+						ms.stats.onReceiverReport(evt.Ssrc, str.RecvReportData, str.SenderInfoData, time.Now())
+					}
+				case rtp.RtcpBye:
 					// peer send bye, notify data send/receive loop to stop
 					logger.Debugf("session: %v rtp peer says bye", s.sessionId)
-					go s.Stop() // CAVEAT: don't call Stop() in this goroutine directly
+					go s.terminate(rpc.StopReason_RTCP_BYE) // CAVEAT: don't stop in this goroutine directly
 					return
 				}
 			}
+		case <-statsTicker.C:
+			ms.stats.publish(s.sessionId.String(), s.instanceId, ms.name)
 		case <-cancelC:
 			return
 		}
 	}
 }
 
-func (s *RtpMediaSession) receiveRtpLoop(ctx context.Context) {
+func (ms *mediaStream) receiveRtpLoop(ctx context.Context) {
+	s := ms.session
 	gauge := prom.RtpSessionGoroutine.With(prometheus.Labels{"type": "recv"})
 	gauge.Inc()
 	// Create and store the data receive channel.
@@ -56,23 +78,33 @@ func (s *RtpMediaSession) receiveRtpLoop(ctx context.Context) {
 	defer func() {
 		gauge.Dec()
 		logger.Debugf("session:%v stop local receive", s.GetSessionId())
-		if s.handleC != nil {
+		if ms.handleC != nil {
 			// notify packet handler
-			close(s.handleC)
-			s.handleC = nil
+			close(ms.handleC)
+			ms.handleC = nil
+		}
+		if ms.dtmfC != nil {
+			close(ms.dtmfC)
+			ms.dtmfC = nil
 		}
 		s.doneC <- "done"
 	}()
 
-	if s.handleC == nil {
+	if ms.handleC == nil {
 		logger.Infof("session:%v has no rtp handling channel, stop local receive early", s.sessionId)
 		return
 	}
 
 	s.watchdog.reportLoopInfo(receiveLoop)
-	rtpSession := s.rtpSession
+	rtpSession := ms.rtpSession
 	dataReceiver := rtpSession.CreateDataReceiveChan()
 	cancelC := ctx.Done()
+	var playoutC <-chan time.Time // keep nil if no jitter buffer
+	if ms.jitterBuffer != nil {
+		ticker := time.NewTicker(ms.jitterBuffer.Step())
+		defer ticker.Stop()
+		playoutC = ticker.C
+	}
 	var nbPacket int
 	for {
 		select {
@@ -82,11 +114,27 @@ func (s *RtpMediaSession) receiveRtpLoop(ctx context.Context) {
 				return
 			}
 
-			// nonblock push received data to handler
 			pl := utils.NewPacketListFromRtpPacket(rp)
-			select {
-			case s.handleC <- pl:
+			now := time.Now()
+			ms.lastReceived.Store(now.UnixNano())
+			if pl != nil {
+				pl.Codec = ms.codecOf(pl.PayloadType)
+				ms.stats.onPacket(pl.Ssrc, pl.Sequence, pl.Pts, now)
+			}
+			switch {
+			case !ms.receiving():
+				// media is discarded by direction, but still counted so that rtcp reports stay correct
+			case pl != nil && ms.dtmfDecoder != nil && pl.PayloadType == ms.telephoneEventPayloadNumber:
+				// telephone events bypass jitter buffer and never go to rtp packet consumer
+				ms.handleTelephoneEvent(pl)
+			case ms.jitterBuffer != nil:
+				ms.jitterBuffer.Push(pl, now)
 			default:
+				// nonblock push received data to handler
+				select {
+				case ms.handleC <- pl:
+				default:
+				}
 			}
 			nbPacket++
 			if nbPacket > ReportInfoPacketInterval {
@@ -97,13 +145,21 @@ func (s *RtpMediaSession) receiveRtpLoop(ctx context.Context) {
 			// don't free packet, let it be GCed, as GoRTP will reuse this packet along with its buffer
 			// which may be hold by other packet-list objects
 			// rp.FreePacket()
+		case <-playoutC:
+			if pl := ms.jitterBuffer.Pop(); pl != nil {
+				select {
+				case ms.handleC <- pl:
+				default:
+				}
+			}
 		case <-cancelC:
 			return
 		}
 	}
 }
 
-func (s *RtpMediaSession) sendRtpLoop(ctx context.Context) {
+func (ms *mediaStream) sendRtpLoop(ctx context.Context) {
+	s := ms.session
 	gauge := prom.RtpSessionGoroutine.With(prometheus.Labels{"type": "send"})
 	gauge.Inc()
 
@@ -119,40 +175,93 @@ func (s *RtpMediaSession) sendRtpLoop(ctx context.Context) {
 		s.doneC <- "done"
 	}()
 
-	if s.pullC == nil {
+	if ms.pullC == nil {
 		logger.Infof("session:%v has no rtp pulling channel, stop local send early", s.sessionId)
 	}
 
 	var nbPacket int
+	var lastPts uint32        // timestamp of last data packet
+	var lastPtsTime time.Time // when last data packet is sent
+	var dtmfC <-chan *comp.DtmfMessage
+	var dtmfTicker *time.Ticker
+	var dtmfTickC <-chan time.Time // keep nil if no digit to send
+	if ms.dtmfGenerator != nil {
+		dtmfC = ms.dtmfPullC
+	} else if ms.dtmfPullC != nil {
+		logger.Warnf("session:%v has dtmf provider but telephone event is not negotiated", s.sessionId)
+	}
+	defer func() {
+		if dtmfTicker != nil {
+			dtmfTicker.Stop()
+		}
+	}()
+	// current timestamp of data stream, telephone events use it as their timestamps. extrapolated at clock rate of
+	// the data stream as telephone-event may be negotiated with another rate
+	currentTimestamp := func() uint32 {
+		if lastPtsTime.IsZero() {
+			return lastPts
+		}
+		elapsed := time.Since(lastPtsTime)
+		return lastPts + uint32(elapsed*time.Duration(clockRateOfCodec(ms.codec().PayloadType))/time.Second)
+	}
 	cancelC := ctx.Done()
 	for {
 		select {
+		case digit, more := <-dtmfC:
+			if !more {
+				dtmfC = nil
+				continue
+			}
+			ms.dtmfGenerator.Enqueue(dtmf.Digit{Digit: digit.Digit, Volume: digit.Volume, Duration: digit.Duration})
+			if dtmfTicker == nil {
+				dtmfTicker = time.NewTicker(ms.dtmfGenerator.Interval())
+				dtmfTickC = dtmfTicker.C
+			}
+		case <-dtmfTickC:
+			dtmfPacket, ok := ms.dtmfGenerator.Tick(currentTimestamp())
+			if ok && ms.rtpSession != nil && ms.sending() {
+				packet := ms.rtpSession.NewDataPacket(dtmfPacket.Timestamp)
+				packet.SetMarker(dtmfPacket.Marker)
+				packet.SetPayload(dtmfPacket.Payload.Marshal())
+				packet.SetPayloadType(ms.telephoneEventPayloadNumber)
+				if _, err := ms.rtpSession.WriteData(packet); err != nil {
+					s.watchdog.reportLoopError(sendLoop, err)
+				}
+				ms.lastSent.Store(time.Now().UnixNano())
+			}
+			if !ms.dtmfGenerator.Busy() {
+				dtmfTicker.Stop()
+				dtmfTicker, dtmfTickC = nil, nil
+			}
 		// pump data out from graph
-		case packetList, more := <-s.pullC:
+		case packetList, more := <-ms.pullC:
 			if !more {
 				return
 			}
 
-			if s.rtpSession == nil {
+			if ms.rtpSession == nil {
 				return
 			}
-			if packetList == nil {
+			if packetList == nil || !ms.sending() {
+				// keep draining graph while media is suppressed by direction
 				continue
 			}
 
 			// send all packets based on RtpPacketList
 			// for video, a frame can have more than one packet with same timestamp
+			current := ms.codec()
 			packetList.Iterate(func(p *utils.RtpPacketList) {
 				payload, _, pts, mark := p.Payload, p.PayloadType, p.Pts, p.Marker
 				if payload != nil {
-					packet := s.rtpSession.NewDataPacket(pts)
+					packet := ms.rtpSession.NewDataPacket(pts)
 					packet.SetMarker(mark)
 					packet.SetPayload(payload)
-					//maybe update pt by sip/sdp after create graph
-					packet.SetPayloadType(s.avPayloadNumber)
-					if _, err := s.rtpSession.WriteData(packet); err != nil {
+					packet.SetPayloadType(ms.payloadTypeOf(p, current))
+					if _, err := ms.rtpSession.WriteData(packet); err != nil {
 						s.watchdog.reportLoopError(sendLoop, err)
 					}
+					lastPts, lastPtsTime = pts, time.Now()
+					ms.lastSent.Store(lastPtsTime.UnixNano())
 				}
 				nbPacket++
 			})
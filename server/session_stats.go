@@ -0,0 +1,219 @@
+package server
+
+import (
+	"github.com/appcrash/GoRTP/rtp"
+	"github.com/appcrash/media/codec"
+	"github.com/appcrash/media/server/prom"
+	"github.com/appcrash/media/server/rpc"
+	"github.com/appcrash/media/server/stats"
+	"github.com/prometheus/client_golang/prometheus"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SessionStatsPeriod is the report interval of session statistics, prometheus gauges are refreshed by then
+const SessionStatsPeriod = 5 * time.Second
+
+type streamStats struct {
+	stats.Report
+	outbound                bool
+	sentPackets, sentOctets uint32
+}
+
+// mediaStats collects statistics of all rtp streams(ssrc) in a media stream, inbound streams are measured by receive
+// loop while outbound streams are updated by receiver reports from peer
+type mediaStats struct {
+	mutex sync.Mutex
+
+	clockRate uint32
+	delay     time.Duration // packetization delay, part of one-way delay when estimating mos
+	model     stats.EModel
+	hasModel  bool // quality of video is not estimated
+
+	receivers   map[uint32]*stats.Receiver
+	senderInfos map[uint32]rtp.SenderInfoData // sender reports of inbound streams
+	outbound    map[uint32]*streamStats
+	lastSsrc    uint32 // inbound stream received most recently
+	rtt         time.Duration
+}
+
+func clockRateOfCodec(c rpc.CodecType) uint32 {
+	switch c {
+	case rpc.CodecType_AMRWB, rpc.CodecType_EVS, rpc.CodecType_TELEPHONE_EVENT_16K:
+		return 16000
+	case rpc.CodecType_H264:
+		return 90000
+	}
+	return 8000
+}
+
+func eModelOfCodec(c rpc.CodecType) (stats.EModel, bool) {
+	switch c {
+	case rpc.CodecType_AMRNB:
+		return stats.EModelAmrNb, true
+	case rpc.CodecType_AMRWB, rpc.CodecType_EVS:
+		return stats.EModelAmrWb, true
+	case rpc.CodecType_H264:
+		return stats.EModel{}, false
+	}
+	return stats.EModelG711, true
+}
+
+func newMediaStats(c rpc.CodecType) *mediaStats {
+	model, hasModel := eModelOfCodec(c)
+	return &mediaStats{
+		clockRate:   clockRateOfCodec(c),
+		delay:       time.Duration(codec.GetCodecTimeStep(c)) * time.Millisecond,
+		model:       model,
+		hasModel:    hasModel,
+		receivers:   make(map[uint32]*stats.Receiver),
+		senderInfos: make(map[uint32]rtp.SenderInfoData),
+		outbound:    make(map[uint32]*streamStats),
+	}
+}
+
+// setCodec changes codec used by estimating, streams received before keep their clock rates
+func (ss *mediaStats) setCodec(c rpc.CodecType) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	ss.clockRate = clockRateOfCodec(c)
+	ss.delay = time.Duration(codec.GetCodecTimeStep(c)) * time.Millisecond
+	ss.model, ss.hasModel = eModelOfCodec(c)
+}
+
+func (ss *mediaStats) onPacket(ssrc uint32, seq uint16, timestamp uint32, arrival time.Time) {
+	ss.mutex.Lock()
+	receiver, ok := ss.receivers[ssrc]
+	if !ok {
+		receiver = stats.NewReceiver(ss.clockRate)
+		ss.receivers[ssrc] = receiver
+	}
+	ss.lastSsrc = ssrc
+	ss.mutex.Unlock()
+	receiver.Update(seq, timestamp, arrival)
+}
+
+func (ss *mediaStats) onSenderReport(ssrc uint32, info rtp.SenderInfoData) {
+	ss.mutex.Lock()
+	ss.senderInfos[ssrc] = info
+	ss.mutex.Unlock()
+}
+
+// onReceiverReport updates outbound stream of ssrc with report block from peer, as well as own sender counters
+func (ss *mediaStats) onReceiverReport(ssrc uint32, rr rtp.RecvReportData, own rtp.SenderInfoData, now time.Time) {
+	report := stats.FromReceiverReport(ssrc, rr.FracLost, rr.PacketsLost, rr.Jitter, ss.clockRate)
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	if rtt, ok := stats.RoundTrip(now, rr.LastSr, rr.Dlsr); ok {
+		report.Rtt = rtt
+		ss.rtt = rtt
+	}
+	ss.outbound[ssrc] = &streamStats{
+		Report:      report,
+		outbound:    true,
+		sentPackets: own.SenderPacketCnt,
+		sentOctets:  own.SenderOctetCnt,
+	}
+}
+
+// streams returns inbound streams followed by outbound ones, must be called with mutex held
+func (ss *mediaStats) streams() (streams []*streamStats) {
+	for ssrc, receiver := range ss.receivers {
+		report := receiver.Report()
+		report.Ssrc = ssrc
+		info := ss.senderInfos[ssrc]
+		streams = append(streams, &streamStats{
+			Report:      report,
+			sentPackets: info.SenderPacketCnt,
+			sentOctets:  info.SenderOctetCnt,
+		})
+	}
+	for _, s := range ss.outbound {
+		streams = append(streams, s)
+	}
+	sort.Slice(streams, func(i, j int) bool {
+		if streams[i].outbound != streams[j].outbound {
+			return !streams[i].outbound
+		}
+		return streams[i].Ssrc < streams[j].Ssrc
+	})
+	return
+}
+
+// mos estimates quality of the inbound stream received most recently, must be called with mutex held
+func (ss *mediaStats) mos() float64 {
+	receiver, ok := ss.receivers[ss.lastSsrc]
+	if !ok || !ss.hasModel {
+		return 0
+	}
+	report := receiver.Report()
+	// jitter buffer is assumed to be twice of jitter
+	delay := ss.rtt/2 + 2*report.Jitter + ss.delay
+	return ss.model.Mos(delay, report.FractionLost)
+}
+
+// quality returns latest round-trip time and mos estimate
+func (ss *mediaStats) quality() (rtt time.Duration, mos float64) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	return ss.rtt, ss.mos()
+}
+
+// appendRpc appends statistics of all rtp streams to result, they are marked as part of media stream of name
+func (ss *mediaStats) appendRpc(result *rpc.SessionStats, name string) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	for _, s := range ss.streams() {
+		result.Streams = append(result.Streams, &rpc.StreamStats{
+			Ssrc:            s.Ssrc,
+			Outbound:        s.outbound,
+			SentPackets:     s.sentPackets,
+			SentOctets:      s.sentOctets,
+			ReceivedPackets: s.Received,
+			FractionLost:    float32(s.FractionLost),
+			CumulativeLost:  s.CumulativeLost,
+			Jitter:          float32(s.Jitter.Seconds() * 1000),
+			Rtt:             float32(s.Rtt.Seconds() * 1000),
+			Stream:          name,
+		})
+	}
+}
+
+// publish refreshes prometheus gauges then starts a new report interval
+func (ss *mediaStats) publish(sessionId, instanceId, name string) {
+	ss.mutex.Lock()
+	defer ss.mutex.Unlock()
+	labels := prometheus.Labels{"session_id": sessionId, "instance_id": instanceId, "stream": name}
+	for _, s := range ss.streams() {
+		if !s.outbound && s.Ssrc != ss.lastSsrc {
+			continue
+		}
+		direction := "inbound"
+		if s.outbound {
+			direction = "outbound"
+		}
+		streamLabels := prometheus.Labels{"session_id": sessionId, "instance_id": instanceId, "stream": name,
+			"direction": direction}
+		prom.RtpSessionFractionLost.With(streamLabels).Set(s.FractionLost)
+		prom.RtpSessionCumulativeLost.With(streamLabels).Set(float64(s.CumulativeLost))
+		prom.RtpSessionJitter.With(streamLabels).Set(s.Jitter.Seconds())
+	}
+	prom.RtpSessionRtt.With(labels).Set(ss.rtt.Seconds())
+	if mos := ss.mos(); mos > 0 {
+		prom.RtpSessionMos.With(labels).Set(mos)
+	}
+	for _, receiver := range ss.receivers {
+		receiver.Roll()
+	}
+}
+
+// unpublishStats removes gauges of all media streams in session
+func unpublishStats(sessionId string) {
+	labels := prometheus.Labels{"session_id": sessionId}
+	prom.RtpSessionFractionLost.DeletePartialMatch(labels)
+	prom.RtpSessionCumulativeLost.DeletePartialMatch(labels)
+	prom.RtpSessionJitter.DeletePartialMatch(labels)
+	prom.RtpSessionRtt.DeletePartialMatch(labels)
+	prom.RtpSessionMos.DeletePartialMatch(labels)
+}
@@ -0,0 +1,260 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/appcrash/media/server/rpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SessionRecord is what SessionStore keeps for a session, the session is made again from it after server restarts
+type SessionRecord struct {
+	SessionId  string
+	InstanceId string
+	LocalPorts []uint16 // reserved from port pool again when recovering
+	// Create has the srtp local key filled if it was generated, so that peer can still decrypt
+	Create  *rpc.CreateParam
+	Updates []*SessionUpdateRecord
+	Started bool
+}
+
+type SessionUpdateRecord struct {
+	Param *rpc.UpdateParam
+	// AfterStart update only switches codec and direction
+	AfterStart bool
+}
+
+// SessionStore persists records of alive sessions, methods are called concurrently
+type SessionStore interface {
+	// Save creates or replaces the record of session
+	Save(r *SessionRecord) error
+	// Delete is called once session stops, deleting a nonexistent record is not an error
+	Delete(sessionId string) error
+	// Load returns all records when server starts
+	Load() ([]*SessionRecord, error)
+}
+
+func newSessionRecord(s *RtpMediaSession, localPorts []uint16, param *rpc.CreateParam) *SessionRecord {
+	create := proto.Clone(param).(*rpc.CreateParam)
+	if create.Srtp != nil {
+		create.Srtp.LocalKey = s.GetSrtpLocalKey()
+	}
+	return &SessionRecord{
+		SessionId:  s.sessionId.String(),
+		InstanceId: s.instanceId,
+		LocalPorts: append([]uint16(nil), localPorts...),
+		Create:     create,
+	}
+}
+
+func (s *RtpMediaSession) recordUpdate(param *rpc.UpdateParam) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.record != nil {
+		s.record.Updates = append(s.record.Updates, &SessionUpdateRecord{
+			Param:      proto.Clone(param).(*rpc.UpdateParam),
+			AfterStart: s.status == sessionStatusStarted,
+		})
+	}
+}
+
+// getRecord returns a copy of record with the current status
+func (s *RtpMediaSession) getRecord() *SessionRecord {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.record == nil {
+		return nil
+	}
+	r := *s.record
+	r.Updates = append([]*SessionUpdateRecord(nil), s.record.Updates...)
+	r.Started = s.status == sessionStatusStarted
+	return &r
+}
+
+// sessionPersister saves record to store whenever session changes, and deletes it once session stops
+type sessionPersister struct {
+	BaseSessionListener
+	store SessionStore
+	mutex sync.Mutex // avoid saving stopped session after it is deleted
+}
+
+func (p *sessionPersister) save(s *RtpMediaSession) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	r := s.getRecord()
+	if r == nil || s.GetStatus() == sessionStatusStopped {
+		return
+	}
+	if err := p.store.Save(r); err != nil {
+		logger.Errorf("save session(%v) to store error: %v", s.sessionId, err)
+	}
+}
+
+func (p *sessionPersister) OnSessionCreated(s *RtpMediaSession) {
+	p.save(s)
+}
+
+func (p *sessionPersister) OnSessionUpdated(s *RtpMediaSession) {
+	p.save(s)
+}
+
+func (p *sessionPersister) OnSessionStarted(s *RtpMediaSession) {
+	p.save(s)
+}
+
+func (p *sessionPersister) OnSessionStopped(s *RtpMediaSession) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if err := p.store.Delete(s.sessionId.String()); err != nil {
+		logger.Errorf("delete session(%v) from store error: %v", s.sessionId, err)
+	}
+}
+
+// sessionRecordJson is the file format of record, params are encoded by protojson so that field names are stable
+type sessionRecordJson struct {
+	SessionId  string            `json:"session_id"`
+	InstanceId string            `json:"instance_id"`
+	LocalPorts []uint16          `json:"local_ports"`
+	Create     json.RawMessage   `json:"create"`
+	Updates    []json.RawMessage `json:"updates,omitempty"`
+	AfterStart []bool            `json:"after_start,omitempty"`
+	Started    bool              `json:"started"`
+}
+
+func marshalRecord(r *SessionRecord) ([]byte, error) {
+	rj := sessionRecordJson{
+		SessionId:  r.SessionId,
+		InstanceId: r.InstanceId,
+		LocalPorts: r.LocalPorts,
+		Started:    r.Started,
+	}
+	var err error
+	if rj.Create, err = protojson.Marshal(r.Create); err != nil {
+		return nil, err
+	}
+	for _, u := range r.Updates {
+		var param []byte
+		if param, err = protojson.Marshal(u.Param); err != nil {
+			return nil, err
+		}
+		rj.Updates = append(rj.Updates, param)
+		rj.AfterStart = append(rj.AfterStart, u.AfterStart)
+	}
+	return json.Marshal(&rj)
+}
+
+func unmarshalRecord(data []byte) (*SessionRecord, error) {
+	var rj sessionRecordJson
+	if err := json.Unmarshal(data, &rj); err != nil {
+		return nil, err
+	}
+	if len(rj.AfterStart) != len(rj.Updates) {
+		return nil, errors.New("updates mismatch after_start flags")
+	}
+	r := &SessionRecord{
+		SessionId:  rj.SessionId,
+		InstanceId: rj.InstanceId,
+		LocalPorts: rj.LocalPorts,
+		Create:     &rpc.CreateParam{},
+		Started:    rj.Started,
+	}
+	if err := protojson.Unmarshal(rj.Create, r.Create); err != nil {
+		return nil, err
+	}
+	for i, param := range rj.Updates {
+		u := &SessionUpdateRecord{Param: &rpc.UpdateParam{}, AfterStart: rj.AfterStart[i]}
+		if err := protojson.Unmarshal(param, u.Param); err != nil {
+			return nil, err
+		}
+		r.Updates = append(r.Updates, u)
+	}
+	return r, nil
+}
+
+const sessionFileSuffix = ".json"
+
+// FileSessionStore keeps every record in its own json file of a directory, a record is written to temporary file
+// then renamed, so it is never partially written
+type FileSessionStore struct {
+	dir string
+}
+
+// NewFileSessionStore creates the directory if not exist
+func NewFileSessionStore(dir string) (*FileSessionStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileSessionStore{dir: dir}, nil
+}
+
+func (fs *FileSessionStore) path(sessionId string) string {
+	return filepath.Join(fs.dir, sessionId+sessionFileSuffix)
+}
+
+func (fs *FileSessionStore) Save(r *SessionRecord) error {
+	data, err := marshalRecord(r)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(fs.dir, r.SessionId+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), fs.path(r.SessionId))
+}
+
+func (fs *FileSessionStore) Delete(sessionId string) error {
+	if err := os.Remove(fs.path(sessionId)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Load skips files can not be parsed, they are left for inspection
+func (fs *FileSessionStore) Load() (records []*SessionRecord, err error) {
+	entries, err := os.ReadDir(fs.dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), sessionFileSuffix) {
+			continue
+		}
+		var data []byte
+		var r *SessionRecord
+		file := filepath.Join(fs.dir, e.Name())
+		if data, err = os.ReadFile(file); err != nil {
+			return nil, err
+		}
+		if r, err = unmarshalRecord(data); err != nil {
+			logger.Errorf("skip broken session record %v: %v", file, err)
+			continue
+		}
+		if e.Name() != r.SessionId+sessionFileSuffix {
+			logger.Errorf("skip session record %v with mismatched id %v", file, r.SessionId)
+			continue
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+func (fs *FileSessionStore) String() string {
+	return fmt.Sprintf("file store(%v)", fs.dir)
+}
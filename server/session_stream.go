@@ -0,0 +1,375 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"github.com/appcrash/GoRTP/rtp"
+	"github.com/appcrash/media/codec"
+	"github.com/appcrash/media/server/comp"
+	"github.com/appcrash/media/server/dtmf"
+	"github.com/appcrash/media/server/jitter"
+	"github.com/appcrash/media/server/latch"
+	"github.com/appcrash/media/server/rpc"
+	"github.com/appcrash/media/server/srtp"
+	"github.com/appcrash/media/server/utils"
+	"net"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	streamNameAudio = "audio"
+	streamNameVideo = "video"
+)
+
+// supportedCodecs are codecs that a stream can negotiate, the others are ignored
+var supportedCodecs = []rpc.CodecType{
+	rpc.CodecType_PCM_ALAW, rpc.CodecType_AMRNB, rpc.CodecType_AMRWB, rpc.CodecType_H264, rpc.CodecType_EVS,
+	rpc.CodecType_TELEPHONE_EVENT_8K, rpc.CodecType_TELEPHONE_EVENT_16K,
+}
+
+// mediaStream is a media stream(m-line in sdp) of session, it has its own port pair, rtp stack, codecs and
+// provider/consumer nodes in graph
+type mediaStream struct {
+	session               *RtpMediaSession
+	name                  string
+	localPort, remotePort uint16
+	remoteIp              *net.IPAddr
+	rtpSession            *rtp.Session
+	rtpSessionLocalId     uint32 // rtpSession id which update rtp params
+
+	codecs  map[uint8]*rpc.CodecInfo      // negotiated audio/video codecs by payload type
+	current atomic.Pointer[rpc.CodecInfo] // codec of sending packets, can be switched at runtime
+
+	telephoneEventPayloadNumber uint8
+	telephoneEventPayloadCodec  rpc.CodecType
+	telephoneEventCodecParam    string
+	dtmfDecoder                 *dtmf.Decoder   // nil if telephone event is not negotiated
+	dtmfGenerator               *dtmf.Generator // nil if telephone event is not negotiated
+
+	srtpTransport  *srtp.Transport
+	latchTransport *latch.Transport
+
+	pullC        <-chan *utils.RtpPacketList
+	handleC      chan<- *utils.RtpPacketList
+	dtmfC        chan<- *comp.DtmfMessage
+	dtmfPullC    <-chan *comp.DtmfMessage
+	jitterBuffer *jitter.Buffer // nil if not enabled
+	stats        *mediaStats
+
+	// unix nanoseconds of the last packet sent and received, 0 if none, watched by watchdog
+	lastSent, lastReceived atomic.Int64
+
+	// direction is rpc.MediaDirection changed at runtime, directionTime is unix nanoseconds of its last change, 0 if
+	// never changed. directionC are optional graph nodes notified of the changes
+	direction     atomic.Int32
+	directionTime atomic.Int64
+	directionC    []chan<- *comp.MediaDirectionMessage
+}
+
+func newMediaStream(s *RtpMediaSession, localPort uint16, remoteIp *net.IPAddr,
+	param *rpc.StreamParam) (ms *mediaStream, err error) {
+	if param.GetPeerPort()&0xffff0000 != 0 {
+		// not an uint16 port number
+		return nil, fmt.Errorf("invalid peer port: %v", param.GetPeerPort())
+	}
+	if _, ok := rpc.MediaDirection_name[int32(param.GetDirection())]; !ok {
+		return nil, fmt.Errorf("invalid media direction: %v", param.GetDirection())
+	}
+	ms = &mediaStream{
+		session:    s,
+		name:       param.GetName(),
+		localPort:  localPort,
+		remotePort: uint16(param.GetPeerPort()),
+		remoteIp:   remoteIp,
+		codecs:     make(map[uint8]*rpc.CodecInfo),
+	}
+	for _, ci := range param.GetCodecs() {
+		switch ci.PayloadType {
+		case rpc.CodecType_PCM_ALAW, rpc.CodecType_AMRNB, rpc.CodecType_AMRWB, rpc.CodecType_H264, rpc.CodecType_EVS:
+			if ci.PayloadNumber&0xffffff80 != 0 {
+				err = fmt.Errorf("invalid payload number: %v", ci.PayloadNumber)
+				return
+			}
+			if _, ok := ms.codecs[uint8(ci.PayloadNumber)]; ok {
+				err = fmt.Errorf("create stream with duplicated payload number:%v", ci.PayloadNumber)
+				return
+			}
+			if first := ms.codec(); first == nil {
+				ms.current.Store(ci)
+			} else if (first.PayloadType == rpc.CodecType_H264) != (ci.PayloadType == rpc.CodecType_H264) {
+				err = fmt.Errorf("create stream with both audio and video codecs: %v, %v",
+					first.PayloadType, ci.PayloadType)
+				return
+			}
+			ms.codecs[uint8(ci.PayloadNumber)] = ci
+		case rpc.CodecType_TELEPHONE_EVENT_8K, rpc.CodecType_TELEPHONE_EVENT_16K:
+			ms.telephoneEventPayloadNumber = uint8(ci.PayloadNumber)
+			ms.telephoneEventPayloadCodec = ci.PayloadType
+			ms.telephoneEventCodecParam = ci.CodecParam
+			clockRate := clockRateOfCodec(ci.PayloadType)
+			ms.dtmfDecoder = dtmf.NewDecoder(clockRate)
+			ms.dtmfGenerator = dtmf.NewGenerator(clockRate, dtmfPacketInterval)
+		}
+	}
+	if ms.codec() == nil {
+		err = errors.New("create stream without any audio/video codec info")
+		return
+	}
+	if ms.name == "" {
+		ms.name = streamNameAudio
+		if ms.isVideo() {
+			ms.name = streamNameVideo
+		}
+	}
+	ms.stats = newMediaStats(ms.codec().PayloadType)
+	ms.direction.Store(int32(rpc.MediaDirection_SENDRECV))
+	if d := param.GetDirection(); d != rpc.MediaDirection_DIRECTION_UNCHANGED {
+		ms.direction.Store(int32(d))
+	}
+	return
+}
+
+// codec returns the current sending codec
+func (ms *mediaStream) codec() *rpc.CodecInfo {
+	return ms.current.Load()
+}
+
+// codecOf returns codec of received packet by its payload type
+func (ms *mediaStream) codecOf(payloadType uint8) rpc.CodecType {
+	if ci, ok := ms.codecs[payloadType]; ok {
+		return ci.PayloadType
+	}
+	if ms.dtmfDecoder != nil && payloadType == ms.telephoneEventPayloadNumber {
+		return ms.telephoneEventPayloadCodec
+	}
+	return rpc.CodecType_RAW
+}
+
+// payloadTypeOf returns payload type of sending packet, graph nodes can choose any negotiated one per packet,
+// otherwise the current codec is used
+func (ms *mediaStream) payloadTypeOf(pl *utils.RtpPacketList, current *rpc.CodecInfo) uint8 {
+	if _, ok := ms.codecs[pl.PayloadType]; ok {
+		return pl.PayloadType
+	}
+	return uint8(current.PayloadNumber)
+}
+
+// switchCodec changes the current sending codec to a negotiated one, must be called with session mutex held
+func (ms *mediaStream) switchCodec(payloadType uint8) error {
+	ci, ok := ms.codecs[payloadType]
+	if !ok {
+		return fmt.Errorf("stream(%v) switches to payload number(%v) not negotiated", ms.name, payloadType)
+	}
+	previous := ms.codec()
+	if previous == ci {
+		return nil
+	}
+	profile := profileOfCodec(ci.PayloadType)
+	if profile == "" {
+		return errors.New("unsupported rtp payload profile")
+	}
+	if ms.rtpSession != nil {
+		ms.rtpSession.SsrcStreamOutForIndex(ms.rtpSessionLocalId).SetProfile(profile, payloadType)
+	}
+	ms.current.Store(ci)
+	ms.stats.setCodec(ci.PayloadType)
+	logger.Infof("session(%v) stream(%v) switches codec from %v(%v) to %v(%v)", ms.session.sessionId, ms.name,
+		previous.PayloadType, previous.PayloadNumber, ci.PayloadType, ci.PayloadNumber)
+	return nil
+}
+
+// renumberCodec changes payload number of the current codec, must be called with session mutex held before starting
+func (ms *mediaStream) renumberCodec(payloadType uint8) {
+	previous := ms.codec()
+	ci := &rpc.CodecInfo{PayloadNumber: uint32(payloadType), PayloadType: previous.PayloadType,
+		CodecParam: previous.CodecParam}
+	delete(ms.codecs, uint8(previous.PayloadNumber))
+	ms.codecs[payloadType] = ci
+	if ms.rtpSession != nil {
+		ms.rtpSession.SsrcStreamOutForIndex(ms.rtpSessionLocalId).SetProfile(profileOfCodec(ci.PayloadType),
+			payloadType)
+	}
+	ms.current.Store(ci)
+	logger.Infof("update payload number from previous=%v,to current=%v", previous.PayloadNumber, payloadType)
+}
+
+func (ms *mediaStream) getDirection() rpc.MediaDirection {
+	return rpc.MediaDirection(ms.direction.Load())
+}
+
+// sending is false if media is suppressed by direction, send loop still drains the graph
+func (ms *mediaStream) sending() bool {
+	d := ms.getDirection()
+	return d == rpc.MediaDirection_SENDRECV || d == rpc.MediaDirection_SENDONLY
+}
+
+// receiving is false if received media is discarded by direction, it is still counted in stats
+func (ms *mediaStream) receiving() bool {
+	d := ms.getDirection()
+	return d == rpc.MediaDirection_SENDRECV || d == rpc.MediaDirection_RECVONLY
+}
+
+// setDirection holds or resumes the stream, DIRECTION_UNCHANGED is ignored. graph nodes are notified if changed.
+// caller must hold session mutex
+func (ms *mediaStream) setDirection(d rpc.MediaDirection) {
+	if d == rpc.MediaDirection_DIRECTION_UNCHANGED {
+		return
+	}
+	previous := rpc.MediaDirection(ms.direction.Swap(int32(d)))
+	if previous == d {
+		return
+	}
+	ms.directionTime.Store(time.Now().UnixNano())
+	logger.Infof("session(%v) stream(%v) changes direction from %v to %v", ms.session.sessionId, ms.name,
+		previous, d)
+	ms.notifyDirection()
+}
+
+// notifyDirection tells the current direction to graph nodes, nonblock as nodes may lag behind
+func (ms *mediaStream) notifyDirection() {
+	d := ms.getDirection()
+	for _, c := range ms.directionC {
+		select {
+		case c <- &comp.MediaDirectionMessage{Stream: ms.name, Direction: d}:
+		default:
+		}
+	}
+}
+
+func (ms *mediaStream) isVideo() bool {
+	return ms.codec().PayloadType == rpc.CodecType_H264
+}
+
+func (ms *mediaStream) setupJitterBuffer(param *rpc.JitterBufferParam) {
+	step := time.Duration(codec.GetCodecTimeStep(ms.codec().PayloadType)) * time.Millisecond
+	ms.jitterBuffer = jitter.NewBuffer(step, jitter.Config{
+		MinDelay: time.Duration(param.GetMinDelay()) * time.Millisecond,
+		MaxDelay: time.Duration(param.GetMaxDelay()) * time.Millisecond,
+		Adaptive: param.GetAdaptive(),
+	})
+}
+
+// onLatch is called by latch transport in its receiving goroutine, update remote address asynchronously as
+// stopping session would wait for that goroutine with session mutex held
+func (ms *mediaStream) onLatch(remote *net.UDPAddr) {
+	s := ms.session
+	logger.Infof("session(%v) stream(%v) latched to remote address %v", s.sessionId, ms.name, remote)
+	go func() {
+		s.mutex.Lock()
+		if s.status != sessionStatusStarted {
+			s.mutex.Unlock()
+			return
+		}
+		ms.remoteIp = &net.IPAddr{IP: remote.IP, Zone: remote.Zone}
+		ms.remotePort = uint16(remote.Port)
+		s.mutex.Unlock()
+		if s.statusListener != nil {
+			s.statusListener(s, sessionStatusUpdated)
+		}
+	}()
+}
+
+// activate listens on udp port and creates rtp stream
+func (ms *mediaStream) activate() (err error) {
+	s := ms.session
+	var tpLocal *rtp.TransportUDP
+	var localPort = int(ms.localPort)
+	if tpLocal, err = rtp.NewTransportUDP(s.localIp, localPort, ""); err != nil {
+		return
+	}
+	// transport stack from bottom to top: udp -> srtp -> latch -> rtp session
+	var tpRecv rtp.TransportRecv = tpLocal
+	var tpWrite rtp.TransportWrite = tpLocal
+	if s.srtpProfile != srtp.ProfileNone {
+		ms.srtpTransport = srtp.NewTransport(tpRecv, tpWrite)
+		tpRecv, tpWrite = ms.srtpTransport, ms.srtpTransport
+	}
+	if s.latchWindow > 0 {
+		var payloadTypes []uint8
+		for pt := range ms.codecs {
+			payloadTypes = append(payloadTypes, pt)
+		}
+		if ms.telephoneEventPayloadNumber != 0 {
+			payloadTypes = append(payloadTypes, ms.telephoneEventPayloadNumber)
+		}
+		ms.latchTransport = latch.NewTransport(tpRecv, tpWrite, latch.Config{
+			Window:       s.latchWindow,
+			PayloadTypes: payloadTypes,
+			OnLatch:      ms.onLatch,
+		})
+		tpRecv, tpWrite = ms.latchTransport, ms.latchTransport
+	}
+	ms.rtpSession = rtp.NewSession(tpWrite, tpRecv)
+	strLocalIdx, errStr := ms.rtpSession.NewSsrcStreamOut(&rtp.Address{
+		IPAddr:   s.localIp.IP,
+		DataPort: localPort,
+		CtrlPort: 1 + localPort,
+		Zone:     "",
+	}, 0, 0)
+	if errStr != "" {
+		return errors.New(string(errStr))
+	}
+	if ci := ms.codec(); profileOfCodec(ci.PayloadType) != "" {
+		ms.rtpSession.SsrcStreamOutForIndex(strLocalIdx).SetProfile(profileOfCodec(ci.PayloadType),
+			byte(ci.PayloadNumber))
+		ms.rtpSessionLocalId = strLocalIdx //add by sean
+	} else {
+		return errors.New("unsupported rtp payload profile")
+	}
+	return nil
+}
+
+// start sends packets to remote address, must be called with session mutex held
+func (ms *mediaStream) start() (err error) {
+	s := ms.session
+	if ms.srtpTransport != nil {
+		if err = s.setupSrtpContext(ms.srtpTransport); err != nil {
+			return
+		}
+	}
+	port := int(ms.remotePort)
+	if _, err = ms.rtpSession.AddRemote(&rtp.Address{
+		IPAddr:   ms.remoteIp.IP,
+		DataPort: port,
+		CtrlPort: 1 + port,
+		Zone:     "",
+	}); err != nil {
+		return
+	}
+	if err = ms.rtpSession.StartSession(); err != nil {
+		return
+	}
+	if ms.latchTransport != nil {
+		ms.latchTransport.Start()
+	}
+	return
+}
+
+func (ms *mediaStream) close() {
+	if ms.rtpSession != nil {
+		ms.rtpSession.CloseSession()
+	}
+}
+
+// handleTelephoneEvent decodes telephone-event packet, delivers completed digits to graph and instance
+func (ms *mediaStream) handleTelephoneEvent(pl *utils.RtpPacketList) {
+	s := ms.session
+	digits, err := ms.dtmfDecoder.Decode(pl.Pts, pl.Payload)
+	if err != nil {
+		logger.Debugf("session(%v) decode telephone event error: %v", s.sessionId, err)
+		return
+	}
+	for _, d := range digits {
+		logger.Debugf("session(%v) received dtmf digit %c duration %v", s.sessionId, d.Digit, d.Duration)
+		if ms.dtmfC != nil {
+			select {
+			case ms.dtmfC <- &comp.DtmfMessage{Digit: d.Digit, Volume: d.Volume, Duration: d.Duration}:
+			default:
+			}
+		}
+		if s.notifyDtmf {
+			s.notifyInstanceOfDtmf(d)
+		}
+	}
+}
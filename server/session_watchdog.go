@@ -2,12 +2,15 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"github.com/appcrash/media/server/prom"
 	"github.com/appcrash/media/server/rpc"
 	"github.com/appcrash/media/server/utils"
 	"sync"
 	"time"
 )
 
+// default periods of WatchdogConfig
 const (
 	SessionAuditPeriod       = 30 * time.Second
 	SessionTimeoutPeriod     = 5 * time.Minute
@@ -23,22 +26,84 @@ const (
 	nbLoopReporter
 )
 
+// minAuditPeriod bounds how often a session with short policy timeouts is audited
+const minAuditPeriod = 100 * time.Millisecond
+
+// WatchdogConfig sets how often watchdog audits session and how long session can be inactive, zero value means
+// the default period
+type WatchdogConfig struct {
+	AuditPeriod   time.Duration
+	TimeoutPeriod time.Duration
+	// Policy applies to sessions unless overridden by CreateParam, zero items are disabled except that
+	// InactivityTimeout defaults to TimeoutPeriod
+	Policy WatchdogPolicy
+}
+
+func (c WatchdogConfig) withDefaults() WatchdogConfig {
+	if c.AuditPeriod <= 0 {
+		c.AuditPeriod = SessionAuditPeriod
+	}
+	if c.TimeoutPeriod <= 0 {
+		c.TimeoutPeriod = SessionTimeoutPeriod
+	}
+	if c.Policy.InactivityTimeout <= 0 {
+		c.Policy.InactivityTimeout = c.TimeoutPeriod
+	}
+	return c
+}
+
+// WatchdogPolicy stops session with a distinct reason once violated, zero disables the check
+type WatchdogPolicy struct {
+	InactivityTimeout time.Duration // no packet sent or received by any stream, counted from start
+	OneWayTimeout     time.Duration // a stream sends but never receives, or the reverse, counted from start
+	MaxDuration       time.Duration // since created
+	HeartbeatTimeout  time.Duration // no session info from instance, counted from creation
+}
+
+// override takes non-zero items of param
+func (p WatchdogPolicy) override(param *rpc.WatchdogParam) WatchdogPolicy {
+	set := func(d *time.Duration, ms uint32) {
+		if ms > 0 {
+			*d = time.Duration(ms) * time.Millisecond
+		}
+	}
+	set(&p.InactivityTimeout, param.GetInactivityTimeout())
+	set(&p.OneWayTimeout, param.GetOneWayTimeout())
+	set(&p.MaxDuration, param.GetMaxDuration())
+	set(&p.HeartbeatTimeout, param.GetHeartbeatTimeout())
+	return p
+}
+
+func (p WatchdogPolicy) toRpc() *rpc.WatchdogParam {
+	return &rpc.WatchdogParam{
+		InactivityTimeout: uint32(p.InactivityTimeout.Milliseconds()),
+		OneWayTimeout:     uint32(p.OneWayTimeout.Milliseconds()),
+		MaxDuration:       uint32(p.MaxDuration.Milliseconds()),
+		HeartbeatTimeout:  uint32(p.HeartbeatTimeout.Milliseconds()),
+	}
+}
+
 // WatchDog is used to detect sessions in abnormal state such as zombie session and end it if necessary
 // it detects state by:
 // 1. send/recv loops actively report info or error
 // 2. periodically check signalling server reported session info(if it is capable)
+// 3. packets sent and received by streams against policies of the session
 //
 // if any of above reported timestamp timeout, watchdog will end this session
 type WatchDog struct {
 	session *RtpMediaSession
+	config  WatchdogConfig
+	policy  WatchdogPolicy
 
 	mutex                  sync.Mutex
 	started                bool
 	errorLogged            *utils.Set[int]
 	createTimestamp        time.Time
+	startTimestamp         time.Time // when session starts, zero if not started
 	instanceAliveTimestamp time.Time // last time we recv session info state from instance
 	loopAliveTimestamp     [nbLoopReporter]time.Time
 	nbError                int32
+	stoppingByError        bool // stop is issued once errors exceed threshold
 	cancel                 context.CancelFunc
 }
 
@@ -46,11 +111,18 @@ func newWatchDog(s *RtpMediaSession) *WatchDog {
 	now := time.Now()
 	return &WatchDog{
 		session:         s,
+		config:          WatchdogConfig{}.withDefaults(),
 		createTimestamp: now,
 		errorLogged:     utils.NewSet[int](),
 	}
 }
 
+// setup takes server config and the session's own policy before watchdog starts
+func (wd *WatchDog) setup(config WatchdogConfig, param *rpc.WatchdogParam) {
+	wd.config = config
+	wd.policy = config.Policy.override(param)
+}
+
 func (wd *WatchDog) start() {
 	wd.mutex.Lock()
 	defer wd.mutex.Unlock()
@@ -63,6 +135,22 @@ func (wd *WatchDog) start() {
 	go wd.healthCheck(ctx)
 }
 
+// close ends auditing once session stops
+func (wd *WatchDog) close() {
+	wd.mutex.Lock()
+	defer wd.mutex.Unlock()
+	if wd.cancel != nil {
+		wd.cancel()
+	}
+}
+
+// onSessionStart begins media policies, streams without any packet are counted from now
+func (wd *WatchDog) onSessionStart() {
+	wd.mutex.Lock()
+	defer wd.mutex.Unlock()
+	wd.startTimestamp = time.Now()
+}
+
 func (wd *WatchDog) reportLoopInfo(loopId int) {
 	wd.mutex.Lock()
 	defer wd.mutex.Unlock()
@@ -77,9 +165,11 @@ func (wd *WatchDog) reportLoopError(loopId int, err error) {
 	}
 
 	wd.nbError++
-	if wd.nbError > ReportErrorThreshold {
+	if wd.nbError > ReportErrorThreshold && !wd.stoppingByError {
+		wd.stoppingByError = true
 		logger.Errorf("watchdog(%v): stop session due to too many errors", wd.session.GetSessionId())
-		wd.stop()
+		// reported by loops which are waited by stopping session
+		go wd.stop(rpc.StopReason_TOO_MANY_ERRORS)
 	}
 }
 
@@ -89,62 +179,152 @@ func (wd *WatchDog) reportSessionInfo(_ *rpc.SystemEvent) {
 	wd.instanceAliveTimestamp = time.Now()
 }
 
-// stop session as well as watchdog itself
-func (wd *WatchDog) stop() {
-	wd.session.Stop()
-	if wd.cancel != nil {
-		wd.cancel()
+func unixMilli(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixMilli()
+}
+
+// toRpc returns snapshot of reported timestamps
+func (wd *WatchDog) toRpc() *rpc.WatchdogInfo {
+	wd.mutex.Lock()
+	defer wd.mutex.Unlock()
+	return &rpc.WatchdogInfo{
+		InstanceAliveTime: unixMilli(wd.instanceAliveTimestamp),
+		SendAliveTime:     unixMilli(wd.loopAliveTimestamp[sendLoop]),
+		ReceiveAliveTime:  unixMilli(wd.loopAliveTimestamp[receiveLoop]),
+		RtcpAliveTime:     unixMilli(wd.loopAliveTimestamp[rtcpLoop]),
+		Errors:            wd.nbError,
+		Policy:            wd.policy.toRpc(),
 	}
 }
 
+// stop session, watchdog itself is closed by stopping session
+func (wd *WatchDog) stop(reason rpc.StopReason) {
+	if wd.session.terminate(reason) {
+		prom.RtpWatchdogStop.WithLabelValues(reason.String()).Inc()
+	}
+}
+
+// auditPeriod is the configured one, or shorter so that a policy is violated for at most half of its timeout
+// before found
+func (wd *WatchDog) auditPeriod() time.Duration {
+	period := wd.config.AuditPeriod
+	for _, d := range []time.Duration{wd.policy.InactivityTimeout, wd.policy.OneWayTimeout, wd.policy.MaxDuration,
+		wd.policy.HeartbeatTimeout} {
+		if d > 0 && d/2 < period {
+			period = d / 2
+		}
+	}
+	return max(period, minAuditPeriod)
+}
+
 // healthCheck periodically check session's state
 func (wd *WatchDog) healthCheck(ctx context.Context) {
-	ticker := time.NewTicker(SessionAuditPeriod)
-	session := wd.session
+	ticker := time.NewTicker(wd.auditPeriod())
+	defer ticker.Stop()
 	for {
 		select {
 		case <-ticker.C:
-			sessionId := session.sessionId
-			// start a new round audit ...
-			var stopSession bool
-			wd.mutex.Lock()
-			switch session.status {
-			case sessionStatusStarted:
-				// currently only check that is any packet still received
-				// open question: how to use send loop's info to determine zombie session
-				recvTs := wd.loopAliveTimestamp[receiveLoop]
-				if !recvTs.IsZero() && time.Since(recvTs) > SessionTimeoutPeriod {
-					logger.Errorf("session(%v) has not received any packet in timeout period, stop it", sessionId)
-					stopSession = true
-				}
-				fallthrough // more checks
-			case sessionStatusCreated:
-				// created session has no running loops, check instance aliveness and if create timestamp too far away
-				if wd.instanceAliveTimestamp.IsZero() {
-					// instance has not reported any info yet, so examine session's creation moment
-					if session.status == sessionStatusCreated && time.Since(wd.createTimestamp) > SessionTimeoutPeriod {
-						logger.Errorf("session(%v) created but not started until timeout, stop it", sessionId)
-						stopSession = true
-					}
-				} else {
-					// the instance is able to report its session info, check whether disconnected
-					if time.Since(wd.instanceAliveTimestamp) > SessionTimeoutPeriod {
-						logger.Errorf("session(%v) has no update from instance since %v, timeout, stop it",
-							wd.instanceAliveTimestamp, sessionId)
-						stopSession = true
-					}
-				}
-			case sessionStatusStopped:
-				stopSession = true
-			default:
-				logger.Errorf("session(%v) has unknown state(%v)", sessionId, session.status)
-			}
-			wd.mutex.Unlock()
-			if stopSession {
-				wd.stop()
+			if reason, why := wd.audit(time.Now()); reason != rpc.StopReason_STOP_REASON_NONE {
+				logger.Errorf("watchdog(%v): %v, stop it", wd.session.sessionId, why)
+				wd.stop(reason)
+				return
 			}
 		case <-ctx.Done():
 			return
 		}
 	}
 }
+
+// audit returns reason to stop session and why, STOP_REASON_NONE if session is healthy
+func (wd *WatchDog) audit(now time.Time) (rpc.StopReason, string) {
+	status := wd.session.GetStatus()
+	wd.mutex.Lock()
+	created, started, instanceAlive := wd.createTimestamp, wd.startTimestamp, wd.instanceAliveTimestamp
+	wd.mutex.Unlock()
+	timeout, policy := wd.config.TimeoutPeriod, wd.policy
+
+	if status == sessionStatusStopped {
+		return rpc.StopReason_STOP_REASON_NONE, ""
+	}
+	if policy.MaxDuration > 0 && now.Sub(created) > policy.MaxDuration {
+		return rpc.StopReason_MAX_DURATION, fmt.Sprintf("session lasts longer than %v", policy.MaxDuration)
+	}
+	if policy.HeartbeatTimeout > 0 {
+		last := instanceAlive
+		if last.IsZero() {
+			last = created
+		}
+		if now.Sub(last) > policy.HeartbeatTimeout {
+			return rpc.StopReason_HEARTBEAT_LOST, fmt.Sprintf("instance reports no session info in %v",
+				policy.HeartbeatTimeout)
+		}
+	}
+	switch status {
+	case sessionStatusCreated:
+		// created session has no running loops, check if create timestamp too far away unless instance reports it
+		if instanceAlive.IsZero() && now.Sub(created) > timeout {
+			return rpc.StopReason_WATCHDOG_TIMEOUT, "session created but not started until timeout"
+		}
+	case sessionStatusStarted:
+		if reason, why := auditMedia(wd.session.streams, now, started, policy); reason != rpc.StopReason_STOP_REASON_NONE {
+			return reason, why
+		}
+	}
+	// the instance is able to report its session info, check whether disconnected
+	if !instanceAlive.IsZero() && now.Sub(instanceAlive) > timeout {
+		return rpc.StopReason_WATCHDOG_TIMEOUT, fmt.Sprintf("session has no update from instance since %v",
+			instanceAlive.Format(time.RFC3339))
+	}
+	return rpc.StopReason_STOP_REASON_NONE, ""
+}
+
+// auditMedia checks packets of streams against inactivity and one-way policies, a direction without any packet is
+// counted from start or the last change of stream direction. streams put on hold are exempted
+func auditMedia(streams []*mediaStream, now, started time.Time, policy WatchdogPolicy) (rpc.StopReason, string) {
+	if started.IsZero() {
+		return rpc.StopReason_STOP_REASON_NONE, ""
+	}
+	active := func(ms *mediaStream, ts int64, timeout time.Duration) bool {
+		last := started.UnixNano()
+		if changed := ms.directionTime.Load(); changed > last {
+			last = changed
+		}
+		if ts > last {
+			last = ts
+		}
+		return now.Sub(time.Unix(0, last)) <= timeout
+	}
+	if t := policy.InactivityTimeout; t > 0 {
+		idle := true
+		for _, ms := range streams {
+			if ms.getDirection() == rpc.MediaDirection_INACTIVE ||
+				active(ms, ms.lastSent.Load(), t) || active(ms, ms.lastReceived.Load(), t) {
+				idle = false
+				break
+			}
+		}
+		if idle {
+			return rpc.StopReason_MEDIA_INACTIVE, fmt.Sprintf("no packet sent or received in %v", t)
+		}
+	}
+	if t := policy.OneWayTimeout; t > 0 {
+		for _, ms := range streams {
+			if ms.getDirection() != rpc.MediaDirection_SENDRECV {
+				continue
+			}
+			sending, receiving := active(ms, ms.lastSent.Load(), t), active(ms, ms.lastReceived.Load(), t)
+			if sending && !receiving {
+				return rpc.StopReason_ONE_WAY_MEDIA, fmt.Sprintf("stream(%v) sends but receives nothing in %v",
+					ms.name, t)
+			}
+			if receiving && !sending {
+				return rpc.StopReason_ONE_WAY_MEDIA, fmt.Sprintf("stream(%v) receives but sends nothing in %v",
+					ms.name, t)
+			}
+		}
+	}
+	return rpc.StopReason_STOP_REASON_NONE, ""
+}
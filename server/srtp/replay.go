@@ -0,0 +1,44 @@
+package srtp
+
+const replayWindowSize = 64
+
+// replayWindow is the sliding window of received packet index, refer to RFC 3711 3.3.2
+type replayWindow struct {
+	initialized bool
+	highest     uint64
+	mask        uint64 // bit i is set if index (highest - i) is received
+}
+
+// check returns true if index is neither received nor too old
+func (w *replayWindow) check(index uint64) bool {
+	if !w.initialized || index > w.highest {
+		return true
+	}
+	diff := w.highest - index
+	if diff >= replayWindowSize {
+		return false
+	}
+	return w.mask&(uint64(1)<<diff) == 0
+}
+
+// accept marks index as received, must be called after packet authenticated
+func (w *replayWindow) accept(index uint64) {
+	if !w.initialized {
+		w.initialized = true
+		w.highest = index
+		w.mask = 1
+		return
+	}
+	if index > w.highest {
+		shift := index - w.highest
+		if shift >= replayWindowSize {
+			w.mask = 0
+		} else {
+			w.mask <<= shift
+		}
+		w.mask |= 1
+		w.highest = index
+	} else {
+		w.mask |= uint64(1) << (w.highest - index)
+	}
+}
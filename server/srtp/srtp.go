@@ -0,0 +1,419 @@
+package srtp
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"sync"
+)
+
+// Profile is crypto suite of SRTP, refer to RFC 4568 6.2 and RFC 7714 14.2
+type Profile int
+
+const (
+	ProfileNone Profile = iota
+	ProfileAesCm128HmacSha1_80
+	ProfileAesCm128HmacSha1_32
+	ProfileAeadAes128Gcm
+)
+
+// key derivation labels, refer to RFC 3711 4.3.1
+const (
+	labelRtpEncryption  = 0x00
+	labelRtpAuth        = 0x01
+	labelRtpSalt        = 0x02
+	labelRtcpEncryption = 0x03
+	labelRtcpAuth       = 0x04
+	labelRtcpSalt       = 0x05
+)
+
+const (
+	rtpHeaderLength  = 12
+	rtcpHeaderLength = 8 // header + ssrc of sender
+	srtcpIndexLength = 4
+	srtcpEncryptFlag = 0x80000000
+	maxSrtcpIndex    = 0x7fffffff
+	authKeyLength    = 20
+)
+
+var (
+	ErrInvalidProfile = errors.New("srtp: invalid profile")
+	ErrInvalidKey     = errors.New("srtp: invalid master key length")
+	ErrMalformed      = errors.New("srtp: malformed packet")
+	ErrAuth           = errors.New("srtp: authentication failed")
+	ErrReplay         = errors.New("srtp: replayed packet")
+)
+
+type profileInfo struct {
+	name       string
+	keyLen     int
+	saltLen    int
+	rtpTagLen  int
+	rtcpTagLen int
+	aead       bool
+}
+
+var profileInfos = map[Profile]*profileInfo{
+	ProfileAesCm128HmacSha1_80: {"AES_CM_128_HMAC_SHA1_80", 16, 14, 10, 10, false},
+	// 32 bits tag is for SRTP only, SRTCP still uses 80 bits tag (RFC 4568 6.2.2)
+	ProfileAesCm128HmacSha1_32: {"AES_CM_128_HMAC_SHA1_32", 16, 14, 4, 10, false},
+	ProfileAeadAes128Gcm:       {"AEAD_AES_128_GCM", 16, 12, 16, 16, true},
+}
+
+func (p Profile) String() string {
+	if info, ok := profileInfos[p]; ok {
+		return info.name
+	}
+	return "NONE"
+}
+
+// MasterKeyLength is length of master key concatenated with master salt, i.e. the decoded inline key of SDES
+func (p Profile) MasterKeyLength() int {
+	if info, ok := profileInfos[p]; ok {
+		return info.keyLen + info.saltLen
+	}
+	return 0
+}
+
+// GenerateMasterKey returns random master key and salt
+func GenerateMasterKey(p Profile) ([]byte, error) {
+	n := p.MasterKeyLength()
+	if n == 0 {
+		return nil, ErrInvalidProfile
+	}
+	key := make([]byte, n)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Context protects packets of one direction, i.e. a local one for outgoing packets and a remote one for incoming
+// packets. it keeps rollover counter and replay window for each ssrc.
+type Context struct {
+	mutex sync.Mutex
+	info  *profileInfo
+
+	rtpBlock, rtcpBlock cipher.Block
+	rtpAead, rtcpAead   cipher.AEAD
+	rtpSalt, rtcpSalt   []byte
+	rtpAuth, rtcpAuth   hash.Hash
+
+	states    map[uint32]*ssrcState
+	rtcpIndex uint32 // last sent srtcp index
+}
+
+type ssrcState struct {
+	initialized bool
+	roc         uint32
+	lastSeq     uint16
+	rtpReplay   replayWindow
+	rtcpReplay  replayWindow
+}
+
+// NewContext derives session keys from master key(concatenated with master salt)
+func NewContext(profile Profile, masterKey []byte) (*Context, error) {
+	info, ok := profileInfos[profile]
+	if !ok {
+		return nil, ErrInvalidProfile
+	}
+	if len(masterKey) != info.keyLen+info.saltLen {
+		return nil, ErrInvalidKey
+	}
+	masterBlock, err := aes.NewCipher(masterKey[:info.keyLen])
+	if err != nil {
+		return nil, err
+	}
+	masterSalt := masterKey[info.keyLen:]
+	c := &Context{
+		info:     info,
+		rtpSalt:  deriveKey(masterBlock, masterSalt, labelRtpSalt, info.saltLen),
+		rtcpSalt: deriveKey(masterBlock, masterSalt, labelRtcpSalt, info.saltLen),
+		states:   make(map[uint32]*ssrcState),
+	}
+	if c.rtpBlock, err = aes.NewCipher(deriveKey(masterBlock, masterSalt, labelRtpEncryption, info.keyLen)); err != nil {
+		return nil, err
+	}
+	if c.rtcpBlock, err = aes.NewCipher(deriveKey(masterBlock, masterSalt, labelRtcpEncryption, info.keyLen)); err != nil {
+		return nil, err
+	}
+	if info.aead {
+		if c.rtpAead, err = cipher.NewGCM(c.rtpBlock); err != nil {
+			return nil, err
+		}
+		if c.rtcpAead, err = cipher.NewGCM(c.rtcpBlock); err != nil {
+			return nil, err
+		}
+	} else {
+		c.rtpAuth = hmac.New(sha1.New, deriveKey(masterBlock, masterSalt, labelRtpAuth, authKeyLength))
+		c.rtcpAuth = hmac.New(sha1.New, deriveKey(masterBlock, masterSalt, labelRtcpAuth, authKeyLength))
+	}
+	return c, nil
+}
+
+// deriveKey is AES-CM PRF with key derivation rate 0, refer to RFC 3711 4.3
+func deriveKey(block cipher.Block, masterSalt []byte, label byte, n int) []byte {
+	iv := make([]byte, aes.BlockSize)
+	copy(iv, masterSalt)
+	iv[7] ^= label
+	out := make([]byte, n)
+	cipher.NewCTR(block, iv).XORKeyStream(out, out)
+	return out
+}
+
+func (c *Context) state(ssrc uint32) *ssrcState {
+	s, ok := c.states[ssrc]
+	if !ok {
+		s = &ssrcState{}
+		c.states[ssrc] = s
+	}
+	return s
+}
+
+// EncryptRtp returns a new SRTP packet of plain RTP packet
+func (c *Context) EncryptRtp(packet []byte) ([]byte, error) {
+	headerLen, err := rtpHeaderLen(packet)
+	if err != nil {
+		return nil, err
+	}
+	ssrc := binary.BigEndian.Uint32(packet[8:12])
+	seq := binary.BigEndian.Uint16(packet[2:4])
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	s := c.state(ssrc)
+	roc := s.estimate(seq)
+	s.update(roc, seq)
+
+	out := make([]byte, len(packet), len(packet)+c.info.rtpTagLen)
+	copy(out, packet[:headerLen])
+	if c.info.aead {
+		nonce := c.rtpNonce(ssrc, roc, seq)
+		return c.rtpAead.Seal(out[:headerLen], nonce, packet[headerLen:], packet[:headerLen]), nil
+	}
+	c.xorKeyStream(c.rtpBlock, c.rtpSalt, ssrc, uint64(roc)<<16|uint64(seq), out[headerLen:], packet[headerLen:])
+	return append(out, c.authTag(c.rtpAuth, out, roc, c.info.rtpTagLen)...), nil
+}
+
+// DecryptRtp returns a new plain RTP packet of SRTP packet, replayed or tampered packets are rejected
+func (c *Context) DecryptRtp(packet []byte) ([]byte, error) {
+	headerLen, err := rtpHeaderLen(packet)
+	if err != nil {
+		return nil, err
+	}
+	tagLen := c.info.rtpTagLen
+	if len(packet) < headerLen+tagLen {
+		return nil, ErrMalformed
+	}
+	ssrc := binary.BigEndian.Uint32(packet[8:12])
+	seq := binary.BigEndian.Uint16(packet[2:4])
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	s := c.state(ssrc)
+	roc := s.estimate(seq)
+	index := uint64(roc)<<16 | uint64(seq)
+	if !s.rtpReplay.check(index) {
+		return nil, ErrReplay
+	}
+
+	var out []byte
+	if c.info.aead {
+		nonce := c.rtpNonce(ssrc, roc, seq)
+		out = make([]byte, headerLen, len(packet)-tagLen)
+		copy(out, packet[:headerLen])
+		if out, err = c.rtpAead.Open(out, nonce, packet[headerLen:], packet[:headerLen]); err != nil {
+			return nil, ErrAuth
+		}
+	} else {
+		authenticated := packet[:len(packet)-tagLen]
+		if subtle.ConstantTimeCompare(c.authTag(c.rtpAuth, authenticated, roc, tagLen), packet[len(authenticated):]) != 1 {
+			return nil, ErrAuth
+		}
+		out = make([]byte, len(authenticated))
+		copy(out, packet[:headerLen])
+		c.xorKeyStream(c.rtpBlock, c.rtpSalt, ssrc, index, out[headerLen:], authenticated[headerLen:])
+	}
+	s.update(roc, seq)
+	s.rtpReplay.accept(index)
+	return out, nil
+}
+
+// EncryptRtcp returns a new SRTCP packet of plain RTCP compound packet
+func (c *Context) EncryptRtcp(packet []byte) ([]byte, error) {
+	if len(packet) < rtcpHeaderLength {
+		return nil, ErrMalformed
+	}
+	ssrc := binary.BigEndian.Uint32(packet[4:8])
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.rtcpIndex = (c.rtcpIndex + 1) & maxSrtcpIndex
+	index := c.rtcpIndex
+	trailer := make([]byte, srtcpIndexLength)
+	binary.BigEndian.PutUint32(trailer, srtcpEncryptFlag|index)
+
+	out := make([]byte, len(packet), len(packet)+c.info.rtcpTagLen+srtcpIndexLength)
+	copy(out, packet[:rtcpHeaderLength])
+	if c.info.aead {
+		nonce := c.rtcpNonce(ssrc, index)
+		aad := append(append([]byte(nil), packet[:rtcpHeaderLength]...), trailer...)
+		out = c.rtcpAead.Seal(out[:rtcpHeaderLength], nonce, packet[rtcpHeaderLength:], aad)
+		return append(out, trailer...), nil
+	}
+	c.xorKeyStream(c.rtcpBlock, c.rtcpSalt, ssrc, uint64(index), out[rtcpHeaderLength:], packet[rtcpHeaderLength:])
+	out = append(out, trailer...)
+	return append(out, c.authTag(c.rtcpAuth, out, 0, c.info.rtcpTagLen)...), nil
+}
+
+// DecryptRtcp returns a new plain RTCP compound packet of SRTCP packet, replayed or tampered packets are rejected
+func (c *Context) DecryptRtcp(packet []byte) ([]byte, error) {
+	tagLen := c.info.rtcpTagLen
+	if len(packet) < rtcpHeaderLength+srtcpIndexLength+tagLen {
+		return nil, ErrMalformed
+	}
+	ssrc := binary.BigEndian.Uint32(packet[4:8])
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	s := c.state(ssrc)
+	var trailer, body []byte
+	if c.info.aead {
+		// ciphertext and tag come before srtcp index
+		trailer = packet[len(packet)-srtcpIndexLength:]
+		body = packet[rtcpHeaderLength : len(packet)-srtcpIndexLength]
+	} else {
+		trailer = packet[len(packet)-tagLen-srtcpIndexLength : len(packet)-tagLen]
+		body = packet[rtcpHeaderLength : len(packet)-tagLen-srtcpIndexLength]
+	}
+	word := binary.BigEndian.Uint32(trailer)
+	index := word & maxSrtcpIndex
+	encrypted := word&srtcpEncryptFlag != 0
+	if !s.rtcpReplay.check(uint64(index)) {
+		return nil, ErrReplay
+	}
+
+	out := make([]byte, rtcpHeaderLength, rtcpHeaderLength+len(body))
+	copy(out, packet[:rtcpHeaderLength])
+	if c.info.aead {
+		var err error
+		aad := append(append([]byte(nil), packet[:rtcpHeaderLength]...), trailer...)
+		if !encrypted {
+			// authentication only, the whole packet is aad
+			if len(body) < tagLen {
+				return nil, ErrMalformed
+			}
+			aad = append(append([]byte(nil), packet[:len(packet)-srtcpIndexLength-tagLen]...), trailer...)
+			if _, err = c.rtcpAead.Open(nil, c.rtcpNonce(ssrc, index), body[len(body)-tagLen:], aad); err != nil {
+				return nil, ErrAuth
+			}
+			out = append(out, body[:len(body)-tagLen]...)
+		} else if out, err = c.rtcpAead.Open(out, c.rtcpNonce(ssrc, index), body, aad); err != nil {
+			return nil, ErrAuth
+		}
+	} else {
+		authenticated := packet[:len(packet)-tagLen]
+		if subtle.ConstantTimeCompare(c.authTag(c.rtcpAuth, authenticated, 0, tagLen), packet[len(authenticated):]) != 1 {
+			return nil, ErrAuth
+		}
+		out = out[:rtcpHeaderLength+len(body)]
+		if encrypted {
+			c.xorKeyStream(c.rtcpBlock, c.rtcpSalt, ssrc, uint64(index), out[rtcpHeaderLength:], body)
+		} else {
+			copy(out[rtcpHeaderLength:], body)
+		}
+	}
+	s.rtcpReplay.accept(uint64(index))
+	return out, nil
+}
+
+// xorKeyStream is AES-CM with IV = (salt * 2^16) XOR (ssrc * 2^64) XOR (index * 2^16), refer to RFC 3711 4.1.1
+func (c *Context) xorKeyStream(block cipher.Block, salt []byte, ssrc uint32, index uint64, dst, src []byte) {
+	iv := make([]byte, aes.BlockSize)
+	copy(iv, salt)
+	var v [8]byte
+	binary.BigEndian.PutUint32(v[:4], ssrc)
+	subtle.XORBytes(iv[4:8], iv[4:8], v[:4])
+	binary.BigEndian.PutUint64(v[:], index<<16)
+	subtle.XORBytes(iv[8:14], iv[8:14], v[:6])
+	cipher.NewCTR(block, iv).XORKeyStream(dst, src)
+}
+
+// authTag is HMAC-SHA1 of authenticated portion, roc is appended for SRTP, refer to RFC 3711 4.2
+func (c *Context) authTag(h hash.Hash, authenticated []byte, roc uint32, tagLen int) []byte {
+	h.Reset()
+	h.Write(authenticated)
+	if h == c.rtpAuth {
+		var r [4]byte
+		binary.BigEndian.PutUint32(r[:], roc)
+		h.Write(r[:])
+	}
+	return h.Sum(nil)[:tagLen]
+}
+
+// rtpNonce is (0x0000 || ssrc || roc || seq) XOR salt, refer to RFC 7714 8.1
+func (c *Context) rtpNonce(ssrc, roc uint32, seq uint16) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint32(nonce[2:6], ssrc)
+	binary.BigEndian.PutUint32(nonce[6:10], roc)
+	binary.BigEndian.PutUint16(nonce[10:12], seq)
+	subtle.XORBytes(nonce, nonce, c.rtpSalt)
+	return nonce
+}
+
+// rtcpNonce is (0x0000 || ssrc || 0x0000 || index) XOR salt, refer to RFC 7714 9.1
+func (c *Context) rtcpNonce(ssrc, index uint32) []byte {
+	nonce := make([]byte, 12)
+	binary.BigEndian.PutUint32(nonce[2:6], ssrc)
+	binary.BigEndian.PutUint32(nonce[8:12], index&maxSrtcpIndex)
+	subtle.XORBytes(nonce, nonce, c.rtcpSalt)
+	return nonce
+}
+
+func rtpHeaderLen(packet []byte) (int, error) {
+	if len(packet) < rtpHeaderLength || packet[0]>>6 != 2 {
+		return 0, ErrMalformed
+	}
+	n := rtpHeaderLength + 4*int(packet[0]&0x0f)
+	if packet[0]&0x10 != 0 {
+		// header extension
+		if len(packet) < n+4 {
+			return 0, ErrMalformed
+		}
+		n += 4 + 4*int(binary.BigEndian.Uint16(packet[n+2:n+4]))
+	}
+	if len(packet) < n {
+		return 0, ErrMalformed
+	}
+	return n, nil
+}
+
+// estimate guesses rollover counter of seq, refer to RFC 3711 3.3.1 and appendix A
+func (s *ssrcState) estimate(seq uint16) uint32 {
+	if !s.initialized {
+		return 0
+	}
+	roc := s.roc
+	if s.lastSeq < 0x8000 {
+		if int(seq)-int(s.lastSeq) > 0x8000 && roc > 0 {
+			roc--
+		}
+	} else if int(s.lastSeq)-0x8000 > int(seq) {
+		roc++
+	}
+	return roc
+}
+
+func (s *ssrcState) update(roc uint32, seq uint16) {
+	if !s.initialized || roc > s.roc || (roc == s.roc && seq > s.lastSeq) {
+		s.initialized = true
+		s.roc = roc
+		s.lastSeq = seq
+	}
+}
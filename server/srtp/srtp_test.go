@@ -0,0 +1,171 @@
+package srtp_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"github.com/appcrash/media/server/srtp"
+	"testing"
+)
+
+var profiles = []srtp.Profile{
+	srtp.ProfileAesCm128HmacSha1_80,
+	srtp.ProfileAesCm128HmacSha1_32,
+	srtp.ProfileAeadAes128Gcm,
+}
+
+func mustDecode(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func rtpPacket(seq uint16, payload []byte) []byte {
+	p := make([]byte, 12, 12+len(payload))
+	p[0], p[1] = 0x80, 0x08
+	binary.BigEndian.PutUint16(p[2:4], seq)
+	binary.BigEndian.PutUint32(p[4:8], uint32(seq)*160)
+	binary.BigEndian.PutUint32(p[8:12], 0xcafebabe)
+	return append(p, payload...)
+}
+
+func newContexts(t *testing.T, p srtp.Profile) (tx, rx *srtp.Context) {
+	key, err := srtp.GenerateMasterKey(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx, err = srtp.NewContext(p, key); err != nil {
+		t.Fatal(err)
+	}
+	if rx, err = srtp.NewContext(p, key); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+// reference packets of libsrtp test driver
+func TestReferenceVector(t *testing.T) {
+	key := mustDecode("e1f97a0d3e018be0d64fa32c06de41390ec675ad498afeebb6960b3aabe6")
+	tx, err := srtp.NewContext(srtp.ProfileAesCm128HmacSha1_80, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain := mustDecode("800f1234decafbadcafebabeabababababababababababababababab")
+	expected := mustDecode("800f1234decafbadcafebabe4e55dc4ce79978d88ca4d215949d2402b78d6acc99ea179b8dbb")
+	if out, _ := tx.EncryptRtp(plain); !bytes.Equal(out, expected) {
+		t.Fatalf("srtp mismatch: %x", out)
+	}
+
+	plain = mustDecode("81c8000bcafebabeabababababababababababababababab")
+	expected = mustDecode("81c8000bcafebabe7128035be487b9bdbef89041f977a5a880000001993e08cd54d6c1230798")
+	if out, _ := tx.EncryptRtcp(plain); !bytes.Equal(out, expected) {
+		t.Fatalf("srtcp mismatch: %x", out)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, p := range profiles {
+		tx, rx := newContexts(t, p)
+		payload := []byte("hello srtp payload")
+		plain := rtpPacket(100, payload)
+		enc, err := tx.EncryptRtp(plain)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Contains(enc, payload) {
+			t.Fatalf("%v: payload is not encrypted", p)
+		}
+		dec, err := rx.DecryptRtp(enc)
+		if err != nil || !bytes.Equal(dec, plain) {
+			t.Fatalf("%v: decrypt rtp failed: %v", p, err)
+		}
+
+		rtcp := mustDecode("80c90001cafebabe")
+		rtcp = append(rtcp, []byte("rtcp body")...)
+		enc, err = tx.EncryptRtcp(rtcp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dec, err = rx.DecryptRtcp(enc)
+		if err != nil || !bytes.Equal(dec, rtcp) {
+			t.Fatalf("%v: decrypt rtcp failed: %v", p, err)
+		}
+	}
+}
+
+func TestTamperAndReplay(t *testing.T) {
+	for _, p := range profiles {
+		tx, rx := newContexts(t, p)
+		enc, _ := tx.EncryptRtp(rtpPacket(1, []byte("payload")))
+		tampered := append([]byte(nil), enc...)
+		tampered[14] ^= 0xff
+		if _, err := rx.DecryptRtp(tampered); err != srtp.ErrAuth {
+			t.Fatalf("%v: tampered packet should fail authentication, got %v", p, err)
+		}
+		if _, err := rx.DecryptRtp(enc); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := rx.DecryptRtp(enc); err != srtp.ErrReplay {
+			t.Fatalf("%v: replayed packet should be rejected, got %v", p, err)
+		}
+
+		rtcp, _ := tx.EncryptRtcp(mustDecode("80c90001cafebabe"))
+		if _, err := rx.DecryptRtcp(rtcp); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := rx.DecryptRtcp(rtcp); err != srtp.ErrReplay {
+			t.Fatalf("%v: replayed rtcp should be rejected, got %v", p, err)
+		}
+	}
+}
+
+func TestReplayWindow(t *testing.T) {
+	tx, rx := newContexts(t, srtp.ProfileAesCm128HmacSha1_80)
+	var packets [][]byte
+	for seq := uint16(0); seq < 100; seq++ {
+		enc, _ := tx.EncryptRtp(rtpPacket(seq, []byte("x")))
+		packets = append(packets, enc)
+	}
+	// reordered packets within window are accepted
+	for _, i := range []int{80, 50, 99, 60} {
+		if _, err := rx.DecryptRtp(packets[i]); err != nil {
+			t.Fatalf("packet %v: %v", i, err)
+		}
+	}
+	// too old
+	if _, err := rx.DecryptRtp(packets[10]); err != srtp.ErrReplay {
+		t.Fatalf("packet out of replay window should be rejected, got %v", err)
+	}
+}
+
+func TestRollover(t *testing.T) {
+	for _, p := range profiles {
+		tx, rx := newContexts(t, p)
+		seq := uint16(0xfffd)
+		for i := 0; i < 6; i++ {
+			plain := rtpPacket(seq, []byte("rollover"))
+			enc, err := tx.EncryptRtp(plain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dec, err := rx.DecryptRtp(enc); err != nil || !bytes.Equal(dec, plain) {
+				t.Fatalf("%v: decrypt seq %v failed: %v", p, seq, err)
+			}
+			seq++
+		}
+	}
+}
+
+func TestInvalidKey(t *testing.T) {
+	if _, err := srtp.NewContext(srtp.ProfileAesCm128HmacSha1_80, make([]byte, 16)); err != srtp.ErrInvalidKey {
+		t.Fatal("short key should be rejected")
+	}
+	if _, err := srtp.NewContext(srtp.ProfileNone, make([]byte, 30)); err != srtp.ErrInvalidProfile {
+		t.Fatal("none profile should be rejected")
+	}
+	if srtp.ProfileAeadAes128Gcm.MasterKeyLength() != 28 || srtp.ProfileAesCm128HmacSha1_32.MasterKeyLength() != 30 {
+		t.Fatal("wrong master key length")
+	}
+}
@@ -0,0 +1,163 @@
+package srtp
+
+import (
+	"github.com/appcrash/GoRTP/rtp"
+	"github.com/appcrash/media/server/prom"
+	"github.com/appcrash/media/server/utils"
+	"reflect"
+	"sync"
+)
+
+var (
+	errorMalformed = prom.RtpSrtpError.WithLabelValues("malformed")
+	errorAuth      = prom.RtpSrtpError.WithLabelValues("auth")
+	errorReplay    = prom.RtpSrtpError.WithLabelValues("replay")
+	errorNoKey     = prom.RtpSrtpError.WithLabelValues("no_key")
+)
+
+// Transport stacks on top of a GoRTP transport(e.g. TransportUDP), it decrypts packets received from lower
+// transport before passing them to upper one(usually rtp.Session), and encrypts packets before writing them to
+// lower transport. packets are dropped until contexts are set.
+type Transport struct {
+	lowerRecv  rtp.TransportRecv
+	lowerWrite rtp.TransportWrite
+	callUpper  rtp.TransportRecv
+
+	mutex         sync.RWMutex
+	local, remote *Context
+}
+
+func NewTransport(lowerRecv rtp.TransportRecv, lowerWrite rtp.TransportWrite) *Transport {
+	t := &Transport{
+		lowerRecv:  lowerRecv,
+		lowerWrite: lowerWrite,
+	}
+	lowerRecv.SetCallUpper(t)
+	return t
+}
+
+// SetContext sets local context to protect outgoing packets and remote context to unprotect incoming packets
+func (t *Transport) SetContext(local, remote *Context) {
+	t.mutex.Lock()
+	t.local, t.remote = local, remote
+	t.mutex.Unlock()
+}
+
+func (t *Transport) contexts() (local, remote *Context) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.local, t.remote
+}
+
+// *** rtp.TransportRecv
+
+func (t *Transport) ListenOnTransports() error {
+	return t.lowerRecv.ListenOnTransports()
+}
+
+func (t *Transport) OnRecvData(rp *rtp.DataPacket) bool {
+	_, remote := t.contexts()
+	if remote == nil || t.callUpper == nil {
+		errorNoKey.Inc()
+		return false
+	}
+	plain, err := remote.DecryptRtp(rp.Buffer()[:rp.InUse()])
+	if err != nil {
+		countError(err)
+		return false
+	}
+	copy(rp.Buffer(), plain)
+	setPacket(rp, rp.Buffer(), len(plain))
+	return t.callUpper.OnRecvData(rp)
+}
+
+func (t *Transport) OnRecvCtrl(rp *rtp.CtrlPacket) bool {
+	_, remote := t.contexts()
+	if remote == nil || t.callUpper == nil {
+		errorNoKey.Inc()
+		return false
+	}
+	plain, err := remote.DecryptRtcp(rp.Buffer()[:rp.InUse()])
+	if err != nil {
+		countError(err)
+		return false
+	}
+	copy(rp.Buffer(), plain)
+	setPacket(rp, rp.Buffer(), len(plain))
+	return t.callUpper.OnRecvCtrl(rp)
+}
+
+func (t *Transport) SetCallUpper(upper rtp.TransportRecv) {
+	t.callUpper = upper
+}
+
+func (t *Transport) CloseRecv() {
+	t.lowerRecv.CloseRecv()
+}
+
+func (t *Transport) SetEndChannel(ch rtp.TransportEnd) {
+	t.lowerRecv.SetEndChannel(ch)
+}
+
+// *** rtp.TransportWrite
+
+// WriteDataTo encrypts to a new packet, as the same packet can be written to more than one remote
+func (t *Transport) WriteDataTo(rp *rtp.DataPacket, addr *rtp.Address) (n int, err error) {
+	local, _ := t.contexts()
+	if local == nil {
+		errorNoKey.Inc()
+		return 0, nil
+	}
+	var protected []byte
+	if protected, err = local.EncryptRtp(rp.Buffer()[:rp.InUse()]); err != nil {
+		countError(err)
+		return
+	}
+	out := new(rtp.DataPacket)
+	setPacket(out, protected, len(protected))
+	return t.lowerWrite.WriteDataTo(out, addr)
+}
+
+func (t *Transport) WriteCtrlTo(rp *rtp.CtrlPacket, addr *rtp.Address) (n int, err error) {
+	local, _ := t.contexts()
+	if local == nil {
+		errorNoKey.Inc()
+		return 0, nil
+	}
+	var protected []byte
+	if protected, err = local.EncryptRtcp(rp.Buffer()[:rp.InUse()]); err != nil {
+		countError(err)
+		return
+	}
+	out := new(rtp.CtrlPacket)
+	setPacket(out, protected, len(protected))
+	return t.lowerWrite.WriteCtrlTo(out, addr)
+}
+
+func (t *Transport) SetToLower(lower rtp.TransportWrite) {
+	if lower != nil {
+		t.lowerWrite = lower
+	}
+}
+
+func (t *Transport) CloseWrite() {
+	t.lowerWrite.CloseWrite()
+}
+
+func countError(err error) {
+	switch err {
+	case ErrAuth:
+		errorAuth.Inc()
+	case ErrReplay:
+		errorReplay.Inc()
+	default:
+		errorMalformed.Inc()
+	}
+}
+
+// setPacket sets buffer and its used length of a GoRTP packet, which are not exported
+func setPacket(packet interface{}, buffer []byte, inUse int) {
+	v := reflect.ValueOf(packet).Elem()
+	utils.SetField(v.FieldByName("buffer"), reflect.ValueOf(buffer))
+	utils.SetField(v.FieldByName("inUse"), reflect.ValueOf(inUse))
+}
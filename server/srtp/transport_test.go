@@ -0,0 +1,107 @@
+package srtp_test
+
+import (
+	"bytes"
+	"github.com/appcrash/GoRTP/rtp"
+	"github.com/appcrash/media/server/srtp"
+	"net"
+	"testing"
+	"time"
+)
+
+type peer struct {
+	port      int
+	transport *srtp.Transport
+	session   *rtp.Session
+}
+
+func newPeer(t *testing.T, port int) *peer {
+	ip, _ := net.ResolveIPAddr("ip", "127.0.0.1")
+	tp, err := rtp.NewTransportUDP(ip, port, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &peer{port: port, transport: srtp.NewTransport(tp, tp)}
+	p.session = rtp.NewSession(p.transport, p.transport)
+	if _, errStr := p.session.NewSsrcStreamOut(&rtp.Address{IPAddr: ip.IP, DataPort: port, CtrlPort: port + 1}, 0, 0); errStr != "" {
+		t.Fatal(errStr)
+	}
+	p.session.SsrcStreamOutForIndex(0).SetProfile("PCMA", 8)
+	return p
+}
+
+func (p *peer) connect(t *testing.T, other *peer) {
+	if _, err := p.session.AddRemote(&rtp.Address{IPAddr: net.ParseIP("127.0.0.1"), DataPort: other.port,
+		CtrlPort: other.port + 1}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func setKeys(t *testing.T, profile srtp.Profile, a, b *peer, keyA, keyB []byte) {
+	ctx := func(key []byte) *srtp.Context {
+		c, err := srtp.NewContext(profile, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	a.transport.SetContext(ctx(keyA), ctx(keyB))
+	b.transport.SetContext(ctx(keyB), ctx(keyA))
+}
+
+func exchange(t *testing.T, from, to *peer, n int) (received int) {
+	recvC := to.session.CreateDataReceiveChan()
+	payload := []byte("protected payload")
+	for i := 0; i < n; i++ {
+		packet := from.session.NewDataPacket(uint32(i * 160))
+		packet.SetPayload(payload)
+		if _, err := from.session.WriteData(packet); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case rp := <-recvC:
+			if !bytes.Equal(rp.Payload(), payload) {
+				t.Fatalf("payload mismatch: %v", rp.Payload())
+			}
+			received++
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+	return
+}
+
+func TestTwoSessions(t *testing.T) {
+	port := 31000
+	for _, profile := range profiles {
+		a, b := newPeer(t, port), newPeer(t, port+2)
+		port += 4
+		keyA, _ := srtp.GenerateMasterKey(profile)
+		keyB, _ := srtp.GenerateMasterKey(profile)
+		a.connect(t, b)
+		b.connect(t, a)
+		setKeys(t, profile, a, b, keyA, keyB)
+		if err := a.session.StartSession(); err != nil {
+			t.Fatal(err)
+		}
+		if err := b.session.StartSession(); err != nil {
+			t.Fatal(err)
+		}
+
+		if n := exchange(t, a, b, 5); n != 5 {
+			t.Fatalf("%v: a -> b received %v packets", profile, n)
+		}
+		if n := exchange(t, b, a, 5); n != 5 {
+			t.Fatalf("%v: b -> a received %v packets", profile, n)
+		}
+		// b uses wrong key for packets from a
+		wrongKey, _ := srtp.GenerateMasterKey(profile)
+		bLocal, _ := srtp.NewContext(profile, keyB)
+		bRemote, _ := srtp.NewContext(profile, wrongKey)
+		b.transport.SetContext(bLocal, bRemote)
+		if n := exchange(t, a, b, 2); n != 0 {
+			t.Fatalf("%v: packets with wrong key should be dropped", profile)
+		}
+		a.session.CloseSession()
+		b.session.CloseSession()
+	}
+}
@@ -0,0 +1,49 @@
+package stats
+
+import "time"
+
+// EModel estimates listening quality by simplified ITU-T G.107 E-model, only delay and packet loss impairments are
+// counted, other factors take their default values
+type EModel struct {
+	Ie  float64 // equipment impairment factor of codec
+	Bpl float64 // packet-loss robustness factor of codec
+}
+
+// impairment factors of ITU-T G.113 Appendix I
+var (
+	EModelG711  = EModel{Ie: 0, Bpl: 25.1} // with packet loss concealment
+	EModelAmrNb = EModel{Ie: 5, Bpl: 10}   // 12.2 kbit/s mode
+	EModelAmrWb = EModel{Ie: 0, Bpl: 13}   // narrowband equivalent
+)
+
+const (
+	defaultR      = 93.2  // R factor if no impairment at all
+	delayKneeInMs = 177.3 // delay impairment increases sharply beyond this one-way delay
+)
+
+// R returns the transmission rating factor given one-way mouth-to-ear delay and packet loss ratio (0 ~ 1)
+func (e EModel) R(delay time.Duration, loss float64) float64 {
+	d := float64(delay) / float64(time.Millisecond)
+	id := 0.024 * d
+	if d > delayKneeInMs {
+		id += 0.11 * (d - delayKneeInMs)
+	}
+	ppl := loss * 100
+	ieEff := e.Ie + (95-e.Ie)*ppl/(ppl+e.Bpl)
+	return defaultR - id - ieEff
+}
+
+// Mos converts R factor to mean opinion score ranging from 1.0 to 4.5
+func (e EModel) Mos(delay time.Duration, loss float64) float64 {
+	return MosOfR(e.R(delay, loss))
+}
+
+func MosOfR(r float64) float64 {
+	switch {
+	case r <= 0:
+		return 1
+	case r >= 100:
+		return 4.5
+	}
+	return 1 + 0.035*r + r*(r-60)*(100-r)*7e-6
+}
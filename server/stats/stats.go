@@ -0,0 +1,178 @@
+package stats
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// sequence number gaps to resync, refer to RFC 3550 A.1
+	maxDropout  = 3000
+	maxMisorder = 100
+
+	jitterGain = 16 // gain of inter-arrival jitter estimator, refer to RFC 3550 A.8
+
+	ntpEpochOffset = 2208988800  // seconds from 1900-01-01 to 1970-01-01
+	maxRoundTrip   = time.Minute // larger round-trip time is regarded as bogus
+)
+
+// Report of one rtp stream identified by ssrc, either measured locally or reported by peer's receiver report
+type Report struct {
+	Ssrc           uint32
+	Received       uint32        // packets received, zero if reported by peer
+	FractionLost   float64       // loss ratio (0 ~ 1) since last interval
+	CumulativeLost int32         // expected minus received since stream begins, can be negative if duplicated
+	Jitter         time.Duration // inter-arrival jitter
+	Rtt            time.Duration // round-trip time, zero if unknown
+}
+
+// Receiver collects statistics of an incoming rtp stream as RFC 3550 A.1, A.3 and A.8 describe,
+// Update is called for each received packet and Roll is called once every report interval
+type Receiver struct {
+	mutex sync.Mutex
+
+	clockRate   float64
+	initialized bool
+	start       time.Time // arrival of first packet, base of arrival timestamps
+
+	baseSeq  uint16
+	maxSeq   uint16
+	badSeq   uint32 // the sequence number expected if source restarts, larger than 0xffff if none
+	cycles   uint32 // shifted count of sequence number wraps
+	received uint32
+
+	expectedPrior uint32
+	receivedPrior uint32
+
+	transit float64
+	jitter  float64 // in timestamp units
+}
+
+func NewReceiver(clockRate uint32) *Receiver {
+	return &Receiver{
+		clockRate: float64(clockRate),
+		badSeq:    math.MaxUint32,
+	}
+}
+
+func (r *Receiver) reset(seq uint16) {
+	r.baseSeq = seq
+	r.maxSeq = seq
+	r.badSeq = math.MaxUint32
+	r.cycles = 0
+	r.received = 0
+	r.expectedPrior = 0
+	r.receivedPrior = 0
+}
+
+// Update the statistics with a received packet
+func (r *Receiver) Update(seq uint16, timestamp uint32, arrival time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.initialized {
+		r.initialized = true
+		r.start = arrival
+		r.reset(seq)
+		r.transit = r.arrivalTimestamp(arrival) - float64(timestamp)
+	}
+
+	delta := seq - r.maxSeq
+	switch {
+	case delta < maxDropout:
+		// in order, with permissible gap
+		if seq < r.maxSeq {
+			r.cycles += 1 << 16
+		}
+		r.maxSeq = seq
+	case delta <= math.MaxUint16-maxMisorder:
+		// the sequence number made a very large jump
+		if uint32(seq) != r.badSeq {
+			r.badSeq = uint32(seq + 1)
+			return
+		}
+		// two sequential packets, assume the other side restarted without telling us
+		r.reset(seq)
+	default:
+		// duplicated or reordered packet
+	}
+	r.received++
+
+	transit := r.arrivalTimestamp(arrival) - float64(timestamp)
+	d := math.Abs(transit - r.transit)
+	r.transit = transit
+	r.jitter += (d - r.jitter) / jitterGain
+}
+
+// arrivalTimestamp converts arrival time to timestamp units
+func (r *Receiver) arrivalTimestamp(arrival time.Time) float64 {
+	return arrival.Sub(r.start).Seconds() * r.clockRate
+}
+
+func (r *Receiver) expected() uint32 {
+	return r.cycles + uint32(r.maxSeq) - uint32(r.baseSeq) + 1
+}
+
+// Report returns statistics up to now, fraction lost is computed since last Roll
+func (r *Receiver) Report() (report Report) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.initialized {
+		return
+	}
+	expected := r.expected()
+	report.Received = r.received
+	report.CumulativeLost = int32(int64(expected) - int64(r.received))
+	expectedInterval := int64(expected) - int64(r.expectedPrior)
+	lostInterval := expectedInterval - (int64(r.received) - int64(r.receivedPrior))
+	if expectedInterval > 0 && lostInterval > 0 {
+		report.FractionLost = float64(lostInterval) / float64(expectedInterval)
+	}
+	report.Jitter = time.Duration(r.jitter / r.clockRate * float64(time.Second))
+	return
+}
+
+// Roll starts a new report interval
+func (r *Receiver) Roll() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.initialized {
+		return
+	}
+	r.expectedPrior = r.expected()
+	r.receivedPrior = r.received
+}
+
+// FromReceiverReport converts values of a report block in RTCP SR/RR
+func FromReceiverReport(ssrc uint32, fractionLost uint8, cumulativeLost uint32, jitter uint32,
+	clockRate uint32) Report {
+	lost := int32(cumulativeLost<<8) >> 8 // 24-bit signed integer
+	return Report{
+		Ssrc:           ssrc,
+		FractionLost:   float64(fractionLost) / 256,
+		CumulativeLost: lost,
+		Jitter:         time.Duration(float64(jitter) / float64(clockRate) * float64(time.Second)),
+	}
+}
+
+// NtpCompact returns the middle 32 bits of NTP timestamp of t, which is the format of LSR and DLSR
+func NtpCompact(t time.Time) uint32 {
+	seconds := uint64(t.Unix()+ntpEpochOffset) << 16
+	fraction := (uint64(t.Nanosecond()) << 16) / uint64(time.Second)
+	return uint32(seconds | fraction)
+}
+
+// RoundTrip computes round-trip time from LSR and DLSR of a report block received at now, refer to RFC 3550 6.4.1
+func RoundTrip(now time.Time, lsr, dlsr uint32) (rtt time.Duration, ok bool) {
+	if lsr == 0 {
+		// no sender report received by peer yet
+		return
+	}
+	compact := NtpCompact(now) - lsr - dlsr
+	rtt = time.Duration(uint64(compact) * uint64(time.Second) >> 16)
+	if rtt > maxRoundTrip {
+		// negative due to clock skew or bogus report
+		return 0, false
+	}
+	return rtt, true
+}
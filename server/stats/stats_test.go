@@ -0,0 +1,116 @@
+package stats_test
+
+import (
+	"github.com/appcrash/media/server/stats"
+	"math"
+	"testing"
+	"time"
+)
+
+const clockRate = 8000
+
+func TestReceiverLoss(t *testing.T) {
+	r := stats.NewReceiver(clockRate)
+	now := time.Now()
+	// 65530 ~ 9 with 3 and 5 lost, sequence number wraps
+	for seq := uint16(65530); seq != 10; seq++ {
+		if seq == 3 || seq == 5 {
+			continue
+		}
+		r.Update(seq, uint32(seq)*160, now.Add(time.Duration(seq)*20*time.Millisecond))
+	}
+	report := r.Report()
+	if report.Received != 14 || report.CumulativeLost != 2 {
+		t.Fatalf("wrong report: %+v", report)
+	}
+	if math.Abs(report.FractionLost-2.0/16) > 1e-9 {
+		t.Fatalf("wrong fraction lost: %v", report.FractionLost)
+	}
+
+	r.Roll()
+	for seq := uint16(10); seq < 20; seq++ {
+		r.Update(seq, uint32(seq)*160, now)
+	}
+	if report = r.Report(); report.FractionLost != 0 || report.CumulativeLost != 2 {
+		t.Fatalf("wrong report after roll: %+v", report)
+	}
+}
+
+func TestReceiverRestart(t *testing.T) {
+	r := stats.NewReceiver(clockRate)
+	now := time.Now()
+	for seq := uint16(100); seq < 110; seq++ {
+		r.Update(seq, 0, now)
+	}
+	// a single jump is ignored, two sequential packets resync
+	r.Update(30000, 0, now)
+	if report := r.Report(); report.Received != 10 || report.CumulativeLost != 0 {
+		t.Fatalf("jump should be ignored: %+v", report)
+	}
+	r.Update(30001, 0, now)
+	if report := r.Report(); report.Received != 1 || report.CumulativeLost != 0 {
+		t.Fatalf("should resync: %+v", report)
+	}
+}
+
+func TestReceiverJitter(t *testing.T) {
+	r := stats.NewReceiver(clockRate)
+	now := time.Now()
+	for i := 0; i < 1000; i++ {
+		// packets arrive 10ms earlier or later alternately
+		offset := 10 * time.Millisecond
+		if i%2 == 0 {
+			offset = -offset
+		}
+		r.Update(uint16(i), uint32(i)*160, now.Add(time.Duration(i)*20*time.Millisecond+offset))
+	}
+	if jitter := r.Report().Jitter; jitter < 19*time.Millisecond || jitter > 21*time.Millisecond {
+		t.Fatalf("jitter should converge to 20ms: %v", jitter)
+	}
+}
+
+func TestFromReceiverReport(t *testing.T) {
+	report := stats.FromReceiverReport(1, 64, 0xfffffe, 800, clockRate)
+	if report.FractionLost != 0.25 || report.CumulativeLost != -2 || report.Jitter != 100*time.Millisecond {
+		t.Fatalf("wrong report: %+v", report)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	sent := time.Now()
+	lsr := stats.NtpCompact(sent)
+	dlsr := uint32(1 << 15) // 500ms
+	rtt, ok := stats.RoundTrip(sent.Add(600*time.Millisecond), lsr, dlsr)
+	if !ok || rtt < 99*time.Millisecond || rtt > 101*time.Millisecond {
+		t.Fatalf("wrong rtt: %v", rtt)
+	}
+	if _, ok = stats.RoundTrip(sent.Add(400*time.Millisecond), lsr, dlsr); ok {
+		t.Fatal("negative rtt should be rejected")
+	}
+	if _, ok = stats.RoundTrip(sent.Add(time.Hour), lsr, dlsr); ok {
+		t.Fatal("bogus rtt should be rejected")
+	}
+	if _, ok = stats.RoundTrip(sent, 0, 0); ok {
+		t.Fatal("rtt is unknown without lsr")
+	}
+}
+
+func TestMos(t *testing.T) {
+	perfect := stats.EModelG711.Mos(0, 0)
+	if perfect < 4.4 || perfect > 4.5 {
+		t.Fatalf("mos of perfect g711 call: %v", perfect)
+	}
+	// well-known reference point: R of 93.2 minus delay impairment
+	if r := stats.EModelG711.R(100*time.Millisecond, 0); math.Abs(r-90.8) > 1e-9 {
+		t.Fatalf("wrong R: %v", r)
+	}
+	lossy := stats.EModelG711.Mos(150*time.Millisecond, 0.05)
+	delayed := stats.EModelG711.Mos(400*time.Millisecond, 0.05)
+	amr := stats.EModelAmrNb.Mos(150*time.Millisecond, 0.05)
+	if !(lossy < perfect && delayed < lossy && amr < lossy) {
+		t.Fatalf("mos should decrease with impairment: %v %v %v %v", perfect, lossy, delayed, amr)
+	}
+	if stats.MosOfR(-10) != 1 || stats.MosOfR(120) != 4.5 {
+		t.Fatal("mos should be clamped")
+	}
+}
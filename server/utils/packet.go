@@ -1,17 +1,22 @@
 package utils
 
-import "github.com/appcrash/GoRTP/rtp"
+import (
+	"github.com/appcrash/GoRTP/rtp"
+	"github.com/appcrash/media/server/rpc"
+)
 
 // RtpPacketList is either received RTP data packet or generated packets by codecs that can be readily put to
 // stack for transmission. audio data is usually one packet at a time as no pts is required, but video codecs can
 // build multiple packets of the same pts. those packets can be linked and send to rtp stack as a whole.
 type RtpPacketList struct {
-	Payload     []byte // rtp payload
-	RawBuffer   []byte // rtp payload + rtp header
-	PayloadType uint8
-	Pts         uint32 // presentation timestamp
-	PrevPts     uint32 // previous packet's pts
-	Marker      bool   // should mark-bit in rtp header be set?
+	Payload     []byte        // rtp payload
+	RawBuffer   []byte        // rtp payload + rtp header
+	PayloadType uint8         // graph nodes choose outgoing payload type by it, the current codec of stream if unknown
+	Codec       rpc.CodecType // codec of received packet by its payload type, RAW if not negotiated
+	Sequence    uint16        // sequence number of received packet
+	Pts         uint32        // presentation timestamp
+	PrevPts     uint32        // previous packet's pts
+	Marker      bool          // should mark-bit in rtp header be set?
 	Ssrc        uint32
 	Csrc        []uint32
 
@@ -26,6 +31,7 @@ func NewPacketListFromRtpPacket(packet *rtp.DataPacket) *RtpPacketList {
 		Payload:     packet.Payload(),
 		RawBuffer:   packet.Buffer()[:packet.InUse()],
 		PayloadType: packet.PayloadType(),
+		Sequence:    packet.Sequence(),
 		Pts:         packet.Timestamp(),
 		Marker:      packet.Marker(),
 		Ssrc:        packet.Ssrc(),
@@ -46,6 +52,8 @@ func (pl RtpPacketList) CloneSingle() *RtpPacketList {
 		Payload:     pl.Payload,
 		RawBuffer:   pl.RawBuffer,
 		PayloadType: pl.PayloadType,
+		Codec:       pl.Codec,
+		Sequence:    pl.Sequence,
 		Pts:         pl.Pts,
 		Marker:      pl.Marker,
 		Ssrc:        pl.Ssrc,
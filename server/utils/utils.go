@@ -158,3 +158,11 @@ func SetField(field, value reflect.Value) {
 		nf.Set(value)
 	}
 }
+
+// GetField returns a readable value of struct field even it is not exported, the field must be addressable
+func GetField(field reflect.Value) reflect.Value {
+	if field.CanInterface() {
+		return field
+	}
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}